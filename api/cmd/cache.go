@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"copier/config"
+	"copier/pkg/cache"
+	"log/slog"
+)
+
+// connectCache connects to Redis, returning nil so commands still run on a single instance without it
+func connectCache(conf *config.Config) cache.Cache {
+	redisCache, err := cache.NewRedisCache(&conf.Redis)
+	if err != nil {
+		slog.Warn("Redis unavailable, continuing without shared cache", "error", err)
+		return nil
+	}
+	return redisCache
+}
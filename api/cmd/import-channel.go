@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"copier/config"
+	"copier/internal/di"
+	"copier/internal/ingest"
+	"copier/internal/logger"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var importChannelID string
+
+var importChannelCmd = &cobra.Command{
+	Use:   "import-channel [result.json]",
+	Short: "Import historical signals from a Telegram Desktop channel export",
+	Long: `Runs every message of a Telegram Desktop JSON export through the channel's parser
+and stores the resulting signals as history with their original timestamps.
+Imported signals are never executed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importChannel,
+}
+
+func init() {
+	importChannelCmd.Flags().StringVar(&importChannelID, "channel", "", "ID of the copier channel to import into")
+	_ = importChannelCmd.MarkFlagRequired("channel")
+}
+
+func importChannel(cmd *cobra.Command, args []string) error {
+	conf := config.GetConfig()
+
+	logger.SetupLogger(conf.ServiceName, string(conf.Mode))
+
+	channelID, err := uuid.Parse(importChannelID)
+	if err != nil {
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open export: %w", err)
+	}
+	defer f.Close()
+
+	export, err := ingest.ParseTelegramExport(f)
+	if err != nil {
+		return err
+	}
+
+	db, err := config.NewPostgresDB()
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	container := di.NewContainer(db, connectCache(conf))
+	ctx := context.Background()
+
+	channel, err := container.ChannelService.GetChannelByID(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Importing channel history", "channel", channel.Name, "export", export.Name, "messages", len(export.Messages))
+
+	result, err := container.SignalService.ImportHistory(ctx, channel, export.SignalMessages(channel.ChannelID))
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	slog.Info("Channel history imported",
+		"messages", result.Messages,
+		"signals", result.Signals,
+		"suppressed", result.Suppressed,
+		"filtered", result.Filtered,
+		"follow_ups", result.FollowUps,
+		"duplicates", result.Duplicates,
+		"ignored", result.Ignored)
+	return nil
+}
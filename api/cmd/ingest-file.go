@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"copier/config"
+	"copier/internal/di"
+	"copier/internal/ingest"
+	"copier/internal/logger"
+	"copier/internal/signal"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+var ingestFollow bool
+
+var ingestFileCmd = &cobra.Command{
+	Use:   "ingest-file [path]",
+	Short: "Replay newline-delimited JSON channel messages from a file or stdin",
+	Long: `Reads one JSON message per line, e.g.
+{"channel_id":"-100123","message_id":"42","text":"BTC/USDT LONG ...","posted_at":"2024-05-01T10:00:00Z"}
+and runs each through the signal ingestion pipeline. Use "-" or omit the path to read stdin.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: ingestFile,
+}
+
+func init() {
+	ingestFileCmd.Flags().BoolVarP(&ingestFollow, "follow", "f", false, "keep waiting for lines appended to the file")
+}
+
+func ingestFile(cmd *cobra.Command, args []string) error {
+	conf := config.GetConfig()
+
+	logger.SetupLogger(conf.ServiceName, string(conf.Mode))
+
+	path := ingest.StdinPath
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	db, err := config.NewPostgresDB()
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	container := di.NewContainer(db, connectCache(conf))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-shutdown()
+		slog.Info("Shutdown signal received, stopping ingestion")
+		cancel()
+	}()
+
+	// Only the file source runs here; webhooks are served by serve-rest
+	sources := ingest.NewRegistry(func(ctx context.Context, msg signal.Message) error {
+		signals, err := container.SignalService.IngestMessage(ctx, msg)
+		if err != nil {
+			return err
+		}
+		slog.Info("Message ingested", "channel_id", msg.ChannelID, "message_id", msg.MessageID, "signals", len(signals))
+		return nil
+	})
+	sources.Register(ingest.NewFileSource(path, ingestFollow))
+
+	return sources.Run(ctx)
+}
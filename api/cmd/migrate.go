@@ -39,6 +39,15 @@ func migrate(cmd *cobra.Command, args []string) error {
 		&models.TradeSettings{},
 		&models.Package{},
 		&models.SubscribePackage{},
+		&models.Signal{},
+		&models.SignalAction{},
+		&models.SymbolAlias{},
+		&models.Order{},
+		&models.Position{},
+		&models.PositionEvent{},
+		&models.ReconciliationEvent{},
+		&models.RiskRejection{},
+		&models.ChannelRoute{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run auto-migration: %w", err)
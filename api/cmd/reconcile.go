@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"copier/config"
+	"copier/internal/di"
+	"copier/internal/logger"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var reconcilePlatformID string
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Compare stored orders and positions with the exchanges and repair drift",
+	Long: `Checks every platform holding active positions or working orders, or only the
+platform given with --platform. Orders and positions that changed on the exchange are
+repaired, orders the copier did not place are flagged, and every discrepancy is written
+to the reconciliation audit table.`,
+	Args: cobra.NoArgs,
+	RunE: reconcile,
+}
+
+func init() {
+	reconcileCmd.Flags().StringVar(&reconcilePlatformID, "platform", "", "ID of a single platform to reconcile")
+}
+
+func reconcile(cmd *cobra.Command, args []string) error {
+	conf := config.GetConfig()
+
+	logger.SetupLogger(conf.ServiceName, string(conf.Mode))
+
+	db, err := config.NewPostgresDB()
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	container := di.NewContainer(db, connectCache(conf))
+	ctx := context.Background()
+
+	if reconcilePlatformID != "" {
+		platformID, err := uuid.Parse(reconcilePlatformID)
+		if err != nil {
+			return fmt.Errorf("invalid platform ID: %w", err)
+		}
+		platform, err := container.PlatformRepo.FindByIDTyped(ctx, platformID)
+		if err != nil {
+			return err
+		}
+
+		events, err := container.ReconciliationService.ReconcilePlatform(ctx, platform)
+		repaired := 0
+		for _, event := range events {
+			if event.Repaired {
+				repaired++
+			}
+		}
+		slog.Info("Platform reconciled", "platform", platform.Name, "discrepancies", len(events), "repaired", repaired)
+		return err
+	}
+
+	report, err := container.ReconciliationService.Reconcile(ctx)
+	if report != nil {
+		slog.Info("Platforms reconciled",
+			"platforms", report.Platforms,
+			"discrepancies", report.Discrepancies,
+			"repaired", report.Repaired,
+			"failed", report.Failed)
+	}
+	if err != nil {
+		return fmt.Errorf("reconciliation failed: %w", err)
+	}
+	return nil
+}
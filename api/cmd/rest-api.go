@@ -6,6 +6,7 @@ import (
 	"copier/http/routes"
 	"copier/internal/database/models"
 	"copier/internal/di"
+	"copier/internal/ingest"
 	"copier/internal/logger"
 	"fmt"
 	"log/slog"
@@ -44,6 +45,15 @@ func serveRest(cmd *cobra.Command, args []string) error {
 		&models.TradeSettings{},
 		&models.Package{},
 		&models.SubscribePackage{},
+		&models.Signal{},
+		&models.SignalAction{},
+		&models.SymbolAlias{},
+		&models.Order{},
+		&models.Position{},
+		&models.PositionEvent{},
+		&models.ReconciliationEvent{},
+		&models.RiskRejection{},
+		&models.ChannelRoute{},
 	)
 	if err != nil {
 		slog.Error("Failed to run auto-migration", "error", err)
@@ -56,7 +66,27 @@ func serveRest(cmd *cobra.Command, args []string) error {
 		"database", conf.Database.Database)
 
 	// Initialize DI Container
-	container := di.NewContainer(db)
+	container := di.NewContainer(db, connectCache(conf))
+	if conf.Ingest.File != "" {
+		container.SignalSources.Register(ingest.NewFileSource(conf.Ingest.File, true))
+	}
+
+	ingestCtx, stopIngest := context.WithCancel(context.Background())
+	defer stopIngest()
+	go func() {
+		if err := container.SignalSources.Run(ingestCtx); err != nil {
+			slog.Error("Signal ingestion stopped", "error", err)
+		}
+	}()
+	if conf.Execution.Enabled {
+		go container.ExecutionService.RunWorker(ingestCtx, conf.Execution.Interval)
+	}
+	if conf.Reconcile.Enabled {
+		go container.ReconciliationService.RunWorker(ingestCtx, conf.Reconcile.Interval)
+	}
+	if conf.Expiry.Enabled {
+		go container.ExpiryService.RunWorker(ingestCtx, conf.Expiry.Interval)
+	}
 
 	mux := http.NewServeMux()
 	server := &http.Server{
@@ -16,6 +16,9 @@ func init() {
 	RootCmd.AddCommand(serveRestCmd)
 	RootCmd.AddCommand(migrateCmd)
 	RootCmd.AddCommand(seedCmd)
+	RootCmd.AddCommand(ingestFileCmd)
+	RootCmd.AddCommand(importChannelCmd)
+	RootCmd.AddCommand(reconcileCmd)
 }
 
 func Execute() {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 )
 
 type Mode string
@@ -34,6 +35,56 @@ type RedisConfig struct {
 	MinIdle  int    `envconfig:"REDIS_MIN_IDLE" default:"5"`
 }
 
+type IngestConfig struct {
+	// File is an NDJSON file (or "-" for stdin) tailed for messages while the API is running
+	File string `envconfig:"INGEST_FILE"`
+}
+
+type DedupConfig struct {
+	// Window is how long an identical signal in the same channel is treated as a duplicate
+	Window time.Duration `envconfig:"DEDUP_WINDOW" default:"15m"`
+	// ReplayWindow is how long delivered message IDs are remembered to reject redeliveries
+	ReplayWindow time.Duration `envconfig:"DEDUP_REPLAY_WINDOW" default:"72h"`
+}
+
+type ExchangeConfig struct {
+	BinanceFuturesURL        string `envconfig:"BINANCE_FUTURES_URL" default:"https://fapi.binance.com"`
+	BinanceFuturesTestnetURL string `envconfig:"BINANCE_FUTURES_TESTNET_URL" default:"https://testnet.binancefuture.com"`
+	// RecvWindow is how long after signing the exchange may accept a request
+	RecvWindow time.Duration `envconfig:"EXCHANGE_RECV_WINDOW" default:"5s"`
+	// SymbolInfoTTL is how long trading rules loaded from an exchange are cached
+	SymbolInfoTTL time.Duration `envconfig:"SYMBOL_INFO_TTL" default:"1h"`
+
+	// Paper trading simulation; fees and slippage are fractions of notional
+	PaperBalance  float64 `envconfig:"PAPER_BALANCE" default:"10000"`
+	PaperMakerFee float64 `envconfig:"PAPER_MAKER_FEE" default:"0.0002"`
+	PaperTakerFee float64 `envconfig:"PAPER_TAKER_FEE" default:"0.0004"`
+	PaperSlippage float64 `envconfig:"PAPER_SLIPPAGE" default:"0.0005"`
+}
+
+type ExecutionConfig struct {
+	// Enabled starts the worker that turns due signals into exchange orders
+	Enabled bool `envconfig:"EXECUTION_ENABLED" default:"true"`
+	// Interval is how often due signals are executed and resting orders are polled
+	Interval time.Duration `envconfig:"EXECUTION_INTERVAL" default:"2s"`
+	// MaxSignalAge skips signals that become due longer than this after they were posted
+	MaxSignalAge time.Duration `envconfig:"EXECUTION_MAX_SIGNAL_AGE" default:"10m"`
+}
+
+type ReconcileConfig struct {
+	// Enabled starts the worker that compares stored orders and positions with the exchanges
+	Enabled bool `envconfig:"RECONCILE_ENABLED" default:"true"`
+	// Interval is how often every active platform is reconciled
+	Interval time.Duration `envconfig:"RECONCILE_INTERVAL" default:"5m"`
+}
+
+type ExpiryConfig struct {
+	// Enabled starts the worker that cancels timed-out entries and closes positions held too long
+	Enabled bool `envconfig:"EXPIRY_ENABLED" default:"true"`
+	// Interval is how often expiry rules are enforced
+	Interval time.Duration `envconfig:"EXPIRY_INTERVAL" default:"1m"`
+}
+
 type CorsOrigin struct {
 	Origin []string `envconfig:"CORS_ORIGIN"`
 }
@@ -53,6 +104,18 @@ type Config struct {
 
 	Redis RedisConfig
 
+	Ingest IngestConfig
+
+	Dedup DedupConfig
+
+	Exchange ExchangeConfig
+
+	Execution ExecutionConfig
+
+	Reconcile ReconcileConfig
+
+	Expiry ExpiryConfig
+
 	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
 
 	Cors CorsOrigin
@@ -35,6 +35,23 @@ func LoadConfig() error {
 	viper.SetDefault("DB_TIMEZONE", "UTC")
 	viper.SetDefault("REDIS_HOST", "localhost")
 	viper.SetDefault("REDIS_PORT", 6379)
+	viper.SetDefault("DEDUP_WINDOW", "15m")
+	viper.SetDefault("DEDUP_REPLAY_WINDOW", "72h")
+	viper.SetDefault("BINANCE_FUTURES_URL", "https://fapi.binance.com")
+	viper.SetDefault("BINANCE_FUTURES_TESTNET_URL", "https://testnet.binancefuture.com")
+	viper.SetDefault("EXCHANGE_RECV_WINDOW", "5s")
+	viper.SetDefault("SYMBOL_INFO_TTL", "1h")
+	viper.SetDefault("PAPER_BALANCE", 10000)
+	viper.SetDefault("PAPER_MAKER_FEE", 0.0002)
+	viper.SetDefault("PAPER_TAKER_FEE", 0.0004)
+	viper.SetDefault("PAPER_SLIPPAGE", 0.0005)
+	viper.SetDefault("EXECUTION_ENABLED", true)
+	viper.SetDefault("EXECUTION_INTERVAL", "2s")
+	viper.SetDefault("EXECUTION_MAX_SIGNAL_AGE", "10m")
+	viper.SetDefault("RECONCILE_ENABLED", true)
+	viper.SetDefault("RECONCILE_INTERVAL", "5m")
+	viper.SetDefault("EXPIRY_ENABLED", true)
+	viper.SetDefault("EXPIRY_INTERVAL", "1m")
 
 	viper.AutomaticEnv()
 
@@ -73,6 +90,42 @@ func LoadConfig() error {
 			ConnMaxLifetime: viper.GetInt("DB_CONN_MAX_LIFETIME"),
 		},
 
+		Ingest: IngestConfig{
+			File: viper.GetString("INGEST_FILE"),
+		},
+
+		Dedup: DedupConfig{
+			Window:       viper.GetDuration("DEDUP_WINDOW"),
+			ReplayWindow: viper.GetDuration("DEDUP_REPLAY_WINDOW"),
+		},
+
+		Exchange: ExchangeConfig{
+			BinanceFuturesURL:        viper.GetString("BINANCE_FUTURES_URL"),
+			BinanceFuturesTestnetURL: viper.GetString("BINANCE_FUTURES_TESTNET_URL"),
+			RecvWindow:               viper.GetDuration("EXCHANGE_RECV_WINDOW"),
+			SymbolInfoTTL:            viper.GetDuration("SYMBOL_INFO_TTL"),
+			PaperBalance:             viper.GetFloat64("PAPER_BALANCE"),
+			PaperMakerFee:            viper.GetFloat64("PAPER_MAKER_FEE"),
+			PaperTakerFee:            viper.GetFloat64("PAPER_TAKER_FEE"),
+			PaperSlippage:            viper.GetFloat64("PAPER_SLIPPAGE"),
+		},
+
+		Execution: ExecutionConfig{
+			Enabled:      viper.GetBool("EXECUTION_ENABLED"),
+			Interval:     viper.GetDuration("EXECUTION_INTERVAL"),
+			MaxSignalAge: viper.GetDuration("EXECUTION_MAX_SIGNAL_AGE"),
+		},
+
+		Reconcile: ReconcileConfig{
+			Enabled:  viper.GetBool("RECONCILE_ENABLED"),
+			Interval: viper.GetDuration("RECONCILE_INTERVAL"),
+		},
+
+		Expiry: ExpiryConfig{
+			Enabled:  viper.GetBool("EXPIRY_ENABLED"),
+			Interval: viper.GetDuration("EXPIRY_INTERVAL"),
+		},
+
 		Cors: CorsOrigin{
 			Origin: viper.GetStringSlice("CORS_ORIGIN"),
 		},
@@ -15,10 +15,17 @@ type ChannelRepository interface {
 	BaseRepository
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error)
 	FindByChannelID(ctx context.Context, channelID string) (*models.Channel, error)
+	FindAllByChannelID(ctx context.Context, channelID string) ([]*models.Channel, error)
 	FindByUserAndName(ctx context.Context, userID uuid.UUID, name string) (*models.Channel, error)
 	FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Channel, error)
 	CreateChannel(ctx context.Context, channel *models.Channel) error
 	UpdateChannel(ctx context.Context, id uuid.UUID, update *models.Channel) error
+	UpdateParserRules(ctx context.Context, id uuid.UUID, rules []models.ParserRule) error
+	UpdateWebhookSecret(ctx context.Context, id uuid.UUID, secret string) error
+	UpdateFilter(ctx context.Context, id uuid.UUID, filter models.ChannelFilter) error
+	UpdateExpiry(ctx context.Context, id uuid.UUID, expiry models.ExpiryRules) error
+	FindRoutes(ctx context.Context, channelID uuid.UUID) ([]*models.ChannelRoute, error)
+	ReplaceRoutes(ctx context.Context, channelID uuid.UUID, routes []*models.ChannelRoute) error
 	DeleteChannel(ctx context.Context, id uuid.UUID) error
 	DeleteChannelsByUser(ctx context.Context, userID uuid.UUID) error
 	FindAllByUser(ctx context.Context, userID uuid.UUID, skip, limit int) ([]*models.Channel, error)
@@ -64,6 +71,17 @@ func (r *channelRepository) FindByChannelID(ctx context.Context, channelID strin
 	return &channel, nil
 }
 
+// FindAllByChannelID finds every user's channel subscribed to the same source channel ID
+func (r *channelRepository) FindAllByChannelID(ctx context.Context, channelID string) ([]*models.Channel, error) {
+	var channels []*models.Channel
+	err := r.db.WithContext(ctx).Where("channel_id = ?", channelID).Find(&channels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find channels by channel ID: %w", err)
+	}
+
+	return channels, nil
+}
+
 // FindByUserAndName finds a channel by user ID and name
 func (r *channelRepository) FindByUserAndName(ctx context.Context, userID uuid.UUID, name string) (*models.Channel, error) {
 	var channel models.Channel
@@ -112,9 +130,83 @@ func (r *channelRepository) UpdateChannel(ctx context.Context, id uuid.UUID, upd
 	return nil
 }
 
-// DeleteChannel deletes a channel by ID
+// UpdateParserRules replaces the parser rules of a channel
+func (r *channelRepository) UpdateParserRules(ctx context.Context, id uuid.UUID, rules []models.ParserRule) error {
+	err := r.db.WithContext(ctx).Model(&models.Channel{ID: id}).Select("parser_rules").Updates(&models.Channel{ParserRules: rules}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update channel parser rules: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFilter replaces the signal filter policy of a channel
+func (r *channelRepository) UpdateFilter(ctx context.Context, id uuid.UUID, filter models.ChannelFilter) error {
+	err := r.db.WithContext(ctx).Model(&models.Channel{ID: id}).Select("filter").Updates(&models.Channel{Filter: filter}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update channel filter: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateExpiry replaces the expiry rules of a channel
+func (r *channelRepository) UpdateExpiry(ctx context.Context, id uuid.UUID, expiry models.ExpiryRules) error {
+	err := r.db.WithContext(ctx).Model(&models.Channel{ID: id}).Select("expiry").Updates(&models.Channel{Expiry: expiry}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update channel expiry: %w", err)
+	}
+
+	return nil
+}
+
+// FindRoutes retrieves the platform routes of a channel, including disabled ones
+func (r *channelRepository) FindRoutes(ctx context.Context, channelID uuid.UUID) ([]*models.ChannelRoute, error) {
+	var routes []*models.ChannelRoute
+	err := r.db.WithContext(ctx).Where("channel_id = ?", channelID).Order("created_at asc").Find(&routes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find channel routes: %w", err)
+	}
+
+	return routes, nil
+}
+
+// ReplaceRoutes replaces all platform routes of a channel in one transaction
+func (r *channelRepository) ReplaceRoutes(ctx context.Context, channelID uuid.UUID, routes []*models.ChannelRoute) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("channel_id = ?", channelID).Delete(&models.ChannelRoute{}).Error; err != nil {
+			return err
+		}
+		if len(routes) == 0 {
+			return nil
+		}
+		return tx.Create(routes).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace channel routes: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWebhookSecret replaces the secret used to verify a channel's webhook deliveries
+func (r *channelRepository) UpdateWebhookSecret(ctx context.Context, id uuid.UUID, secret string) error {
+	err := r.db.WithContext(ctx).Model(&models.Channel{ID: id}).Update("webhook_secret", secret).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook secret: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteChannel deletes a channel by ID together with its routes
 func (r *channelRepository) DeleteChannel(ctx context.Context, id uuid.UUID) error {
-	err := r.db.WithContext(ctx).Delete(&models.Channel{}, id).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("channel_id = ?", id).Delete(&models.ChannelRoute{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Channel{}, id).Error
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete channel: %w", err)
 	}
@@ -122,9 +214,14 @@ func (r *channelRepository) DeleteChannel(ctx context.Context, id uuid.UUID) err
 	return nil
 }
 
-// DeleteChannelsByUser deletes all channels for a specific user
+// DeleteChannelsByUser deletes all channels for a specific user together with their routes
 func (r *channelRepository) DeleteChannelsByUser(ctx context.Context, userID uuid.UUID) error {
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Channel{}).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ChannelRoute{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.Channel{}).Error
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete channels by user: %w", err)
 	}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderRepository defines order-specific repository operations
+type OrderRepository interface {
+	BaseRepository
+	FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Order, error)
+	FindBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.Order, error)
+	FindBySignalAndPlatform(ctx context.Context, signalID, platformID uuid.UUID) ([]*models.Order, error)
+	FindByPosition(ctx context.Context, positionID uuid.UUID) ([]*models.Order, error)
+	FindWorking(ctx context.Context, limit int) ([]*models.Order, error)
+	FindWorkingByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Order, error)
+	FindByExchangeOrderIDs(ctx context.Context, platformID uuid.UUID, exchangeOrderIDs []string) ([]*models.Order, error)
+	FindUnconfirmed(ctx context.Context, submittedBefore time.Time, limit int) ([]*models.Order, error)
+	CreateOrder(ctx context.Context, order *models.Order) error
+	UpdateOrderColumns(ctx context.Context, id uuid.UUID, update *models.Order, columns ...string) error
+}
+
+// orderRepository implements OrderRepository interface
+type orderRepository struct {
+	BaseRepository
+	db *gorm.DB
+}
+
+// NewOrderRepository creates a new order repository instance
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{
+		BaseRepository: NewBaseRepository(db),
+		db:             db,
+	}
+}
+
+// FindByIDTyped finds an order by ID and returns typed Order struct
+func (r *orderRepository) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Order, error) {
+	var order models.Order
+	err := r.db.WithContext(ctx).First(&order, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("order not found with ID: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find order by ID: %w", err)
+	}
+
+	return &order, nil
+}
+
+// FindBySignal finds every order derived from a signal in creation order
+func (r *orderRepository) FindBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.WithContext(ctx).Where("signal_id = ?", signalID).Order("created_at asc").Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by signal: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindBySignalAndPlatform finds the orders of a signal on one platform, entries first then by leg
+func (r *orderRepository) FindBySignalAndPlatform(ctx context.Context, signalID, platformID uuid.UUID) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.WithContext(ctx).
+		Where("signal_id = ? AND platform_id = ?", signalID, platformID).
+		Order("created_at asc, leg asc").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by signal and platform: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindByPosition finds every order of a position, entries first then by leg
+func (r *orderRepository) FindByPosition(ctx context.Context, positionID uuid.UUID) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.WithContext(ctx).
+		Where("position_id = ?", positionID).
+		Order("created_at asc, leg asc").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by position: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindWorking finds orders resting on an exchange, least recently checked first
+func (r *orderRepository) FindWorking(ctx context.Context, limit int) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", models.WorkingOrderStatuses).
+		Order("updated_at asc").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find working orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindWorkingByPlatform finds every order of a platform that is stored as resting on its exchange
+func (r *orderRepository) FindWorkingByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.WithContext(ctx).
+		Where("platform_id = ? AND status IN ?", platformID, models.WorkingOrderStatuses).
+		Order("created_at asc").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find working orders by platform: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindByExchangeOrderIDs finds the orders of a platform with the given exchange order IDs
+func (r *orderRepository) FindByExchangeOrderIDs(ctx context.Context, platformID uuid.UUID, exchangeOrderIDs []string) ([]*models.Order, error) {
+	var orders []*models.Order
+	if len(exchangeOrderIDs) == 0 {
+		return orders, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("platform_id = ? AND exchange_order_id IN ?", platformID, exchangeOrderIDs).
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by exchange order IDs: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindUnconfirmed finds orders sent before submittedBefore whose placement outcome is unknown,
+// oldest first
+func (r *orderRepository) FindUnconfirmed(ctx context.Context, submittedBefore time.Time, limit int) ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND submitted_at IS NOT NULL AND submitted_at < ?", models.OrderStatusPending, submittedBefore).
+		Order("submitted_at asc").
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unconfirmed orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// CreateOrder creates a new order
+func (r *orderRepository) CreateOrder(ctx context.Context, order *models.Order) error {
+	err := r.db.WithContext(ctx).Create(order).Error
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateOrderColumns writes the given columns of update, including zero values
+func (r *orderRepository) UpdateOrderColumns(ctx context.Context, id uuid.UUID, update *models.Order, columns ...string) error {
+	err := r.db.WithContext(ctx).Model(&models.Order{ID: id}).Select(columns).Updates(update).Error
+	if err != nil {
+		return fmt.Errorf("failed to update order columns: %w", err)
+	}
+
+	return nil
+}
@@ -17,6 +17,7 @@ type PlatformRepository interface {
 	FindByUserAndName(ctx context.Context, userID uuid.UUID, name string) (*models.Platform, error)
 	FindByAPIKey(ctx context.Context, apiKey string) (*models.Platform, error)
 	FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Platform, error)
+	FindActive(ctx context.Context) ([]*models.Platform, error)
 	CreatePlatform(ctx context.Context, platform *models.Platform) error
 	UpdatePlatform(ctx context.Context, id uuid.UUID, update *models.Platform) error
 	DeletePlatform(ctx context.Context, id uuid.UUID) error
@@ -93,6 +94,22 @@ func (r *platformRepository) FindByIDTyped(ctx context.Context, id uuid.UUID) (*
 	return &platform, nil
 }
 
+// FindActive finds the platforms that hold active positions or working orders
+func (r *platformRepository) FindActive(ctx context.Context) ([]*models.Platform, error) {
+	var platforms []*models.Platform
+	err := r.db.WithContext(ctx).
+		Where("id IN (?) OR id IN (?)",
+			r.db.Model(&models.Position{}).Select("platform_id").Where("status IN ?", models.ActivePositionStatuses),
+			r.db.Model(&models.Order{}).Select("platform_id").Where("status IN ?", models.WorkingOrderStatuses)).
+		Order("created_at asc").
+		Find(&platforms).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active platforms: %w", err)
+	}
+
+	return platforms, nil
+}
+
 // CreatePlatform creates a new platform
 func (r *platformRepository) CreatePlatform(ctx context.Context, platform *models.Platform) error {
 	err := r.db.WithContext(ctx).Create(platform).Error
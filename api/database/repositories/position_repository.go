@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PositionRepository defines position-specific repository operations
+type PositionRepository interface {
+	BaseRepository
+	FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Position, error)
+	FindAllByUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus, skip, limit int) ([]*models.Position, error)
+	CountByUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus) (int64, error)
+	FindActiveBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.Position, error)
+	FindTrailing(ctx context.Context, limit int) ([]*models.Position, error)
+	FindActiveByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Position, error)
+	FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.Position, error)
+	SumRealizedPnL(ctx context.Context, userID uuid.UUID, closedSince time.Time) (float64, error)
+	FindEntryExpired(ctx context.Context, now time.Time, limit int) ([]*models.Position, error)
+	FindHoldingExpired(ctx context.Context, now time.Time, limit int) ([]*models.Position, error)
+	FindAwaitingEntryAtTP1(ctx context.Context, limit int) ([]*models.Position, error)
+	CreatePosition(ctx context.Context, position *models.Position, event *models.PositionEvent) error
+	SaveTransition(ctx context.Context, position *models.Position, from models.PositionStatus, event *models.PositionEvent, columns ...string) (bool, error)
+	UpdatePositionColumns(ctx context.Context, id uuid.UUID, update *models.Position, columns ...string) error
+}
+
+// positionRepository implements PositionRepository interface
+type positionRepository struct {
+	BaseRepository
+	db *gorm.DB
+}
+
+// NewPositionRepository creates a new position repository instance
+func NewPositionRepository(db *gorm.DB) PositionRepository {
+	return &positionRepository{
+		BaseRepository: NewBaseRepository(db),
+		db:             db,
+	}
+}
+
+// FindByIDTyped finds a position by ID with its orders and status history
+func (r *positionRepository) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Position, error) {
+	var position models.Position
+	err := r.db.WithContext(ctx).Preload("Orders", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).First(&position, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("position not found with ID: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find position by ID: %w", err)
+	}
+
+	return &position, nil
+}
+
+// FindAllByUser retrieves a user's positions, newest first, optionally restricted to some statuses
+func (r *positionRepository) FindAllByUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus, skip, limit int) ([]*models.Position, error) {
+	var positions []*models.Position
+	query := r.byUser(ctx, userID, statuses).Order("created_at desc")
+	if skip > 0 {
+		query = query.Offset(skip)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find positions by user: %w", err)
+	}
+
+	return positions, nil
+}
+
+// CountByUser returns the number of a user's positions, optionally restricted to some statuses
+func (r *positionRepository) CountByUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus) (int64, error) {
+	var count int64
+	err := r.byUser(ctx, userID, statuses).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count positions by user: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *positionRepository) byUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.Position{}).Where("user_id = ?", userID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	return query
+}
+
+// FindActiveBySignal finds the positions of a signal that still hold or await exposure
+func (r *positionRepository) FindActiveBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Where("signal_id = ? AND status IN ?", signalID, models.ActivePositionStatuses).
+		Order("created_at asc").
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions by signal: %w", err)
+	}
+
+	return positions, nil
+}
+
+// FindActiveByPlatform finds the positions of a platform that still hold or await exposure
+func (r *positionRepository) FindActiveByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Where("platform_id = ? AND status IN ?", platformID, models.ActivePositionStatuses).
+		Order("created_at asc").
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions by platform: %w", err)
+	}
+
+	return positions, nil
+}
+
+// FindActiveByUser finds the positions of a user that still hold or await exposure, with their orders
+func (r *positionRepository) FindActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Preload("Orders", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		Where("user_id = ? AND status IN ?", userID, models.ActivePositionStatuses).
+		Order("created_at asc").
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find active positions by user: %w", err)
+	}
+
+	return positions, nil
+}
+
+// SumRealizedPnL adds up the profit realised by a user's positions that closed since closedSince
+func (r *positionRepository) SumRealizedPnL(ctx context.Context, userID uuid.UUID, closedSince time.Time) (float64, error) {
+	var total float64
+	err := r.db.WithContext(ctx).Model(&models.Position{}).
+		Where("user_id = ? AND closed_at >= ?", userID, closedSince).
+		Select("COALESCE(SUM(realized_pnl), 0)").
+		Scan(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum realised profit: %w", err)
+	}
+
+	return total, nil
+}
+
+// FindTrailing finds open positions whose percentage trailing stop is active, i.e. after TP1
+func (r *positionRepository) FindTrailing(ctx context.Context, limit int) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND rule_trailing_stop_percentage > 0 AND targets_hit >= 1",
+			[]models.PositionStatus{models.PositionStatusOpen, models.PositionStatusPartiallyClosed}).
+		Order("updated_at asc").
+		Limit(limit).
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find trailing positions: %w", err)
+	}
+
+	return positions, nil
+}
+
+// FindEntryExpired finds positions still waiting on entries whose entry time-out has passed
+func (r *positionRepository) FindEntryExpired(ctx context.Context, now time.Time, limit int) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND entry_expires_at < ?", awaitingEntryStatuses, now).
+		Order("entry_expires_at asc").
+		Limit(limit).
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find positions with expired entries: %w", err)
+	}
+
+	return positions, nil
+}
+
+// FindHoldingExpired finds positions holding exposure past their maximum holding time
+func (r *positionRepository) FindHoldingExpired(ctx context.Context, now time.Time, limit int) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND expires_at < ?", []models.PositionStatus{
+			models.PositionStatusPartiallyFilled,
+			models.PositionStatusOpen,
+			models.PositionStatusPartiallyClosed,
+		}, now).
+		Order("expires_at asc").
+		Limit(limit).
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find positions past their holding time: %w", err)
+	}
+
+	return positions, nil
+}
+
+// FindAwaitingEntryAtTP1 finds positions still waiting on entries that are cancelled once the
+// price reaches TP1, with their orders
+func (r *positionRepository) FindAwaitingEntryAtTP1(ctx context.Context, limit int) ([]*models.Position, error) {
+	var positions []*models.Position
+	err := r.db.WithContext(ctx).
+		Preload("Orders", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		Where("status IN ? AND expiry_cancel_entry_at_tp1 = ?", awaitingEntryStatuses, true).
+		Order("created_at asc").
+		Limit(limit).
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find positions awaiting entry: %w", err)
+	}
+
+	return positions, nil
+}
+
+// awaitingEntryStatuses lists the statuses of positions whose entries may still be working
+var awaitingEntryStatuses = []models.PositionStatus{
+	models.PositionStatusPendingEntry,
+	models.PositionStatusPartiallyFilled,
+}
+
+// CreatePosition creates a new position together with the event that opened its history
+func (r *positionRepository) CreatePosition(ctx context.Context, position *models.Position, event *models.PositionEvent) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Orders", "Events").Create(position).Error; err != nil {
+			return err
+		}
+		event.PositionID = position.ID
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create position: %w", err)
+	}
+
+	return nil
+}
+
+// SaveTransition stores the given columns of a position and the event that changed them
+// atomically, only while the position still has the status it was loaded with. It reports whether
+// the position was saved; false means another writer changed its status first.
+func (r *positionRepository) SaveTransition(ctx context.Context, position *models.Position, from models.PositionStatus, event *models.PositionEvent, columns ...string) (bool, error) {
+	saved := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		position.UpdatedAt = time.Now()
+		result := tx.Model(&models.Position{}).
+			Where("id = ? AND status = ?", position.ID, from).
+			Select(append(slices.Clone(columns), "updated_at")).
+			Updates(position)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		saved = true
+		event.PositionID = position.ID
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to save position transition: %w", err)
+	}
+
+	return saved, nil
+}
+
+// UpdatePositionColumns writes the given columns of update, including zero values
+func (r *positionRepository) UpdatePositionColumns(ctx context.Context, id uuid.UUID, update *models.Position, columns ...string) error {
+	err := r.db.WithContext(ctx).Model(&models.Position{ID: id}).Select(columns).Updates(update).Error
+	if err != nil {
+		return fmt.Errorf("failed to update position columns: %w", err)
+	}
+
+	return nil
+}
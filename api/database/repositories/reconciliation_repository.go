@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"copier/internal/database/models"
+
+	"gorm.io/gorm"
+)
+
+// ReconciliationRepository defines reconciliation audit repository operations
+type ReconciliationRepository interface {
+	BaseRepository
+	CreateEvents(ctx context.Context, events []*models.ReconciliationEvent) error
+}
+
+// reconciliationRepository implements ReconciliationRepository interface
+type reconciliationRepository struct {
+	BaseRepository
+	db *gorm.DB
+}
+
+// NewReconciliationRepository creates a new reconciliation repository instance
+func NewReconciliationRepository(db *gorm.DB) ReconciliationRepository {
+	return &reconciliationRepository{
+		BaseRepository: NewBaseRepository(db),
+		db:             db,
+	}
+}
+
+// CreateEvents stores the discrepancies found by one reconciliation of a platform
+func (r *reconciliationRepository) CreateEvents(ctx context.Context, events []*models.ReconciliationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&events).Error; err != nil {
+		return fmt.Errorf("failed to create reconciliation events: %w", err)
+	}
+
+	return nil
+}
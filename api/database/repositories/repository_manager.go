@@ -12,6 +12,12 @@ type RepositoryManager struct {
 	TradeSettingsRepo    TradeSettingsRepository
 	PackageRepo          PackageRepository
 	SubscribePackageRepo SubscribePackageRepository
+	SignalRepo           SignalRepository
+	SymbolAliasRepo      SymbolAliasRepository
+	OrderRepo            OrderRepository
+	PositionRepo         PositionRepository
+	ReconciliationRepo   ReconciliationRepository
+	RiskRepo             RiskRepository
 }
 
 // NewRepositoryManager creates a new repository manager with all repositories
@@ -23,6 +29,12 @@ func NewRepositoryManager(db *gorm.DB) *RepositoryManager {
 		TradeSettingsRepo:    NewTradeSettingsRepository(db),
 		PackageRepo:          NewPackageRepository(db),
 		SubscribePackageRepo: NewSubscribePackageRepository(db),
+		SignalRepo:           NewSignalRepository(db),
+		SymbolAliasRepo:      NewSymbolAliasRepository(db),
+		OrderRepo:            NewOrderRepository(db),
+		PositionRepo:         NewPositionRepository(db),
+		ReconciliationRepo:   NewReconciliationRepository(db),
+		RiskRepo:             NewRiskRepository(db),
 	}
 }
 
@@ -55,3 +67,33 @@ func (rm *RepositoryManager) GetPackageRepository() PackageRepository {
 func (rm *RepositoryManager) GetSubscribePackageRepository() SubscribePackageRepository {
 	return rm.SubscribePackageRepo
 }
+
+// GetSignalRepository returns the signal repository
+func (rm *RepositoryManager) GetSignalRepository() SignalRepository {
+	return rm.SignalRepo
+}
+
+// GetSymbolAliasRepository returns the symbol alias repository
+func (rm *RepositoryManager) GetSymbolAliasRepository() SymbolAliasRepository {
+	return rm.SymbolAliasRepo
+}
+
+// GetOrderRepository returns the order repository
+func (rm *RepositoryManager) GetOrderRepository() OrderRepository {
+	return rm.OrderRepo
+}
+
+// GetPositionRepository returns the position repository
+func (rm *RepositoryManager) GetPositionRepository() PositionRepository {
+	return rm.PositionRepo
+}
+
+// GetReconciliationRepository returns the reconciliation repository
+func (rm *RepositoryManager) GetReconciliationRepository() ReconciliationRepository {
+	return rm.ReconciliationRepo
+}
+
+// GetRiskRepository returns the risk repository
+func (rm *RepositoryManager) GetRiskRepository() RiskRepository {
+	return rm.RiskRepo
+}
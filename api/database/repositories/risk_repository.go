@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskRepository defines risk guard repository operations
+type RiskRepository interface {
+	BaseRepository
+	CreateRejection(ctx context.Context, rejection *models.RiskRejection) error
+	FindRejectionsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*models.RiskRejection, error)
+}
+
+// riskRepository implements RiskRepository interface
+type riskRepository struct {
+	BaseRepository
+	db *gorm.DB
+}
+
+// NewRiskRepository creates a new risk repository instance
+func NewRiskRepository(db *gorm.DB) RiskRepository {
+	return &riskRepository{
+		BaseRepository: NewBaseRepository(db),
+		db:             db,
+	}
+}
+
+// CreateRejection stores a signal the risk guard refused to copy
+func (r *riskRepository) CreateRejection(ctx context.Context, rejection *models.RiskRejection) error {
+	if err := r.db.WithContext(ctx).Create(rejection).Error; err != nil {
+		return fmt.Errorf("failed to create risk rejection: %w", err)
+	}
+
+	return nil
+}
+
+// FindRejectionsByUser retrieves a user's most recent risk rejections, newest first
+func (r *riskRepository) FindRejectionsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*models.RiskRejection, error) {
+	var rejections []*models.RiskRejection
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&rejections).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find risk rejections by user: %w", err)
+	}
+
+	return rejections, nil
+}
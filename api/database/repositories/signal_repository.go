@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SignalRepository defines signal-specific repository operations
+type SignalRepository interface {
+	BaseRepository
+	FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Signal, error)
+	FindByChannelAndMessage(ctx context.Context, channelID uuid.UUID, messageID string) (*models.Signal, error)
+	FindAllByChannel(ctx context.Context, channelID uuid.UUID, skip, limit int) ([]*models.Signal, error)
+	CountSignalsByChannel(ctx context.Context, channelID uuid.UUID) (int64, error)
+	CreateSignal(ctx context.Context, signal *models.Signal) error
+	UpdateSignal(ctx context.Context, id uuid.UUID, update *models.Signal) error
+	UpdateSignalColumns(ctx context.Context, id uuid.UUID, update *models.Signal, columns ...string) error
+	AmendSignal(ctx context.Context, id uuid.UUID, from []models.SignalStatus, update *models.Signal, columns ...string) (bool, error)
+	FindRecentByFingerprint(ctx context.Context, channelID uuid.UUID, fingerprint string, since, until time.Time) (*models.Signal, error)
+	FindLatestOpenBySymbol(ctx context.Context, channelID uuid.UUID, symbol string, historical bool) (*models.Signal, error)
+	HasMessage(ctx context.Context, channelID uuid.UUID, messageID string) (bool, error)
+	FindDueForExecution(ctx context.Context, now time.Time, limit int) ([]*models.Signal, error)
+	TransitionStatus(ctx context.Context, id uuid.UUID, from []models.SignalStatus, to models.SignalStatus, reason string) (bool, error)
+	CreateAction(ctx context.Context, action *models.SignalAction) error
+	FindActionsBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.SignalAction, error)
+	FindPendingActions(ctx context.Context, types []models.SignalActionType, limit int) ([]*models.SignalAction, error)
+	UpdateActionColumns(ctx context.Context, id uuid.UUID, update *models.SignalAction, columns ...string) error
+}
+
+// signalRepository implements SignalRepository interface
+type signalRepository struct {
+	BaseRepository
+	db *gorm.DB
+}
+
+// NewSignalRepository creates a new signal repository instance
+func NewSignalRepository(db *gorm.DB) SignalRepository {
+	return &signalRepository{
+		BaseRepository: NewBaseRepository(db),
+		db:             db,
+	}
+}
+
+// FindByIDTyped finds a signal by ID and returns typed Signal struct
+func (r *signalRepository) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Signal, error) {
+	var signal models.Signal
+	err := r.db.WithContext(ctx).Preload("Actions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).Preload("Orders", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).Preload("RiskRejections", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).First(&signal, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("signal not found with ID: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find signal by ID: %w", err)
+	}
+
+	return &signal, nil
+}
+
+// FindByChannelAndMessage finds the signal parsed from a specific source message
+func (r *signalRepository) FindByChannelAndMessage(ctx context.Context, channelID uuid.UUID, messageID string) (*models.Signal, error) {
+	var signal models.Signal
+	err := r.db.WithContext(ctx).Where("channel_id = ? AND message_id = ?", channelID, messageID).First(&signal).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("signal not found for channel %s and message %s", channelID, messageID)
+		}
+		return nil, fmt.Errorf("failed to find signal by message: %w", err)
+	}
+
+	return &signal, nil
+}
+
+// FindAllByChannel retrieves all signals for a channel with pagination
+func (r *signalRepository) FindAllByChannel(ctx context.Context, channelID uuid.UUID, skip, limit int) ([]*models.Signal, error) {
+	var signals []*models.Signal
+	query := r.db.WithContext(ctx).Where("channel_id = ?", channelID).Order("posted_at desc")
+	if skip > 0 {
+		query = query.Offset(skip)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&signals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signals by channel: %w", err)
+	}
+
+	return signals, nil
+}
+
+// CountSignalsByChannel returns the number of signals for a specific channel
+func (r *signalRepository) CountSignalsByChannel(ctx context.Context, channelID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Signal{}).Where("channel_id = ?", channelID).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count signals by channel: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateSignal creates a new signal
+func (r *signalRepository) CreateSignal(ctx context.Context, signal *models.Signal) error {
+	err := r.db.WithContext(ctx).Create(signal).Error
+	if err != nil {
+		return fmt.Errorf("failed to create signal: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSignal updates an existing signal
+func (r *signalRepository) UpdateSignal(ctx context.Context, id uuid.UUID, update *models.Signal) error {
+	err := r.db.WithContext(ctx).Model(&models.Signal{}).Where("id = ?", id).Updates(update).Error
+	if err != nil {
+		return fmt.Errorf("failed to update signal: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSignalColumns writes the given columns of update, including zero values
+func (r *signalRepository) UpdateSignalColumns(ctx context.Context, id uuid.UUID, update *models.Signal, columns ...string) error {
+	err := r.db.WithContext(ctx).Model(&models.Signal{ID: id}).Select(columns).Updates(update).Error
+	if err != nil {
+		return fmt.Errorf("failed to update signal columns: %w", err)
+	}
+
+	return nil
+}
+
+// AmendSignal writes the given columns of update only while the signal is in one of the from
+// statuses, reporting whether it was
+func (r *signalRepository) AmendSignal(ctx context.Context, id uuid.UUID, from []models.SignalStatus, update *models.Signal, columns ...string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Signal{}).
+		Where("id = ? AND status IN ?", id, from).
+		Select(columns).Updates(update)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to amend signal: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// FindRecentByFingerprint finds the earliest non-duplicate signal with the same content posted in a
+// time range, or returns nil when there is none
+func (r *signalRepository) FindRecentByFingerprint(ctx context.Context, channelID uuid.UUID, fingerprint string, since, until time.Time) (*models.Signal, error) {
+	var signal models.Signal
+	err := r.db.WithContext(ctx).
+		Where("channel_id = ? AND fingerprint = ? AND status <> ? AND posted_at BETWEEN ? AND ?",
+			channelID, fingerprint, models.SignalStatusSuppressed, since, until).
+		Order("posted_at asc").
+		First(&signal).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find signal by fingerprint: %w", err)
+	}
+
+	return &signal, nil
+}
+
+// FindLatestOpenBySymbol finds the most recent open signal for a symbol in a channel,
+// looking only at imported history or only at live signals
+func (r *signalRepository) FindLatestOpenBySymbol(ctx context.Context, channelID uuid.UUID, symbol string, historical bool) (*models.Signal, error) {
+	var signal models.Signal
+	err := r.db.WithContext(ctx).
+		Where("channel_id = ? AND symbol = ? AND status IN ? AND historical = ?", channelID, symbol, models.OpenSignalStatuses, historical).
+		Order("posted_at desc").
+		First(&signal).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("no open signal for channel %s and symbol %s", channelID, symbol)
+		}
+		return nil, fmt.Errorf("failed to find open signal by symbol: %w", err)
+	}
+
+	return &signal, nil
+}
+
+// HasMessage reports whether a source message already produced a signal or an action in a channel
+func (r *signalRepository) HasMessage(ctx context.Context, channelID uuid.UUID, messageID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Signal{}).
+		Where("channel_id = ? AND message_id = ?", channelID, messageID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to count signals by message: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.SignalAction{}).
+		Joins("JOIN signals ON signals.id = signal_actions.signal_id").
+		Where("signals.channel_id = ? AND signal_actions.source_message_id = ?", channelID, messageID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to count signal actions by message: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// FindDueForExecution finds live signals waiting for execution whose copy delay has passed, oldest first
+func (r *signalRepository) FindDueForExecution(ctx context.Context, now time.Time, limit int) ([]*models.Signal, error) {
+	var signals []*models.Signal
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND historical = ? AND (execute_after IS NULL OR execute_after <= ?)", models.SignalStatusNew, false, now).
+		Order("posted_at asc").
+		Limit(limit).
+		Find(&signals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signals due for execution: %w", err)
+	}
+
+	return signals, nil
+}
+
+// TransitionStatus moves a signal to a new status only if it is still in one of the from statuses,
+// so concurrent workers cannot both claim it. It reports whether the signal was updated.
+func (r *signalRepository) TransitionStatus(ctx context.Context, id uuid.UUID, from []models.SignalStatus, to models.SignalStatus, reason string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Signal{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(map[string]interface{}{"status": to, "status_reason": reason})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update signal status: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// CreateAction records a management action against a signal
+func (r *signalRepository) CreateAction(ctx context.Context, action *models.SignalAction) error {
+	err := r.db.WithContext(ctx).Create(action).Error
+	if err != nil {
+		return fmt.Errorf("failed to create signal action: %w", err)
+	}
+
+	return nil
+}
+
+// FindActionsBySignal retrieves a signal's action history in chronological order
+func (r *signalRepository) FindActionsBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.SignalAction, error) {
+	var actions []*models.SignalAction
+	err := r.db.WithContext(ctx).Where("signal_id = ?", signalID).Order("created_at asc").Find(&actions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signal actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// FindPendingActions finds actions of the given types still waiting to be applied, oldest first
+func (r *signalRepository) FindPendingActions(ctx context.Context, types []models.SignalActionType, limit int) ([]*models.SignalAction, error) {
+	var actions []*models.SignalAction
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND type IN ?", models.SignalActionStatusPending, types).
+		Order("created_at asc").
+		Limit(limit).
+		Find(&actions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending signal actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// UpdateActionColumns writes the given columns of update, including zero values
+func (r *signalRepository) UpdateActionColumns(ctx context.Context, id uuid.UUID, update *models.SignalAction, columns ...string) error {
+	err := r.db.WithContext(ctx).Model(&models.SignalAction{ID: id}).Select(columns).Updates(update).Error
+	if err != nil {
+		return fmt.Errorf("failed to update signal action columns: %w", err)
+	}
+
+	return nil
+}
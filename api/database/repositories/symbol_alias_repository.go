@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SymbolAliasRepository defines symbol alias repository operations
+type SymbolAliasRepository interface {
+	BaseRepository
+	FindAll(ctx context.Context) ([]*models.SymbolAlias, error)
+	FindByExchange(ctx context.Context, exchange models.Exchange) ([]*models.SymbolAlias, error)
+	FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.SymbolAlias, error)
+	FindByExchangeAndAlias(ctx context.Context, exchange models.Exchange, alias string) (*models.SymbolAlias, error)
+	CreateAlias(ctx context.Context, alias *models.SymbolAlias) error
+	UpdateAlias(ctx context.Context, id uuid.UUID, update *models.SymbolAlias) error
+	DeleteAlias(ctx context.Context, id uuid.UUID) error
+}
+
+// symbolAliasRepository implements SymbolAliasRepository interface
+type symbolAliasRepository struct {
+	BaseRepository
+	db *gorm.DB
+}
+
+// NewSymbolAliasRepository creates a new symbol alias repository instance
+func NewSymbolAliasRepository(db *gorm.DB) SymbolAliasRepository {
+	return &symbolAliasRepository{
+		BaseRepository: NewBaseRepository(db),
+		db:             db,
+	}
+}
+
+// FindAll finds every alias, exchange-independent ones first
+func (r *symbolAliasRepository) FindAll(ctx context.Context) ([]*models.SymbolAlias, error) {
+	var aliases []*models.SymbolAlias
+	err := r.db.WithContext(ctx).Order("exchange asc, alias asc").Find(&aliases).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find symbol aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// FindByExchange finds the aliases of one exchange ("" for exchange-independent aliases)
+func (r *symbolAliasRepository) FindByExchange(ctx context.Context, exchange models.Exchange) ([]*models.SymbolAlias, error) {
+	var aliases []*models.SymbolAlias
+	err := r.db.WithContext(ctx).Where("exchange = ?", exchange).Order("alias asc").Find(&aliases).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find symbol aliases by exchange: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// FindByIDTyped finds a symbol alias by ID
+func (r *symbolAliasRepository) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.SymbolAlias, error) {
+	var alias models.SymbolAlias
+	err := r.db.WithContext(ctx).First(&alias, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("symbol alias not found with ID: %s", id)
+		}
+		return nil, fmt.Errorf("failed to find symbol alias by ID: %w", err)
+	}
+
+	return &alias, nil
+}
+
+// FindByExchangeAndAlias finds the mapping for an alias on an exchange
+func (r *symbolAliasRepository) FindByExchangeAndAlias(ctx context.Context, exchange models.Exchange, alias string) (*models.SymbolAlias, error) {
+	var found models.SymbolAlias
+	err := r.db.WithContext(ctx).Where("exchange = ? AND alias = ?", exchange, alias).First(&found).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("symbol alias %s not found for exchange %q", alias, exchange)
+		}
+		return nil, fmt.Errorf("failed to find symbol alias: %w", err)
+	}
+
+	return &found, nil
+}
+
+// CreateAlias creates a new symbol alias
+func (r *symbolAliasRepository) CreateAlias(ctx context.Context, alias *models.SymbolAlias) error {
+	err := r.db.WithContext(ctx).Create(alias).Error
+	if err != nil {
+		return fmt.Errorf("failed to create symbol alias: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAlias updates a symbol alias
+func (r *symbolAliasRepository) UpdateAlias(ctx context.Context, id uuid.UUID, update *models.SymbolAlias) error {
+	err := r.db.WithContext(ctx).Model(&models.SymbolAlias{ID: id}).Updates(update).Error
+	if err != nil {
+		return fmt.Errorf("failed to update symbol alias: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAlias deletes a symbol alias by ID
+func (r *symbolAliasRepository) DeleteAlias(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Delete(&models.SymbolAlias{}, id).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete symbol alias: %w", err)
+	}
+
+	return nil
+}
@@ -25,6 +25,10 @@ type TradeSettingsRepository interface {
 	CountTradeSettings(ctx context.Context) (int64, error)
 	UpdateStopLossSettings(ctx context.Context, userID uuid.UUID, percentage int, status bool) error
 	UpdateTakeProfitSettings(ctx context.Context, userID uuid.UUID, status bool, step int, percentages []float64) error
+	UpdateStopRules(ctx context.Context, userID uuid.UUID, rules models.StopRules) error
+	UpdateExpiryRules(ctx context.Context, userID uuid.UUID, rules models.ExpiryRules) error
+	UpdateTradingPaused(ctx context.Context, userID uuid.UUID, paused bool) error
+	UpdateRiskLimits(ctx context.Context, userID uuid.UUID, limits models.RiskLimits) error
 }
 
 // tradeSettingsRepository implements TradeSettingsRepository interface
@@ -183,14 +187,66 @@ func (r *tradeSettingsRepository) UpdateStopLossSettings(ctx context.Context, us
 
 // UpdateTakeProfitSettings updates only take profit related settings
 func (r *tradeSettingsRepository) UpdateTakeProfitSettings(ctx context.Context, userID uuid.UUID, status bool, step int, percentages []float64) error {
-	err := r.db.WithContext(ctx).Model(&models.TradeSettings{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
-		"take_profit_status": status,
-		"take_profit_step":   step,
-		"tp_percentage":      percentages,
-	}).Error
+	// a struct update so tp_percentage goes through its JSON serializer
+	err := r.db.WithContext(ctx).Model(&models.TradeSettings{}).Where("user_id = ?", userID).
+		Select("take_profit_status", "take_profit_step", "tp_percentage").
+		Updates(&models.TradeSettings{
+			TakeProfitStatus: status,
+			TakeProfitStep:   step,
+			TPPercentage:     percentages,
+		}).Error
 	if err != nil {
 		return fmt.Errorf("failed to update take profit settings: %w", err)
 	}
 
 	return nil
 }
+
+// UpdateStopRules updates only the rules that move the stop as targets fill
+func (r *tradeSettingsRepository) UpdateStopRules(ctx context.Context, userID uuid.UUID, rules models.StopRules) error {
+	err := r.db.WithContext(ctx).Model(&models.TradeSettings{}).Where("user_id = ?", userID).
+		Select("break_even_after_tp1", "trail_to_previous_tp", "trailing_stop_percentage").
+		Updates(&models.TradeSettings{StopRules: rules}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update stop rules: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateExpiryRules updates only the rules that time out entries and positions
+func (r *tradeSettingsRepository) UpdateExpiryRules(ctx context.Context, userID uuid.UUID, rules models.ExpiryRules) error {
+	err := r.db.WithContext(ctx).Model(&models.TradeSettings{}).Where("user_id = ?", userID).
+		Select("expiry_entry_timeout_minutes", "expiry_cancel_entry_at_tp1", "expiry_max_holding_hours").
+		Updates(&models.TradeSettings{ExpiryRules: rules}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update expiry rules: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTradingPaused pauses or resumes the execution of a user's signals
+func (r *tradeSettingsRepository) UpdateTradingPaused(ctx context.Context, userID uuid.UUID, paused bool) error {
+	err := r.db.WithContext(ctx).Model(&models.TradeSettings{}).Where("user_id = ?", userID).
+		Select("trading_paused").
+		Updates(&models.TradeSettings{TradingPaused: paused}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update trading pause: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRiskLimits updates only the limits of the risk guard
+func (r *tradeSettingsRepository) UpdateRiskLimits(ctx context.Context, userID uuid.UUID, limits models.RiskLimits) error {
+	err := r.db.WithContext(ctx).Model(&models.TradeSettings{}).Where("user_id = ?", userID).
+		Select("risk_max_open_positions", "risk_max_total_notional", "risk_max_symbol_notional",
+			"risk_max_side_notional", "risk_daily_loss_limit", "risk_weekly_loss_limit").
+		Updates(&models.TradeSettings{RiskLimits: limits}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update risk limits: %w", err)
+	}
+
+	return nil
+}
@@ -41,6 +41,11 @@ func SeedData() error {
 		return fmt.Errorf("failed to seed trade settings: %v", err)
 	}
 
+	err = seedSymbolAliases(db)
+	if err != nil {
+		return fmt.Errorf("failed to seed symbol aliases: %v", err)
+	}
+
 	slog.Info("Database seeding completed successfully")
 	return nil
 }
@@ -151,9 +156,11 @@ func seedPlatforms(db *gorm.DB) error {
 		{
 			ID:        uuid.New(),
 			UserID:    userID,
-			Name:      "MT4-Demo",
+			Name:      "Binance-Testnet",
 			APIKey:    "sample_api_key",
 			APISecret: "sample_api_secret",
+			Exchange:  models.ExchangeBinanceFutures,
+			Testnet:   true,
 		},
 	}
 
@@ -227,6 +234,54 @@ func seedTradeSettings(db *gorm.DB) error {
 	return nil
 }
 
+func seedSymbolAliases(db *gorm.DB) error {
+	aliases := []models.SymbolAlias{
+		// Names channels use instead of tickers
+		{Alias: "BITCOIN", Symbol: "BTCUSDT"},
+		{Alias: "XBT", Symbol: "BTCUSDT"},
+		{Alias: "ETHEREUM", Symbol: "ETHUSDT"},
+		{Alias: "ETHER", Symbol: "ETHUSDT"},
+		{Alias: "SOLANA", Symbol: "SOLUSDT"},
+		{Alias: "RIPPLE", Symbol: "XRPUSDT"},
+		{Alias: "DOGECOIN", Symbol: "DOGEUSDT"},
+		{Alias: "CARDANO", Symbol: "ADAUSDT"},
+		{Alias: "AVALANCHE", Symbol: "AVAXUSDT"},
+		{Alias: "CHAINLINK", Symbol: "LINKUSDT"},
+		{Alias: "LITECOIN", Symbol: "LTCUSDT"},
+		{Alias: "POLKADOT", Symbol: "DOTUSDT"},
+		{Alias: "TONCOIN", Symbol: "TONUSDT"},
+
+		// Binance futures lists low-priced coins as 1000x contracts
+		{Exchange: models.ExchangeBinanceFutures, Alias: "PEPEUSDT", Symbol: "1000PEPEUSDT", Multiplier: 1000},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "SHIBUSDT", Symbol: "1000SHIBUSDT", Multiplier: 1000},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "FLOKIUSDT", Symbol: "1000FLOKIUSDT", Multiplier: 1000},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "BONKUSDT", Symbol: "1000BONKUSDT", Multiplier: 1000},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "LUNCUSDT", Symbol: "1000LUNCUSDT", Multiplier: 1000},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "XECUSDT", Symbol: "1000XECUSDT", Multiplier: 1000},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "SATSUSDT", Symbol: "1000SATSUSDT", Multiplier: 1000},
+	}
+
+	for _, alias := range aliases {
+		if alias.Multiplier == 0 {
+			alias.Multiplier = 1
+		}
+
+		var existing models.SymbolAlias
+		if err := db.Where("exchange = ? AND alias = ?", alias.Exchange, alias.Alias).First(&existing).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				if err := db.Create(&alias).Error; err != nil {
+					return fmt.Errorf("failed to create symbol alias %s: %v", alias.Alias, err)
+				}
+			} else {
+				return err
+			}
+		}
+	}
+
+	slog.Info("Seeded symbol aliases")
+	return nil
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
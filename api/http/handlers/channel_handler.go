@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/ingest"
 	"copier/internal/services"
 	AppError "copier/internal/shared/error"
+	"copier/internal/shared/exceptions"
 	"copier/internal/shared/response"
 	"copier/internal/shared/utils"
 )
@@ -13,19 +17,30 @@ import (
 // ChannelHandler handles HTTP requests related to channels
 type ChannelHandler struct {
 	channelService services.ChannelService
+	signalService  services.SignalService
 }
 
 // NewChannelHandler creates a new ChannelHandler instance
-func NewChannelHandler(channelService services.ChannelService) *ChannelHandler {
+func NewChannelHandler(channelService services.ChannelService, signalService services.SignalService) *ChannelHandler {
 	return &ChannelHandler{
 		channelService: channelService,
+		signalService:  signalService,
 	}
 }
 
 // CreateChannelRequest defines the payload for channel creation
 type CreateChannelRequest struct {
-	Name      string `json:"name" validate:"required,min=1,max=255"`
-	ChannelID string `json:"channel_id" validate:"required"`
+	Name         string               `json:"name" validate:"required,min=1,max=255"`
+	ChannelID    string               `json:"channel_id" validate:"required"`
+	ParserRules  []models.ParserRule  `json:"parser_rules" validate:"omitempty,dive"`
+	DeletePolicy models.DeletePolicy  `json:"delete_policy" validate:"omitempty,oneof=ignore cancel_pending close_position"`
+	Filter       models.ChannelFilter `json:"filter"`
+	Expiry       models.ExpiryRules   `json:"expiry"`
+}
+
+// ReplaceRoutesRequest lists the platforms a channel's signals are copied to
+type ReplaceRoutesRequest struct {
+	Routes []*models.ChannelRoute `json:"routes" validate:"dive"`
 }
 
 func (h *ChannelHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -39,8 +54,20 @@ func (h *ChannelHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	channel, err := h.channelService.CreateChannel(r.Context(), userID, req.Name, req.ChannelID)
+	channel, err := h.channelService.CreateChannel(r.Context(), &models.Channel{
+		UserID:       userID,
+		Name:         req.Name,
+		ChannelID:    req.ChannelID,
+		ParserRules:  req.ParserRules,
+		DeletePolicy: req.DeletePolicy,
+		Filter:       req.Filter,
+		Expiry:       req.Expiry,
+	})
 	if err != nil {
+		if errors.Is(err, exceptions.ErrInvalidParserRule) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
 		AppError.InternalServerErrorWithError("Failed to create channel", err).WriteToResponse(w)
 		return
 	}
@@ -76,6 +103,10 @@ func (h *ChannelHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	channel, err := h.channelService.UpdateChannel(r.Context(), id, &update)
 	if err != nil {
+		if errors.Is(err, exceptions.ErrInvalidParserRule) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
 		AppError.InternalServerErrorWithError("Failed to update channel", err).WriteToResponse(w)
 		return
 	}
@@ -83,6 +114,91 @@ func (h *ChannelHandler) Update(w http.ResponseWriter, r *http.Request) {
 	response.WriteOK(w, "Channel updated successfully", channel)
 }
 
+// UpdateParserRulesRequest defines the payload for replacing a channel's parser rules
+type UpdateParserRulesRequest struct {
+	ParserRules []models.ParserRule `json:"parser_rules" validate:"omitempty,dive"`
+}
+
+func (h *ChannelHandler) UpdateParserRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req UpdateParserRulesRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	existing, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || existing.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	channel, err := h.channelService.UpdateParserRules(r.Context(), id, req.ParserRules)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrInvalidParserRule) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to update parser rules", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Parser rules updated successfully", channel)
+}
+
+// ParsePreviewRequest defines the payload for a dry-run parse of a sample message
+type ParsePreviewRequest struct {
+	Text string `json:"text" validate:"required"`
+	// Balance is the available quote balance to size against; balance-based sizing needs it
+	Balance float64 `json:"balance" validate:"min=0"`
+}
+
+// ParsePreview shows what the copier would extract from a message without persisting or executing it
+func (h *ChannelHandler) ParsePreview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req ParsePreviewRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	channel, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || channel.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	preview, err := h.signalService.PreviewMessage(r.Context(), channel, req.Text, execution.Account{Available: req.Balance})
+	if err != nil {
+		if services.IsParseError(err) {
+			AppError.UnprocessableEntity("Message could not be parsed as a signal", map[string]interface{}{
+				"reason": err.Error(),
+			}).WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to preview message", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Message parsed successfully", preview)
+}
+
 func (h *ChannelHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, ok := utils.ResolvePathID(w, r, "id")
 	if !ok {
@@ -96,3 +212,161 @@ func (h *ChannelHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	response.WriteNoContent(w)
 }
+
+// RotateWebhookSecret issues a new HMAC secret for the channel's webhook; it is only shown once
+func (h *ChannelHandler) RotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	existing, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || existing.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	secret, err := h.channelService.RotateWebhookSecret(r.Context(), id)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to rotate webhook secret", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Webhook secret rotated successfully", map[string]interface{}{
+		"webhook_url":      "/api/v1/webhooks/" + id.String(),
+		"webhook_secret":   secret,
+		"signature_header": ingest.SignatureHeader,
+	})
+}
+
+// UpdateFilter replaces the channel's signal filter policy
+func (h *ChannelHandler) UpdateFilter(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ChannelFilter
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	existing, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || existing.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	channel, err := h.channelService.UpdateFilter(r.Context(), id, req)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to update channel filter", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Channel filter updated successfully", channel)
+}
+
+// UpdateExpiry replaces the channel's expiry rules
+func (h *ChannelHandler) UpdateExpiry(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.ExpiryRules
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	existing, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || existing.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	channel, err := h.channelService.UpdateExpiry(r.Context(), id, req)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to update channel expiry", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Channel expiry updated successfully", channel)
+}
+
+// ListRoutes returns the platforms the channel's signals are copied to
+func (h *ChannelHandler) ListRoutes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	existing, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || existing.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	routes, err := h.channelService.GetRoutes(r.Context(), id)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to retrieve channel routes", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Channel routes retrieved successfully", routes)
+}
+
+// ReplaceRoutes replaces the platforms the channel's signals are copied to and their allocation
+func (h *ChannelHandler) ReplaceRoutes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req ReplaceRoutesRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	existing, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || existing.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	routes, err := h.channelService.ReplaceRoutes(r.Context(), existing, req.Routes)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrInvalidRoute) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to update channel routes", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Channel routes updated successfully", routes)
+}
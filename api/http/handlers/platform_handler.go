@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"copier/internal/database/models"
+	"copier/internal/exchange"
 	"copier/internal/services"
 	AppError "copier/internal/shared/error"
+	"copier/internal/shared/exceptions"
 	"copier/internal/shared/response"
 	"copier/internal/shared/utils"
 )
@@ -24,9 +28,12 @@ func NewPlatformHandler(platformService services.PlatformService) *PlatformHandl
 
 // CreatePlatformRequest defines the payload for platform creation
 type CreatePlatformRequest struct {
-	Name      string `json:"name" validate:"required,min=1,max=255"`
-	APIKey    string `json:"api_key" validate:"required"`
-	APISecret string `json:"api_secret" validate:"required"`
+	Name      string          `json:"name" validate:"required,min=1,max=255"`
+	APIKey    string          `json:"api_key" validate:"required_unless=Paper true"`
+	APISecret string          `json:"api_secret" validate:"required_unless=Paper true"`
+	Exchange  models.Exchange `json:"exchange" validate:"omitempty,oneof=binance_futures"`
+	Testnet   bool            `json:"testnet"`
+	Paper     bool            `json:"paper"`
 }
 
 func (h *PlatformHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -40,7 +47,15 @@ func (h *PlatformHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	platform, err := h.platformService.CreatePlatform(r.Context(), userID, req.Name, req.APIKey, req.APISecret)
+	platform, err := h.platformService.CreatePlatform(r.Context(), &models.Platform{
+		UserID:    userID,
+		Name:      req.Name,
+		APIKey:    req.APIKey,
+		APISecret: req.APISecret,
+		Exchange:  req.Exchange,
+		Testnet:   req.Testnet,
+		Paper:     req.Paper,
+	})
 	if err != nil {
 		AppError.InternalServerErrorWithError("Failed to create platform", err).WriteToResponse(w)
 		return
@@ -97,3 +112,85 @@ func (h *PlatformHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	response.WriteNoContent(w)
 }
+
+// Balances fetches the wallet balances of a platform from its exchange, confirming the API keys work
+func (h *PlatformHandler) Balances(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	platform, err := h.platformService.GetPlatformByID(r.Context(), id)
+	if err != nil || platform.UserID != userID {
+		AppError.ResourceNotFound("Platform", id.String()).WriteToResponse(w)
+		return
+	}
+
+	balances, err := h.platformService.Balances(r.Context(), platform)
+	if err != nil {
+		AppError.ExternalServiceError(string(platform.Exchange), "balance lookup", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Balances retrieved successfully", balances)
+}
+
+// PaperTicksRequest feeds prices to a paper platform, as a list of ticks or as CSV ("time,symbol,price")
+type PaperTicksRequest struct {
+	Ticks []exchange.Tick `json:"ticks" validate:"required_without=CSV,omitempty,dive"`
+	CSV   string          `json:"csv" validate:"required_without=Ticks"`
+}
+
+// PaperTicks replays prices into a paper platform's simulated exchange, filling any orders they reach
+func (h *PlatformHandler) PaperTicks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req PaperTicksRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	platform, err := h.platformService.GetPlatformByID(r.Context(), id)
+	if err != nil || platform.UserID != userID {
+		AppError.ResourceNotFound("Platform", id.String()).WriteToResponse(w)
+		return
+	}
+
+	ticks := req.Ticks
+	if req.CSV != "" {
+		parsed, err := exchange.ParsePriceCSV(strings.NewReader(req.CSV))
+		if err != nil {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
+		ticks = append(ticks, parsed...)
+	}
+
+	positions, err := h.platformService.ReplayPrices(r.Context(), platform, ticks)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotPaperPlatform) {
+			AppError.BadRequest("Prices can only be fed to paper platforms").WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to replay prices", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Prices replayed successfully", map[string]interface{}{
+		"ticks":     len(ticks),
+		"positions": positions,
+	})
+}
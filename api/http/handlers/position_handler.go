@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"copier/internal/database/models"
+	"copier/internal/services"
+	AppError "copier/internal/shared/error"
+	"copier/internal/shared/response"
+	"copier/internal/shared/utils"
+)
+
+// PositionHandler handles HTTP requests related to positions held by the copier
+type PositionHandler struct {
+	positionService services.PositionService
+}
+
+// NewPositionHandler creates a new PositionHandler instance
+func NewPositionHandler(positionService services.PositionService) *PositionHandler {
+	return &PositionHandler{
+		positionService: positionService,
+	}
+}
+
+// ListByUser retrieves the user's positions; ?status=open,partially_closed filters by status and
+// ?status=active selects every position that still holds or awaits exposure
+func (h *PositionHandler) ListByUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	var statuses []models.PositionStatus
+	if raw := r.URL.Query().Get("status"); raw != "" {
+		for _, status := range strings.Split(raw, ",") {
+			status = strings.TrimSpace(status)
+			if status == "active" {
+				statuses = append(statuses, models.ActivePositionStatuses...)
+				continue
+			}
+			statuses = append(statuses, models.PositionStatus(status))
+		}
+	}
+
+	skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50
+	}
+
+	positions, total, err := h.positionService.GetPositionsByUser(r.Context(), userID, statuses, skip, limit)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to retrieve positions", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Positions retrieved successfully", map[string]interface{}{
+		"positions": positions,
+		"total":     total,
+	})
+}
+
+// GetByID retrieves a single position owned by the user with its orders and history
+func (h *PositionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	position, err := h.positionService.GetPositionByID(r.Context(), id)
+	if err != nil || position.UserID != userID {
+		AppError.ResourceNotFound("Position", id.String()).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Position retrieved successfully", position)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"copier/internal/services"
+	AppError "copier/internal/shared/error"
+	"copier/internal/shared/response"
+	"copier/internal/shared/utils"
+)
+
+// RiskHandler handles HTTP requests about the user's risk limits
+type RiskHandler struct {
+	riskService services.RiskService
+}
+
+// NewRiskHandler creates a new RiskHandler instance
+func NewRiskHandler(riskService services.RiskService) *RiskHandler {
+	return &RiskHandler{
+		riskService: riskService,
+	}
+}
+
+// Budget reports what the user's positions use of their risk limits and what is left
+func (h *RiskHandler) Budget(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	budget, err := h.riskService.Budget(r.Context(), userID)
+	if err != nil {
+		AppError.ResourceNotFound("TradeSettings", userID.String()).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Risk budget retrieved successfully", budget)
+}
+
+// Rejections lists the signals the risk guard recently refused to copy; ?limit= defaults to 50
+func (h *RiskHandler) Rejections(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rejections, err := h.riskService.Rejections(r.Context(), userID, limit)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to retrieve risk rejections", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Risk rejections retrieved successfully", rejections)
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"copier/internal/ingest"
+	"copier/internal/services"
+	AppError "copier/internal/shared/error"
+	"copier/internal/shared/exceptions"
+	"copier/internal/shared/response"
+	"copier/internal/shared/utils"
+	"copier/internal/signal"
+)
+
+// SignalHandler handles HTTP requests related to parsed signals
+type SignalHandler struct {
+	signalService  services.SignalService
+	channelService services.ChannelService
+}
+
+// NewSignalHandler creates a new SignalHandler instance
+func NewSignalHandler(signalService services.SignalService, channelService services.ChannelService) *SignalHandler {
+	return &SignalHandler{
+		signalService:  signalService,
+		channelService: channelService,
+	}
+}
+
+// PostMessageRequest defines the payload for submitting a raw channel message
+type PostMessageRequest struct {
+	MessageID string             `json:"message_id" validate:"required,max=255"`
+	ReplyToID string             `json:"reply_to_id" validate:"max=255"`
+	Text      string             `json:"text" validate:"required_unless=Kind delete"`
+	Kind      signal.MessageKind `json:"kind" validate:"omitempty,oneof=new edit delete"`
+	PostedAt  *time.Time         `json:"posted_at"`
+}
+
+// PostMessage parses a raw message posted in one of the user's channels and stores the signal
+func (h *SignalHandler) PostMessage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req PostMessageRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	channel, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || channel.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	msg := signal.Message{
+		ChannelID: channel.ChannelID,
+		MessageID: req.MessageID,
+		ReplyToID: req.ReplyToID,
+		Text:      req.Text,
+		Kind:      req.Kind,
+	}
+	if req.PostedAt != nil {
+		msg.PostedAt = *req.PostedAt
+	}
+
+	sig, err := h.signalService.IngestForChannel(r.Context(), channel, msg)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrDuplicateMessage) {
+			AppError.Conflict(err.Error()).WriteToResponse(w)
+			return
+		}
+		if services.IsParseError(err) {
+			AppError.UnprocessableEntity("Message could not be parsed as a signal", map[string]interface{}{
+				"reason": err.Error(),
+			}).WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to ingest message", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteCreated(w, "Message processed successfully", sig)
+}
+
+// ListByChannel retrieves the signals parsed from one of the user's channels
+func (h *SignalHandler) ListByChannel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	channel, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || channel.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50
+	}
+
+	signals, total, err := h.signalService.GetSignalsByChannel(r.Context(), id, skip, limit)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to retrieve signals", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Signals retrieved successfully", map[string]interface{}{
+		"signals": signals,
+		"total":   total,
+	})
+}
+
+// GetByID retrieves a single signal owned by the user
+func (h *SignalHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	sig, err := h.signalService.GetSignalByID(r.Context(), id)
+	if err != nil || sig.UserID != userID {
+		AppError.ResourceNotFound("Signal", id.String()).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Signal retrieved successfully", sig)
+}
+
+// maxImportSize caps the size of an uploaded channel export
+const maxImportSize = 50 << 20
+
+// ImportHistory stores historical signals from a Telegram Desktop result.json export sent as the request body
+func (h *SignalHandler) ImportHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	channel, err := h.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || channel.UserID != userID {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	export, err := ingest.ParseTelegramExport(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		AppError.BadRequest("Invalid Telegram export").WriteToResponse(w)
+		return
+	}
+
+	result, err := h.signalService.ImportHistory(r.Context(), channel, export.SignalMessages(channel.ChannelID))
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to import channel history", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Channel history imported successfully", result)
+}
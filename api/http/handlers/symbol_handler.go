@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"copier/internal/database/models"
+	"copier/internal/services"
+	AppError "copier/internal/shared/error"
+	"copier/internal/shared/exceptions"
+	"copier/internal/shared/response"
+	"copier/internal/shared/utils"
+)
+
+// SymbolHandler handles HTTP requests for the symbol alias registry
+type SymbolHandler struct {
+	symbolService services.SymbolService
+}
+
+// NewSymbolHandler creates a new SymbolHandler instance
+func NewSymbolHandler(symbolService services.SymbolService) *SymbolHandler {
+	return &SymbolHandler{
+		symbolService: symbolService,
+	}
+}
+
+// UpdateSymbolAliasRequest defines the payload for changing an alias mapping
+type UpdateSymbolAliasRequest struct {
+	Alias      string  `json:"alias" validate:"omitempty,min=2,max=50"`
+	Symbol     string  `json:"symbol" validate:"omitempty,min=2,max=50"`
+	Multiplier float64 `json:"multiplier" validate:"omitempty,gt=0"`
+}
+
+// ListAliases retrieves every symbol alias
+func (h *SymbolHandler) ListAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := h.symbolService.ListAliases(r.Context())
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to retrieve symbol aliases", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Symbol aliases retrieved successfully", aliases)
+}
+
+// CreateAlias adds an alias mapping
+func (h *SymbolHandler) CreateAlias(w http.ResponseWriter, r *http.Request) {
+	var req models.SymbolAlias
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	alias, err := h.symbolService.CreateAlias(r.Context(), &models.SymbolAlias{
+		Exchange:   req.Exchange,
+		Alias:      req.Alias,
+		Symbol:     req.Symbol,
+		Multiplier: req.Multiplier,
+	})
+	if err != nil {
+		if errors.Is(err, exceptions.ErrSymbolAliasExists) {
+			AppError.DuplicateResource("Symbol alias", "alias", req.Alias).WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to create symbol alias", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteCreated(w, "Symbol alias created successfully", alias)
+}
+
+// UpdateAlias changes an alias mapping
+func (h *SymbolHandler) UpdateAlias(w http.ResponseWriter, r *http.Request) {
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req UpdateSymbolAliasRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	alias, err := h.symbolService.UpdateAlias(r.Context(), id, &models.SymbolAlias{
+		Alias:      req.Alias,
+		Symbol:     req.Symbol,
+		Multiplier: req.Multiplier,
+	})
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to update symbol alias", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Symbol alias updated successfully", alias)
+}
+
+// DeleteAlias removes an alias mapping
+func (h *SymbolHandler) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.symbolService.DeleteAlias(r.Context(), id); err != nil {
+		AppError.InternalServerErrorWithError("Failed to delete symbol alias", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteNoContent(w)
+}
+
+// Resolve shows how a symbol or alias resolves, optionally for an exchange
+func (h *SymbolHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	raw := r.URL.Query().Get("symbol")
+	if raw == "" {
+		AppError.BadRequest("symbol is required").WriteToResponse(w)
+		return
+	}
+	exchange := models.Exchange(r.URL.Query().Get("exchange"))
+
+	table, err := h.symbolService.Table(r.Context())
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to load symbol aliases", err).WriteToResponse(w)
+		return
+	}
+
+	canonical, known := table.Canonical(raw)
+	data := map[string]interface{}{
+		"input":     raw,
+		"canonical": canonical,
+		"known":     known,
+	}
+	if exchange != "" {
+		data["exchange"] = table.ForExchange(exchange, raw)
+	}
+
+	response.WriteOK(w, "Symbol resolved successfully", data)
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"copier/internal/database/models"
 	"copier/internal/services"
 	AppError "copier/internal/shared/error"
+	"copier/internal/shared/exceptions"
 	"copier/internal/shared/response"
 	"copier/internal/shared/utils"
 )
@@ -55,6 +57,10 @@ func (h *TradeSettingsHandler) Upsert(w http.ResponseWriter, r *http.Request) {
 
 	settings, err := h.settingsService.UpsertTradeSettings(r.Context(), userID, &req.Settings)
 	if err != nil {
+		if isSettingsValidationError(err) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
 		AppError.InternalServerErrorWithError("Failed to upsert trade settings", err).WriteToResponse(w)
 		return
 	}
@@ -106,9 +112,97 @@ func (h *TradeSettingsHandler) UpdateTakeProfit(w http.ResponseWriter, r *http.R
 	}
 
 	if err := h.settingsService.UpdateTakeProfit(r.Context(), userID, req.Status, req.Step, req.Percentages); err != nil {
+		if isSettingsValidationError(err) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
 		AppError.InternalServerErrorWithError("Failed to update take profit settings", err).WriteToResponse(w)
 		return
 	}
 
 	response.WriteOK(w, "Take profit settings updated successfully", nil)
 }
+
+// UpdateStopRulesRequest defines the payload for updating how the stop follows take profit fills
+type UpdateStopRulesRequest struct {
+	models.StopRules
+}
+
+func (h *TradeSettingsHandler) UpdateStopRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateStopRulesRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.settingsService.UpdateStopRules(r.Context(), userID, req.StopRules); err != nil {
+		if isSettingsValidationError(err) {
+			AppError.BadRequest(err.Error()).WriteToResponse(w)
+			return
+		}
+		AppError.InternalServerErrorWithError("Failed to update stop rules", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Stop rules updated successfully", nil)
+}
+
+// UpdateExpiryRulesRequest defines the payload for updating when entries and positions time out
+type UpdateExpiryRulesRequest struct {
+	models.ExpiryRules
+}
+
+func (h *TradeSettingsHandler) UpdateExpiryRules(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateExpiryRulesRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.settingsService.UpdateExpiryRules(r.Context(), userID, req.ExpiryRules); err != nil {
+		AppError.InternalServerErrorWithError("Failed to update expiry rules", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Expiry rules updated successfully", nil)
+}
+
+// UpdateRiskLimitsRequest defines the payload for updating the limits of the risk guard
+type UpdateRiskLimitsRequest struct {
+	models.RiskLimits
+}
+
+func (h *TradeSettingsHandler) UpdateRiskLimits(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateRiskLimitsRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.settingsService.UpdateRiskLimits(r.Context(), userID, req.RiskLimits); err != nil {
+		AppError.InternalServerErrorWithError("Failed to update risk limits", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Risk limits updated successfully", nil)
+}
+
+// isSettingsValidationError reports whether err rejects the settings themselves rather than failing to store them
+func isSettingsValidationError(err error) bool {
+	return errors.Is(err, exceptions.ErrInvalidTakeProfit) ||
+		errors.Is(err, exceptions.ErrInvalidStopRules) ||
+		errors.Is(err, exceptions.ErrInvalidSizing) ||
+		errors.Is(err, exceptions.ErrInvalidEntry)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"copier/internal/services"
+	AppError "copier/internal/shared/error"
+	"copier/internal/shared/exceptions"
+	"copier/internal/shared/response"
+	"copier/internal/shared/utils"
+)
+
+// TradingHandler handles HTTP requests that pause, resume and close out trading
+type TradingHandler struct {
+	tradingService services.TradingService
+}
+
+// NewTradingHandler creates a new TradingHandler instance
+func NewTradingHandler(tradingService services.TradingService) *TradingHandler {
+	return &TradingHandler{
+		tradingService: tradingService,
+	}
+}
+
+// Status reports whether the user's signals are being executed
+func (h *TradingHandler) Status(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.tradingService.Status(r.Context(), userID)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to read trading status", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Trading status retrieved successfully", status)
+}
+
+// Pause stops the user's new signals from being executed
+func (h *TradingHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.tradingService.Pause(r.Context(), userID); err != nil {
+		writeTradingError(w, "Failed to pause trading", err)
+		return
+	}
+
+	response.WriteOK(w, "Trading paused successfully", nil)
+}
+
+// Resume lifts the user's pause
+func (h *TradingHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.tradingService.Resume(r.Context(), userID); err != nil {
+		writeTradingError(w, "Failed to resume trading", err)
+		return
+	}
+
+	response.WriteOK(w, "Trading resumed successfully", nil)
+}
+
+// CloseAll pauses the user and closes every position on each of their platforms
+func (h *TradingHandler) CloseAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.tradingService.CloseAll(r.Context(), userID)
+	if err != nil {
+		AppError.InternalServerErrorWithError("Failed to close positions", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Close-all finished", report)
+}
+
+// HaltTradingRequest defines the payload for halting all trading
+type HaltTradingRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+// Halt stops all new executions for every user; admin only
+func (h *TradingHandler) Halt(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := utils.ResolveAuthenticatedUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req HaltTradingRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	halt, err := h.tradingService.Halt(r.Context(), adminID, req.Reason)
+	if err != nil {
+		writeTradingError(w, "Failed to halt trading", err)
+		return
+	}
+
+	response.WriteOK(w, "Trading halted successfully", halt)
+}
+
+// ResumeAll lifts the global halt; admin only
+func (h *TradingHandler) ResumeAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.tradingService.ResumeAll(r.Context()); err != nil {
+		writeTradingError(w, "Failed to lift trading halt", err)
+		return
+	}
+
+	response.WriteOK(w, "Trading halt lifted successfully", nil)
+}
+
+func writeTradingError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, exceptions.ErrTradeSettingsNotFound):
+		AppError.NotFound(err.Error()).WriteToResponse(w)
+	case errors.Is(err, exceptions.ErrKillSwitchUnavailable):
+		AppError.ServiceUnavailable(err.Error()).WriteToResponse(w)
+	default:
+		AppError.InternalServerErrorWithError(message, err).WriteToResponse(w)
+	}
+}
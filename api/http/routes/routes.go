@@ -34,18 +34,62 @@ func SetupRoutes(mux *http.ServeMux, container *di.Container) http.Handler {
 	mux.Handle("GET /api/v1/platforms", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PlatformHandler.ListByUser))))
 	mux.Handle("PUT /api/v1/platforms/{id}", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PlatformHandler.Update))))
 	mux.Handle("DELETE /api/v1/platforms/{id}", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PlatformHandler.Delete))))
+	mux.Handle("GET /api/v1/platforms/{id}/balances", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PlatformHandler.Balances))))
+	mux.Handle("POST /api/v1/platforms/{id}/paper/ticks", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PlatformHandler.PaperTicks))))
 
 	// Channel Routes
 	mux.Handle("POST /api/v1/channels", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.Create))))
 	mux.Handle("GET /api/v1/channels", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.ListByUser))))
 	mux.Handle("PUT /api/v1/channels/{id}", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.Update))))
 	mux.Handle("DELETE /api/v1/channels/{id}", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.Delete))))
+	mux.Handle("PUT /api/v1/channels/{id}/parser-rules", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.UpdateParserRules))))
+	mux.Handle("POST /api/v1/channels/{id}/parse-preview", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.ParsePreview))))
+	mux.Handle("PUT /api/v1/channels/{id}/filter", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.UpdateFilter))))
+	mux.Handle("PUT /api/v1/channels/{id}/expiry", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.UpdateExpiry))))
+	mux.Handle("GET /api/v1/channels/{id}/routes", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.ListRoutes))))
+	mux.Handle("PUT /api/v1/channels/{id}/routes", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.ReplaceRoutes))))
+	mux.Handle("POST /api/v1/channels/{id}/webhook-secret", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.ChannelHandler.RotateWebhookSecret))))
+
+	// Webhook Routes (authenticated by the per-channel HMAC signature)
+	mux.Handle("POST /api/v1/webhooks/{id}", manager.With(container.WebhookSource))
+
+	// Signal Routes
+	mux.Handle("POST /api/v1/channels/{id}/messages", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.SignalHandler.PostMessage))))
+	mux.Handle("POST /api/v1/channels/{id}/import", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.SignalHandler.ImportHistory))))
+	mux.Handle("GET /api/v1/channels/{id}/signals", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.SignalHandler.ListByChannel))))
+	mux.Handle("GET /api/v1/signals/{id}", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.SignalHandler.GetByID))))
+
+	// Position Routes
+	mux.Handle("GET /api/v1/positions", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PositionHandler.ListByUser))))
+	mux.Handle("GET /api/v1/positions/{id}", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.PositionHandler.GetByID))))
+
+	// Trading Routes
+	mux.Handle("GET /api/v1/trading/status", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradingHandler.Status))))
+	mux.Handle("POST /api/v1/trading/pause", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradingHandler.Pause))))
+	mux.Handle("POST /api/v1/trading/resume", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradingHandler.Resume))))
+	mux.Handle("POST /api/v1/trading/close-all", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradingHandler.CloseAll))))
+	mux.Handle("POST /api/v1/trading/halt", manager.With(middleware.AuthMiddleware(middleware.RoleMiddleware("admin")(http.HandlerFunc(container.TradingHandler.Halt)))))
+	mux.Handle("DELETE /api/v1/trading/halt", manager.With(middleware.AuthMiddleware(middleware.RoleMiddleware("admin")(http.HandlerFunc(container.TradingHandler.ResumeAll)))))
+
+	// Risk Routes
+	mux.Handle("GET /api/v1/risk/budget", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.RiskHandler.Budget))))
+	mux.Handle("GET /api/v1/risk/rejections", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.RiskHandler.Rejections))))
+
+	// Symbol Registry Routes
+	mux.Handle("GET /api/v1/symbols/resolve", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.SymbolHandler.Resolve))))
+	mux.Handle("GET /api/v1/symbols/aliases", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.SymbolHandler.ListAliases))))
+	mux.Handle("POST /api/v1/symbols/aliases", manager.With(middleware.AuthMiddleware(middleware.RoleMiddleware("admin")(http.HandlerFunc(container.SymbolHandler.CreateAlias)))))
+	mux.Handle("PUT /api/v1/symbols/aliases/{id}", manager.With(middleware.AuthMiddleware(middleware.RoleMiddleware("admin")(http.HandlerFunc(container.SymbolHandler.UpdateAlias)))))
+	mux.Handle("DELETE /api/v1/symbols/aliases/{id}", manager.With(middleware.AuthMiddleware(middleware.RoleMiddleware("admin")(http.HandlerFunc(container.SymbolHandler.DeleteAlias)))))
 
 	// Trade Settings Routes
 	mux.Handle("GET /api/v1/trade-settings", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.GetByUser))))
 	mux.Handle("POST /api/v1/trade-settings", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.Upsert))))
 	mux.Handle("PATCH /api/v1/trade-settings/stop-loss", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.UpdateStopLoss))))
 	mux.Handle("PATCH /api/v1/trade-settings/take-profit", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.UpdateTakeProfit))))
+	mux.Handle("PATCH /api/v1/trade-settings/stop-rules", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.UpdateStopRules))))
+	mux.Handle("PATCH /api/v1/trade-settings/expiry", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.UpdateExpiryRules))))
+	mux.Handle("PATCH /api/v1/trade-settings/risk", manager.With(middleware.AuthMiddleware(http.HandlerFunc(container.TradeSettingsHandler.UpdateRiskLimits))))
 
 	mux.HandleFunc("/", container.NotFoundHandler.NotFound)
 
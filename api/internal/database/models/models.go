@@ -23,21 +23,66 @@ type User struct {
 	Subscriptions []SubscribePackage `gorm:"foreignKey:UserID" json:"subscriptions,omitempty"`
 }
 
+// ParserRule is a channel-specific extraction rule tried before the generic parser.
+// Exactly one of Pattern (a regex with named captures) or Template must be set.
+type ParserRule struct {
+	Name     string `json:"name" validate:"required,min=1,max=100"`
+	Pattern  string `json:"pattern,omitempty" validate:"required_without=Template"`
+	Template string `json:"template,omitempty" validate:"required_without=Pattern"`
+}
+
+// DeletePolicy decides what happens to a signal when its source message is deleted
+type DeletePolicy string
+
+const (
+	DeletePolicyIgnore        DeletePolicy = "ignore"
+	DeletePolicyCancelPending DeletePolicy = "cancel_pending"
+	DeletePolicyClosePosition DeletePolicy = "close_position"
+)
+
+// ChannelFilter decides which of a channel's signals are copied; zero values disable a check
+type ChannelFilter struct {
+	// AllowSymbols, when set, limits copying to these symbols or base assets (e.g. "BTCUSDT" or "BTC")
+	AllowSymbols []string `json:"allow_symbols,omitempty" validate:"omitempty,dive,min=2,max=50"`
+	// DenySymbols are never copied, even when allowed
+	DenySymbols []string `json:"deny_symbols,omitempty" validate:"omitempty,dive,min=2,max=50"`
+	// QuoteAssets, when set, limits copying to symbols quoted in these assets (e.g. "USDT")
+	QuoteAssets      []string   `json:"quote_assets,omitempty" validate:"omitempty,dive,min=2,max=10"`
+	Side             SignalSide `json:"side,omitempty" validate:"omitempty,oneof=long short"`
+	MaxLeverage      int        `json:"max_leverage,omitempty" validate:"min=0,max=200"`
+	MinRiskReward    float64    `json:"min_risk_reward,omitempty" validate:"min=0"`
+	CopyDelaySeconds int        `json:"copy_delay_seconds,omitempty" validate:"min=0,max=86400"`
+}
+
 type Channel struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	Name      string    `gorm:"type:varchar(255);not null" json:"name" validate:"required,min=1,max=255"`
-	ChannelID string    `gorm:"type:varchar(255);not null" json:"channel_id" validate:"required,min=1,max=255"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           uuid.UUID     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID       uuid.UUID     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name         string        `gorm:"type:varchar(255);not null" json:"name" validate:"required,min=1,max=255"`
+	ChannelID    string        `gorm:"type:varchar(255);not null;index" json:"channel_id" validate:"required,min=1,max=255"`
+	ParserRules  []ParserRule  `gorm:"type:jsonb;serializer:json" json:"parser_rules,omitempty" validate:"omitempty,dive"`
+	DeletePolicy DeletePolicy  `gorm:"type:varchar(50);not null;default:ignore" json:"delete_policy" validate:"omitempty,oneof=ignore cancel_pending close_position"`
+	Filter       ChannelFilter `gorm:"type:jsonb;serializer:json" json:"filter"`
+	// Expiry overrides the user's expiry rules for signals from this channel where set
+	Expiry ExpiryRules `gorm:"type:jsonb;serializer:json" json:"expiry"`
+	// WebhookSecret keys the HMAC signature of webhook deliveries; never returned by the API
+	WebhookSecret string    `gorm:"type:varchar(128)" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Signals []Signal `gorm:"foreignKey:ChannelID" json:"signals,omitempty"`
 }
 
 type Platform struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
 	Name      string    `gorm:"type:varchar(255);not null" json:"name" validate:"required,min=1,max=255"`
-	APIKey    string    `gorm:"type:text;not null" json:"api_key" validate:"required,min=1,max=500"`
-	APISecret string    `gorm:"type:text;not null" json:"api_secret" validate:"required,min=1,max=500"`
+	APIKey    string    `gorm:"type:text;not null" json:"api_key" validate:"required_unless=Paper true,max=500"`
+	APISecret string    `gorm:"type:text;not null" json:"api_secret" validate:"required_unless=Paper true,max=500"`
+	Exchange  Exchange  `gorm:"type:varchar(50);not null;default:binance_futures" json:"exchange" validate:"omitempty,oneof=binance_futures"`
+	// Testnet routes the platform's orders to the exchange's test environment
+	Testnet bool `gorm:"type:boolean;not null;default:false" json:"testnet"`
+	// Paper platforms trade against an in-process simulation and never reach the exchange
+	Paper     bool      `gorm:"type:boolean;not null;default:false" json:"paper"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -45,15 +90,109 @@ type Platform struct {
 type TradeSettings struct {
 	ID                  uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	UserID              uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	PerTradeAmount      float64   `gorm:"type:decimal(20,8);not null;default:0" json:"per_trade_amount" validate:"required,min=0"`
+	PerTradeAmount      float64   `gorm:"type:decimal(20,8);not null;default:0" json:"per_trade_amount" validate:"min=0"`
 	StopLossPercentage  int       `gorm:"type:integer;not null;default:0" json:"stop_loss_percentage" validate:"required,min=0,max=100"`
 	StopLossStatus      bool      `gorm:"type:boolean;not null;default:false" json:"stop_loss_status"`
 	OverRangePercentage float64   `gorm:"type:decimal(5,2);not null;default:0" json:"over_range_percentage" validate:"required,min=0"`
 	TakeProfitStatus    bool      `gorm:"type:boolean;not null;default:false" json:"take_profit_status"`
 	TakeProfitStep      int       `gorm:"type:integer;not null;default:1" json:"take_profit_step" validate:"required,min=1"`
-	TPPercentage        []float64 `gorm:"type:jsonb" json:"tp_percentage" validate:"required,dive,min=0,max=100"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	TPPercentage        []float64 `gorm:"type:jsonb;serializer:json" json:"tp_percentage" validate:"required,dive,min=0,max=100"`
+	StopRules           `gorm:"embedded"`
+	ExpiryRules         `gorm:"embedded;embeddedPrefix:expiry_"`
+	RiskLimits          `gorm:"embedded;embeddedPrefix:risk_"`
+
+	// SizingMode selects how quantities are computed; PerTradeAmount is used by fixed_amount
+	SizingMode SizingMode `gorm:"type:varchar(30);not null;default:fixed_amount" json:"sizing_mode" validate:"omitempty,oneof=fixed_amount balance_percentage fixed_risk fixed_quantity"`
+	// BalancePercentage is the share of available balance committed as margin in balance_percentage mode
+	BalancePercentage float64 `gorm:"type:decimal(5,2);not null;default:0" json:"balance_percentage" validate:"min=0,max=100"`
+	// RiskAmount is the quote amount lost if the stop is hit in fixed_risk mode
+	RiskAmount float64 `gorm:"type:decimal(20,8);not null;default:0" json:"risk_amount" validate:"min=0"`
+	// FixedQuantity is the contract quantity traded in fixed_quantity mode
+	FixedQuantity float64 `gorm:"type:decimal(20,8);not null;default:0" json:"fixed_quantity" validate:"min=0"`
+	// MaxNotional caps the notional of any position; 0 means no cap
+	MaxNotional float64 `gorm:"type:decimal(20,8);not null;default:0" json:"max_notional" validate:"min=0"`
+
+	// LeverageSource says whether the signal's leverage is followed or DefaultLeverage is always used
+	LeverageSource LeverageSource `gorm:"type:varchar(10);not null;default:signal" json:"leverage_source" validate:"omitempty,oneof=signal default"`
+	// DefaultLeverage is used when the signal gives no leverage or LeverageSource is default
+	DefaultLeverage int `gorm:"type:integer;not null;default:1" json:"default_leverage" validate:"min=0,max=125"`
+	// MaxLeverage caps the leverage a signal may ask for; 0 means no cap
+	MaxLeverage int        `gorm:"type:integer;not null;default:0" json:"max_leverage" validate:"min=0,max=125"`
+	MarginMode  MarginMode `gorm:"type:varchar(10);not null;default:cross" json:"margin_mode" validate:"omitempty,oneof=isolated cross"`
+
+	// EntryStrategy says where in the signal's entry zone positions are entered
+	EntryStrategy EntryStrategy `gorm:"type:varchar(20);not null;default:first_price" json:"entry_strategy" validate:"omitempty,oneof=first_price midpoint scaled"`
+	// ScaledEntryCount is how many limit orders a scaled entry spreads across the zone
+	ScaledEntryCount int `gorm:"type:integer;not null;default:0" json:"scaled_entry_count" validate:"min=0,max=10"`
+	// ScaledEntryWeights are the percentages of the position entered at each scaled order, nearest
+	// price first; empty splits the position evenly
+	ScaledEntryWeights []float64 `gorm:"type:jsonb;serializer:json" json:"scaled_entry_weights" validate:"dive,min=0,max=100"`
+
+	// TradingPaused stops new signals of the user from being executed; it is changed only through
+	// the trading pause and resume endpoints
+	TradingPaused bool `gorm:"type:boolean;not null;default:false" json:"trading_paused"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SizingMode selects how the quantity of a position is computed
+type SizingMode string
+
+const (
+	// SizingModeFixedAmount trades PerTradeAmount of quote notional
+	SizingModeFixedAmount       SizingMode = "fixed_amount"
+	SizingModeBalancePercentage SizingMode = "balance_percentage"
+	SizingModeFixedRisk         SizingMode = "fixed_risk"
+	SizingModeFixedQuantity     SizingMode = "fixed_quantity"
+)
+
+// LeverageSource selects where the leverage of a position comes from
+type LeverageSource string
+
+const (
+	LeverageSourceSignal  LeverageSource = "signal"
+	LeverageSourceDefault LeverageSource = "default"
+)
+
+// EntryStrategy selects where in a signal's entry zone a position is entered
+type EntryStrategy string
+
+const (
+	// EntryStrategyFirstPrice enters at market inside the zone, or waits at the edge reached first
+	EntryStrategyFirstPrice EntryStrategy = "first_price"
+	// EntryStrategyMidpoint waits at the middle of the zone unless the price is already better
+	EntryStrategyMidpoint EntryStrategy = "midpoint"
+	// EntryStrategyScaled spreads ScaledEntryCount limit orders across the zone
+	EntryStrategyScaled EntryStrategy = "scaled"
+)
+
+// MarginMode is how the margin of a futures position is held
+type MarginMode string
+
+const (
+	MarginModeIsolated MarginMode = "isolated"
+	MarginModeCross    MarginMode = "cross"
+)
+
+// StopRules say how the stop loss follows a position as its take profit targets fill
+type StopRules struct {
+	// BreakEvenAfterTP1 moves the stop to the entry price once the first target fills
+	BreakEvenAfterTP1 bool `gorm:"type:boolean;not null;default:false" json:"break_even_after_tp1"`
+	// TrailToPreviousTP moves the stop to the previous target after each fill, and to entry after TP1
+	TrailToPreviousTP bool `gorm:"type:boolean;not null;default:false" json:"trail_to_previous_tp"`
+	// TrailingStopPercentage trails the stop this far behind the best price seen once TP1 fills; 0 disables it
+	TrailingStopPercentage float64 `gorm:"type:decimal(5,2);not null;default:0" json:"trailing_stop_percentage" validate:"min=0,max=50"`
+}
+
+// ExpiryRules time out entries and positions that did not play out; zero values disable a rule
+type ExpiryRules struct {
+	// EntryTimeoutMinutes cancels entries still unfilled this long after the position was created
+	EntryTimeoutMinutes int `gorm:"type:integer;not null;default:0" json:"entry_timeout_minutes" validate:"min=0,max=43200"`
+	// CancelEntryAtTP1 cancels a position still waiting for its entry once the price reaches TP1
+	CancelEntryAtTP1 bool `gorm:"type:boolean;not null;default:false" json:"cancel_entry_at_tp1"`
+	// MaxHoldingHours closes a position at market this long after it opened
+	MaxHoldingHours int `gorm:"type:integer;not null;default:0" json:"max_holding_hours" validate:"min=0,max=8760"`
 }
 
 type PackageType string
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderPurpose says what an order does for its signal
+type OrderPurpose string
+
+const (
+	OrderPurposeEntry      OrderPurpose = "entry"
+	OrderPurposeStopLoss   OrderPurpose = "stop_loss"
+	OrderPurposeTakeProfit OrderPurpose = "take_profit"
+	// OrderPurposeClose orders reduce a position at market on a management command
+	OrderPurposeClose OrderPurpose = "close"
+)
+
+type OrderStatus string
+
+const (
+	// OrderStatusPending orders are recorded but not yet sent, e.g. protection waiting for its entry
+	// to fill. A pending order with SubmittedAt set was sent without a confirmed outcome.
+	OrderStatusPending         OrderStatus = "pending"
+	OrderStatusNew             OrderStatus = "new"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusRejected        OrderStatus = "rejected"
+	OrderStatusExpired         OrderStatus = "expired"
+)
+
+// WorkingOrderStatuses lists the statuses of orders resting on the exchange
+var WorkingOrderStatuses = []OrderStatus{
+	OrderStatusNew,
+	OrderStatusPartiallyFilled,
+}
+
+// Order is an order the copier derived from a signal for one of the user's platforms
+type Order struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID    `gorm:"type:uuid;not null;index" json:"user_id"`
+	SignalID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"signal_id"`
+	PlatformID uuid.UUID    `gorm:"type:uuid;not null;index" json:"platform_id"`
+	PositionID *uuid.UUID   `gorm:"type:uuid;index" json:"position_id,omitempty"`
+	Purpose    OrderPurpose `gorm:"type:varchar(20);not null" json:"purpose"`
+	Leg        int          `gorm:"type:integer;not null;default:1" json:"leg"`
+	// Revision counts how often the leg was replaced with a resized order
+	Revision int `gorm:"type:integer;not null;default:0" json:"revision"`
+	// Symbol is the contract traded on the platform, which may differ from the signal's symbol
+	Symbol          string      `gorm:"type:varchar(50);not null" json:"symbol"`
+	Side            string      `gorm:"type:varchar(10);not null" json:"side"`
+	Type            string      `gorm:"type:varchar(30);not null" json:"type"`
+	Price           float64     `gorm:"type:decimal(20,8);not null;default:0" json:"price"`
+	Quantity        float64     `gorm:"type:decimal(20,8);not null;default:0" json:"quantity"`
+	ReduceOnly      bool        `gorm:"type:boolean;not null;default:false" json:"reduce_only"`
+	Status          OrderStatus `gorm:"type:varchar(30);not null;index" json:"status"`
+	FilledQuantity  float64     `gorm:"type:decimal(20,8);not null;default:0" json:"filled_quantity"`
+	AvgFillPrice    float64     `gorm:"type:decimal(20,8);not null;default:0" json:"avg_fill_price"`
+	ExchangeOrderID string      `gorm:"type:varchar(100);index" json:"exchange_order_id,omitempty"`
+	// ClientOrderID is derived from the signal, position and leg and stored before the order is
+	// sent, so an order whose placement outcome is unknown can be looked up instead of resent
+	ClientOrderID string `gorm:"type:varchar(100);index" json:"client_order_id,omitempty"`
+	// Error is the exchange's reason for rejecting the order
+	Error string `gorm:"type:text" json:"error,omitempty"`
+	// CancelReason says why the copier cancelled the order, e.g. an entry time-out
+	CancelReason string     `gorm:"type:text" json:"cancel_reason,omitempty"`
+	SubmittedAt  *time.Time `json:"submitted_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
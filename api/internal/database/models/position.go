@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PositionStatus string
+
+const (
+	// PositionStatusPendingEntry positions wait for their entry order to fill
+	PositionStatusPendingEntry    PositionStatus = "pending_entry"
+	PositionStatusPartiallyFilled PositionStatus = "partially_filled"
+	PositionStatusOpen            PositionStatus = "open"
+	PositionStatusPartiallyClosed PositionStatus = "partially_closed"
+	PositionStatusClosed          PositionStatus = "closed"
+	// PositionStatusStopped positions were closed by their stop loss
+	PositionStatusStopped   PositionStatus = "stopped"
+	PositionStatusCancelled PositionStatus = "cancelled"
+	PositionStatusExpired   PositionStatus = "expired"
+)
+
+// ActivePositionStatuses lists the statuses of positions that still hold or await exposure
+var ActivePositionStatuses = []PositionStatus{
+	PositionStatusPendingEntry,
+	PositionStatusPartiallyFilled,
+	PositionStatusOpen,
+	PositionStatusPartiallyClosed,
+}
+
+// PositionTrigger names what caused a position to change
+type PositionTrigger string
+
+const (
+	PositionTriggerCreated        PositionTrigger = "created"
+	PositionTriggerEntryFill      PositionTrigger = "entry_fill"
+	PositionTriggerEntryRejected  PositionTrigger = "entry_rejected"
+	PositionTriggerEntryCancelled PositionTrigger = "entry_cancelled"
+	PositionTriggerEntryExpired   PositionTrigger = "entry_expired"
+	PositionTriggerTakeProfit     PositionTrigger = "take_profit"
+	PositionTriggerStopLoss       PositionTrigger = "stop_loss"
+	PositionTriggerCommand        PositionTrigger = "command"
+	PositionTriggerStopMoved      PositionTrigger = "stop_moved"
+	// PositionTriggerProtectionResized changes resize the stop loss and targets to a new entry fill
+	PositionTriggerProtectionResized PositionTrigger = "protection_resized"
+	// PositionTriggerReconcile changes repair drift found by comparing a position with the exchange
+	PositionTriggerReconcile PositionTrigger = "reconcile"
+)
+
+// Position is the exposure the copier holds for one signal on one platform
+type Position struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	SignalID   uuid.UUID `gorm:"type:uuid;not null;index" json:"signal_id"`
+	PlatformID uuid.UUID `gorm:"type:uuid;not null;index" json:"platform_id"`
+	// Symbol is the contract traded on the platform, which may differ from the signal's symbol
+	Symbol string         `gorm:"type:varchar(50);not null" json:"symbol"`
+	Side   SignalSide     `gorm:"type:varchar(10);not null" json:"side"`
+	Status PositionStatus `gorm:"type:varchar(30);not null;index" json:"status"`
+	// Quantity is the planned entry size; FilledQuantity and ClosedQuantity track what actually traded
+	Quantity       float64 `gorm:"type:decimal(20,8);not null;default:0" json:"quantity"`
+	FilledQuantity float64 `gorm:"type:decimal(20,8);not null;default:0" json:"filled_quantity"`
+	ClosedQuantity float64 `gorm:"type:decimal(20,8);not null;default:0" json:"closed_quantity"`
+	EntryPrice     float64 `gorm:"type:decimal(20,8);not null;default:0" json:"entry_price"`
+	ExitPrice      float64 `gorm:"type:decimal(20,8);not null;default:0" json:"exit_price"`
+	RealizedPnL    float64 `gorm:"column:realized_pnl;type:decimal(20,8);not null;default:0" json:"realized_pnl"`
+	// Leverage and MarginMode are what was applied on the exchange before entering
+	Leverage   int        `gorm:"type:integer;not null;default:1" json:"leverage"`
+	MarginMode MarginMode `gorm:"type:varchar(10)" json:"margin_mode,omitempty"`
+	// ProtectedQuantity is the filled quantity the stop loss and take profit orders were sized for
+	ProtectedQuantity float64 `gorm:"type:decimal(20,8);not null;default:0" json:"protected_quantity"`
+	// StopPrice is the trigger of the working stop loss, 0 when the position has none
+	StopPrice float64 `gorm:"type:decimal(20,8);not null;default:0" json:"stop_price"`
+	// TargetsHit is the highest take profit leg that has completely filled
+	TargetsHit int `gorm:"type:integer;not null;default:0" json:"targets_hit"`
+	// FirstTarget is the signal's TP1 in the platform's prices, 0 when the signal has no targets
+	FirstTarget float64 `gorm:"type:decimal(20,8);not null;default:0" json:"first_target,omitempty"`
+	// PeakPrice is the best price seen while a trailing stop is active
+	PeakPrice float64 `gorm:"type:decimal(20,8);not null;default:0" json:"peak_price,omitempty"`
+	// StopRules are the user's stop rules when the position was opened
+	StopRules StopRules `gorm:"embedded;embeddedPrefix:rule_" json:"stop_rules"`
+	// Expiry are the expiry rules of the signal's channel and the user when the position was created
+	Expiry ExpiryRules `gorm:"embedded;embeddedPrefix:expiry_" json:"expiry"`
+	// EntryExpiresAt is when entries that have not filled are cancelled
+	EntryExpiresAt *time.Time `gorm:"index" json:"entry_expires_at,omitempty"`
+	// ExpiresAt is when the position is closed regardless of its targets, set once it opens
+	ExpiresAt *time.Time `gorm:"index" json:"expires_at,omitempty"`
+	// CloseReason explains why a position ended without being fully traded, e.g. a rejected entry
+	CloseReason string     `gorm:"type:text" json:"close_reason,omitempty"`
+	OpenedAt    *time.Time `json:"opened_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Orders []Order         `gorm:"foreignKey:PositionID" json:"orders,omitempty"`
+	Events []PositionEvent `gorm:"foreignKey:PositionID" json:"events,omitempty"`
+}
+
+// Remaining returns the quantity still held
+func (p *Position) Remaining() float64 {
+	return p.FilledQuantity - p.ClosedQuantity
+}
+
+// PositionEvent records one change of a position, such as a fill or a moved stop, forming its history
+type PositionEvent struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PositionID uuid.UUID       `gorm:"type:uuid;not null;index" json:"position_id"`
+	FromStatus PositionStatus  `gorm:"type:varchar(30)" json:"from_status,omitempty"`
+	ToStatus   PositionStatus  `gorm:"type:varchar(30);not null" json:"to_status"`
+	Trigger    PositionTrigger `gorm:"type:varchar(30);not null" json:"trigger"`
+	Detail     string          `gorm:"type:text" json:"detail,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
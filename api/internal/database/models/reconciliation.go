@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconciliationKind names a discrepancy between stored state and the exchange
+type ReconciliationKind string
+
+const (
+	// ReconciliationOrderChanged orders filled or ended on the exchange without the copier noticing
+	ReconciliationOrderChanged ReconciliationKind = "order_changed"
+	// ReconciliationOrderMissing orders are stored as working but unknown to the exchange
+	ReconciliationOrderMissing ReconciliationKind = "order_missing"
+	// ReconciliationOrderReopened orders are stored as ended but still rest on the exchange
+	ReconciliationOrderReopened ReconciliationKind = "order_reopened"
+	// ReconciliationOrphanOrder orders rest on the exchange but were not placed by the copier
+	ReconciliationOrphanOrder ReconciliationKind = "orphan_order"
+	// ReconciliationPositionClosed positions are stored as open but flat on the exchange
+	ReconciliationPositionClosed ReconciliationKind = "position_closed"
+	// ReconciliationPositionDrift positions hold a different quantity on the exchange
+	ReconciliationPositionDrift ReconciliationKind = "position_drift"
+	// ReconciliationUntrackedPosition positions exist on the exchange without a stored position
+	ReconciliationUntrackedPosition ReconciliationKind = "untracked_position"
+)
+
+// ReconciliationEvent is an audit record of one discrepancy found between the stored state of a
+// platform and its exchange, and whether it was repaired
+type ReconciliationEvent struct {
+	ID              uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID          uuid.UUID          `gorm:"type:uuid;not null;index" json:"user_id"`
+	PlatformID      uuid.UUID          `gorm:"type:uuid;not null;index" json:"platform_id"`
+	Kind            ReconciliationKind `gorm:"type:varchar(30);not null;index" json:"kind"`
+	Symbol          string             `gorm:"type:varchar(50)" json:"symbol,omitempty"`
+	OrderID         *uuid.UUID         `gorm:"type:uuid" json:"order_id,omitempty"`
+	PositionID      *uuid.UUID         `gorm:"type:uuid" json:"position_id,omitempty"`
+	ExchangeOrderID string             `gorm:"type:varchar(100)" json:"exchange_order_id,omitempty"`
+	// Stored and Exchange describe the state on either side, e.g. a status or a quantity
+	Stored   string `gorm:"type:text" json:"stored,omitempty"`
+	Exchange string `gorm:"type:text" json:"exchange,omitempty"`
+	// Repaired is set when the stored state was brought in line with the exchange
+	Repaired  bool      `gorm:"type:boolean;not null;default:false" json:"repaired"`
+	Detail    string    `gorm:"type:text" json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RiskRule names a risk limit that can stop a signal from being copied
+type RiskRule string
+
+const (
+	RiskRuleMaxOpenPositions  RiskRule = "max_open_positions"
+	RiskRuleMaxTotalNotional  RiskRule = "max_total_notional"
+	RiskRuleMaxSymbolNotional RiskRule = "max_symbol_notional"
+	RiskRuleMaxSideNotional   RiskRule = "max_side_notional"
+	RiskRuleDailyLossLimit    RiskRule = "daily_loss_limit"
+	RiskRuleWeeklyLossLimit   RiskRule = "weekly_loss_limit"
+)
+
+// RiskLimits bound what a user's copied positions may hold and lose; zero values disable a limit
+type RiskLimits struct {
+	// MaxOpenPositions caps the positions holding or awaiting exposure across all platforms
+	MaxOpenPositions int `gorm:"type:integer;not null;default:0" json:"max_open_positions" validate:"min=0,max=1000"`
+	// MaxTotalNotional caps the notional of all positions together
+	MaxTotalNotional float64 `gorm:"type:decimal(20,8);not null;default:0" json:"max_total_notional" validate:"min=0"`
+	// MaxSymbolNotional caps the notional held in any one symbol, both sides together
+	MaxSymbolNotional float64 `gorm:"type:decimal(20,8);not null;default:0" json:"max_symbol_notional" validate:"min=0"`
+	// MaxSideNotional caps the notional of all long or of all short positions
+	MaxSideNotional float64 `gorm:"type:decimal(20,8);not null;default:0" json:"max_side_notional" validate:"min=0"`
+	// DailyLossLimit pauses copying until the next UTC day once this much was lost today
+	DailyLossLimit float64 `gorm:"type:decimal(20,8);not null;default:0" json:"daily_loss_limit" validate:"min=0"`
+	// WeeklyLossLimit pauses copying until the next UTC week, starting Monday, once this much was
+	// lost this week
+	WeeklyLossLimit float64 `gorm:"type:decimal(20,8);not null;default:0" json:"weekly_loss_limit" validate:"min=0"`
+}
+
+// RiskRejection records a signal the risk guard refused to copy, on one platform or for all of them
+type RiskRejection struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	SignalID uuid.UUID `gorm:"type:uuid;not null;index" json:"signal_id"`
+	// PlatformID is nil when the signal was refused before any platform was tried, e.g. by a loss limit
+	PlatformID *uuid.UUID `gorm:"type:uuid" json:"platform_id,omitempty"`
+	Rule       RiskRule   `gorm:"type:varchar(30);not null;index" json:"rule"`
+	Symbol     string     `gorm:"type:varchar(50)" json:"symbol,omitempty"`
+	// Limit and Value are the configured limit and what the signal would have brought it to
+	Limit     float64   `gorm:"type:decimal(20,8)" json:"limit"`
+	Value     float64   `gorm:"type:decimal(20,8)" json:"value"`
+	Detail    string    `gorm:"type:text" json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelRoute sends a channel's signals to one of the user's platforms with its own allocation.
+// Signals of channels without routes are copied to every platform of the user.
+type ChannelRoute struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	ChannelID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_channel_route" json:"channel_id"`
+	PlatformID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_channel_route" json:"platform_id"`
+	// Weight scales the user's position size on the platform in percent; 0 keeps the full size
+	Weight float64 `gorm:"type:decimal(10,4);not null;default:0" json:"weight" validate:"min=0,max=1000"`
+	// FixedAmount trades this quote notional on the platform whatever the sizing mode, and takes
+	// precedence over Weight; 0 means not set
+	FixedAmount float64 `gorm:"type:decimal(20,8);not null;default:0" json:"fixed_amount" validate:"min=0"`
+	// Disabled routes receive none of the channel's signals but keep the channel routed
+	Disabled  bool      `gorm:"type:boolean;not null;default:false" json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PlatformResultStatus is the outcome of a signal on one platform
+type PlatformResultStatus string
+
+const (
+	PlatformResultPlaced  PlatformResultStatus = "placed"
+	PlatformResultSkipped PlatformResultStatus = "skipped"
+	PlatformResultFailed  PlatformResultStatus = "failed"
+)
+
+// PlatformResult records what executing a signal did on one platform
+type PlatformResult struct {
+	PlatformID uuid.UUID            `json:"platform_id"`
+	Name       string               `json:"name"`
+	Status     PlatformResultStatus `json:"status"`
+	Reason     string               `json:"reason,omitempty"`
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SignalSide string
+
+const (
+	SignalSideLong  SignalSide = "long"
+	SignalSideShort SignalSide = "short"
+)
+
+type SignalStatus string
+
+const (
+	SignalStatusNew       SignalStatus = "new"
+	SignalStatusCancelled SignalStatus = "cancelled"
+	SignalStatusClosed    SignalStatus = "closed"
+	// SignalStatusSuppressed marks a duplicate of a signal already received in the dedup window
+	SignalStatusSuppressed SignalStatus = "suppressed"
+	// SignalStatusFiltered marks a signal rejected by its channel's filter policy
+	SignalStatusFiltered SignalStatus = "filtered"
+	// SignalStatusActive marks a signal whose orders were placed on at least one platform
+	SignalStatusActive SignalStatus = "active"
+	// SignalStatusSkipped marks a signal the execution engine decided not to trade
+	SignalStatusSkipped SignalStatus = "skipped"
+	// SignalStatusFailed marks a signal whose orders were rejected on every platform
+	SignalStatusFailed SignalStatus = "failed"
+)
+
+// OpenSignalStatuses lists the statuses of signals that follow-up messages may still manage
+var OpenSignalStatuses = []SignalStatus{
+	SignalStatusNew,
+	SignalStatusActive,
+}
+
+type Signal struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID      uuid.UUID    `gorm:"type:uuid;not null;index" json:"user_id"`
+	ChannelID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"channel_id"`
+	MessageID   string       `gorm:"type:varchar(255);index" json:"message_id"`
+	Symbol      string       `gorm:"type:varchar(50);not null" json:"symbol"`
+	Side        SignalSide   `gorm:"type:varchar(10);not null" json:"side"`
+	EntryLow    float64      `gorm:"type:decimal(20,8);not null;default:0" json:"entry_low"`
+	EntryHigh   float64      `gorm:"type:decimal(20,8);not null;default:0" json:"entry_high"`
+	Targets     []float64    `gorm:"type:jsonb;serializer:json" json:"targets"`
+	StopLoss    *float64     `gorm:"type:decimal(20,8)" json:"stop_loss,omitempty"`
+	Leverage    *int         `gorm:"type:integer" json:"leverage,omitempty"`
+	RawText     string       `gorm:"type:text;not null" json:"raw_text"`
+	MatchedRule string       `gorm:"type:varchar(100)" json:"matched_rule"`
+	Status      SignalStatus `gorm:"type:varchar(50);not null;index" json:"status"`
+	// StatusReason explains why a signal was not executed, e.g. the duplicate it repeats
+	StatusReason string `gorm:"type:text" json:"status_reason,omitempty"`
+	// PlatformResults are the outcomes of the signal on each platform it was routed to
+	PlatformResults []PlatformResult `gorm:"type:jsonb;serializer:json" json:"platform_results,omitempty"`
+	// Fingerprint identifies the normalised signal content for de-duplication
+	Fingerprint string `gorm:"type:varchar(64);index" json:"fingerprint,omitempty"`
+	// Historical signals were imported from channel history and are never executed
+	Historical bool      `gorm:"type:boolean;not null;default:false;index" json:"historical"`
+	PostedAt   time.Time `json:"posted_at"`
+	// ExecuteAfter delays copying by the channel's copy delay; nil means immediately
+	ExecuteAfter *time.Time `json:"execute_after,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	Channel Channel        `gorm:"foreignKey:ChannelID" json:"-"`
+	Actions []SignalAction `gorm:"foreignKey:SignalID" json:"actions,omitempty"`
+	Orders  []Order        `gorm:"foreignKey:SignalID" json:"orders,omitempty"`
+	// RiskRejections are the risk limits that stopped the signal from being copied
+	RiskRejections []RiskRejection `gorm:"foreignKey:SignalID" json:"risk_rejections,omitempty"`
+}
+
+// EntryPrice returns the midpoint of the signal's entry range
+func (s *Signal) EntryPrice() float64 {
+	if s.EntryHigh == 0 {
+		return s.EntryLow
+	}
+	return (s.EntryLow + s.EntryHigh) / 2
+}
+
+type SignalActionType string
+
+const (
+	SignalActionMoveStop      SignalActionType = "move_stop"
+	SignalActionClosePartial  SignalActionType = "close_partial"
+	SignalActionCancelPending SignalActionType = "cancel_pending"
+	SignalActionCloseAll      SignalActionType = "close_all"
+	SignalActionTargetHit     SignalActionType = "target_hit"
+	SignalActionAmend         SignalActionType = "amend"
+	SignalActionSourceDeleted SignalActionType = "source_deleted"
+)
+
+type SignalActionStatus string
+
+const (
+	SignalActionStatusPending SignalActionStatus = "pending"
+	SignalActionStatusApplied SignalActionStatus = "applied"
+	SignalActionStatusSkipped SignalActionStatus = "skipped"
+	SignalActionStatusFailed  SignalActionStatus = "failed"
+	// SignalActionStatusHistorical marks actions replayed from imported history
+	SignalActionStatusHistorical SignalActionStatus = "historical"
+)
+
+// FieldChange describes one field of a signal altered by an edit of its source message
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// SignalAction records a management instruction applied to a signal, forming its history
+type SignalAction struct {
+	ID              uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SignalID        uuid.UUID          `gorm:"type:uuid;not null;index" json:"signal_id"`
+	Type            SignalActionType   `gorm:"type:varchar(50);not null" json:"type"`
+	StopPrice       *float64           `gorm:"type:decimal(20,8)" json:"stop_price,omitempty"`
+	BreakEven       bool               `gorm:"type:boolean;not null;default:false" json:"break_even"`
+	Percent         *float64           `gorm:"type:decimal(5,2)" json:"percent,omitempty"`
+	Target          *int               `gorm:"type:integer" json:"target,omitempty"`
+	SourceMessageID string             `gorm:"type:varchar(255)" json:"source_message_id"`
+	Text            string             `gorm:"type:text" json:"text"`
+	Changes         []FieldChange      `gorm:"type:jsonb;serializer:json" json:"changes,omitempty"`
+	Status          SignalActionStatus `gorm:"type:varchar(50);not null;index" json:"status"`
+	Note            string             `gorm:"type:text" json:"note,omitempty"`
+	AppliedAt       *time.Time         `json:"applied_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
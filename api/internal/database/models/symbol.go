@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Exchange identifies the venue a platform trades on
+type Exchange string
+
+const (
+	ExchangeBinanceFutures Exchange = "binance_futures"
+)
+
+// SymbolAlias maps a name used by channels to a canonical symbol. Aliases without an exchange
+// give the exchange-independent symbol (e.g. "BITCOIN" → "BTCUSDT"); exchange aliases map that
+// symbol to the contract actually traded there (e.g. "PEPEUSDT" → "1000PEPEUSDT" on Binance).
+type SymbolAlias struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Exchange Exchange  `gorm:"type:varchar(50);not null;default:'';uniqueIndex:idx_symbol_alias_exchange_alias" json:"exchange" validate:"omitempty,oneof=binance_futures"`
+	Alias    string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_symbol_alias_exchange_alias" json:"alias" validate:"required,min=2,max=50"`
+	Symbol   string    `gorm:"type:varchar(50);not null" json:"symbol" validate:"required,min=2,max=50"`
+	// Multiplier converts signal prices to contract prices, e.g. 1000 for 1000PEPEUSDT
+	Multiplier float64   `gorm:"type:decimal(20,8);not null;default:1" json:"multiplier" validate:"omitempty,gt=0"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -1,16 +1,24 @@
 package di
 
 import (
+	"context"
+
+	"copier/config"
 	"copier/database/repositories"
 	"copier/http/handlers"
+	"copier/internal/exchange"
+	"copier/internal/ingest"
 	"copier/internal/services"
+	"copier/internal/signal"
+	"copier/pkg/cache"
 
 	"gorm.io/gorm"
 )
 
 // Container holds all application dependencies
 type Container struct {
-	DB *gorm.DB
+	DB    *gorm.DB
+	Cache cache.Cache
 
 	// Repositories
 	UserRepo             repositories.UserRepository
@@ -19,14 +27,36 @@ type Container struct {
 	TradeSettingsRepo    repositories.TradeSettingsRepository
 	PackageRepo          repositories.PackageRepository
 	SubscribePackageRepo repositories.SubscribePackageRepository
+	SignalRepo           repositories.SignalRepository
+	SymbolAliasRepo      repositories.SymbolAliasRepository
+	OrderRepo            repositories.OrderRepository
+	PositionRepo         repositories.PositionRepository
+	ReconciliationRepo   repositories.ReconciliationRepository
+	RiskRepo             repositories.RiskRepository
 
 	// Services
-	UserService          services.UserService
-	PackageService       services.PackageService
-	SubscriptionService  services.SubscriptionService
-	PlatformService      services.PlatformService
-	ChannelService       services.ChannelService
-	TradeSettingsService services.TradeSettingsService
+	UserService           services.UserService
+	PackageService        services.PackageService
+	SubscriptionService   services.SubscriptionService
+	PlatformService       services.PlatformService
+	ChannelService        services.ChannelService
+	TradeSettingsService  services.TradeSettingsService
+	SignalService         services.SignalService
+	SymbolService         services.SymbolService
+	SymbolInfoService     services.SymbolInfoService
+	ExecutionService      services.ExecutionService
+	PositionService       services.PositionService
+	ReconciliationService services.ReconciliationService
+	ExpiryService         services.ExpiryService
+	KillSwitch            services.KillSwitch
+	TradingService        services.TradingService
+	RiskService           services.RiskService
+
+	Exchanges *exchange.Factory
+
+	// Signal sources
+	SignalSources *ingest.Registry
+	WebhookSource *ingest.WebhookSource
 
 	// Handlers
 	UserHandler          *handlers.UserHandler
@@ -35,13 +65,21 @@ type Container struct {
 	PlatformHandler      *handlers.PlatformHandler
 	ChannelHandler       *handlers.ChannelHandler
 	TradeSettingsHandler *handlers.TradeSettingsHandler
+	SignalHandler        *handlers.SignalHandler
+	SymbolHandler        *handlers.SymbolHandler
+	PositionHandler      *handlers.PositionHandler
+	TradingHandler       *handlers.TradingHandler
+	RiskHandler          *handlers.RiskHandler
 	WelcomeHandler       *handlers.WelcomeHandler
 	HealthHandler        *handlers.HealthHandler
 	NotFoundHandler      *handlers.NotFoundHandler
 }
 
-// NewContainer initializes all dependencies and returns a new Container instance
-func NewContainer(db *gorm.DB) *Container {
+// NewContainer initializes all dependencies and returns a new Container instance.
+// cache may be nil, in which case de-duplication falls back to database checks only.
+func NewContainer(db *gorm.DB, cache cache.Cache) *Container {
+	conf := config.GetConfig()
+
 	// 1. Repositories
 	userRepo := repositories.NewUserRepository(db)
 	channelRepo := repositories.NewChannelRepository(db)
@@ -49,28 +87,70 @@ func NewContainer(db *gorm.DB) *Container {
 	tradeSettingsRepo := repositories.NewTradeSettingsRepository(db)
 	packageRepo := repositories.NewPackageRepository(db)
 	subscribePackageRepo := repositories.NewSubscribePackageRepository(db)
+	signalRepo := repositories.NewSignalRepository(db)
+	symbolAliasRepo := repositories.NewSymbolAliasRepository(db)
+	orderRepo := repositories.NewOrderRepository(db)
+	positionRepo := repositories.NewPositionRepository(db)
+	reconciliationRepo := repositories.NewReconciliationRepository(db)
+	riskRepo := repositories.NewRiskRepository(db)
 
 	// 2. Services
 	userService := services.NewUserService(userRepo)
 	packageService := services.NewPackageService(packageRepo)
 	subscriptionService := services.NewSubscriptionService(subscribePackageRepo, packageRepo)
-	platformService := services.NewPlatformService(platformRepo)
-	channelService := services.NewChannelService(channelRepo)
+	exchanges := exchange.NewFactory(exchange.FactoryConfig{
+		BinanceFuturesURL:        conf.Exchange.BinanceFuturesURL,
+		BinanceFuturesTestnetURL: conf.Exchange.BinanceFuturesTestnetURL,
+		RecvWindow:               conf.Exchange.RecvWindow,
+		Paper: exchange.PaperConfig{
+			Balance:  conf.Exchange.PaperBalance,
+			MakerFee: conf.Exchange.PaperMakerFee,
+			TakerFee: conf.Exchange.PaperTakerFee,
+			Slippage: conf.Exchange.PaperSlippage,
+		},
+	})
+	platformService := services.NewPlatformService(platformRepo, exchanges)
+	channelService := services.NewChannelService(channelRepo, platformRepo)
 	tradeSettingsService := services.NewTradeSettingsService(tradeSettingsRepo)
+	symbolService := services.NewSymbolService(symbolAliasRepo)
+	dedup := signal.NewDeduplicator(cache, conf.Dedup.Window, conf.Dedup.ReplayWindow)
+	signalService := services.NewSignalService(signalRepo, channelRepo, tradeSettingsRepo, symbolService, dedup)
+	symbolInfoService := services.NewSymbolInfoService(cache, conf.Exchange.SymbolInfoTTL)
+	killSwitch := services.NewKillSwitch(cache)
+	riskService := services.NewRiskService(riskRepo, positionRepo, tradeSettingsRepo)
+	executionService := services.NewExecutionService(signalRepo, orderRepo, positionRepo, tradeSettingsRepo, platformRepo, channelRepo, platformService, symbolService, symbolInfoService, killSwitch, riskService, conf.Execution.MaxSignalAge)
+	positionService := services.NewPositionService(positionRepo)
+	reconciliationService := services.NewReconciliationService(reconciliationRepo, orderRepo, positionRepo, platformRepo, platformService, executionService)
+	expiryService := services.NewExpiryService(positionRepo, platformRepo, platformService, executionService)
+	tradingService := services.NewTradingService(tradeSettingsRepo, platformRepo, positionRepo, executionService, killSwitch)
 
-	// 3. Handlers
+	// 3. Signal sources
+	signalSources := ingest.NewRegistry(func(ctx context.Context, msg signal.Message) error {
+		_, err := signalService.IngestMessage(ctx, msg)
+		return err
+	})
+	webhookSource := ingest.NewWebhookSource(channelService)
+	signalSources.Register(webhookSource)
+
+	// 4. Handlers
 	userHandler := handlers.NewUserHandler(userService)
 	packageHandler := handlers.NewPackageHandler(packageService)
 	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService)
 	platformHandler := handlers.NewPlatformHandler(platformService)
-	channelHandler := handlers.NewChannelHandler(channelService)
+	channelHandler := handlers.NewChannelHandler(channelService, signalService)
 	tradeSettingsHandler := handlers.NewTradeSettingsHandler(tradeSettingsService)
+	signalHandler := handlers.NewSignalHandler(signalService, channelService)
+	symbolHandler := handlers.NewSymbolHandler(symbolService)
+	positionHandler := handlers.NewPositionHandler(positionService)
+	tradingHandler := handlers.NewTradingHandler(tradingService)
+	riskHandler := handlers.NewRiskHandler(riskService)
 	welcomeHandler := handlers.NewWelcomeHandler()
 	healthHandler := handlers.NewHealthHandler()
 	notFoundHandler := handlers.NewNotFoundHandler()
 
 	return &Container{
-		DB: db,
+		DB:    db,
+		Cache: cache,
 
 		// Repositories
 		UserRepo:             userRepo,
@@ -79,14 +159,36 @@ func NewContainer(db *gorm.DB) *Container {
 		TradeSettingsRepo:    tradeSettingsRepo,
 		PackageRepo:          packageRepo,
 		SubscribePackageRepo: subscribePackageRepo,
+		SignalRepo:           signalRepo,
+		SymbolAliasRepo:      symbolAliasRepo,
+		OrderRepo:            orderRepo,
+		PositionRepo:         positionRepo,
+		ReconciliationRepo:   reconciliationRepo,
+		RiskRepo:             riskRepo,
 
 		// Services
-		UserService:          userService,
-		PackageService:       packageService,
-		SubscriptionService:  subscriptionService,
-		PlatformService:      platformService,
-		ChannelService:       channelService,
-		TradeSettingsService: tradeSettingsService,
+		UserService:           userService,
+		PackageService:        packageService,
+		SubscriptionService:   subscriptionService,
+		PlatformService:       platformService,
+		ChannelService:        channelService,
+		TradeSettingsService:  tradeSettingsService,
+		SignalService:         signalService,
+		SymbolService:         symbolService,
+		SymbolInfoService:     symbolInfoService,
+		ExecutionService:      executionService,
+		PositionService:       positionService,
+		ReconciliationService: reconciliationService,
+		ExpiryService:         expiryService,
+		KillSwitch:            killSwitch,
+		TradingService:        tradingService,
+		RiskService:           riskService,
+
+		Exchanges: exchanges,
+
+		// Signal sources
+		SignalSources: signalSources,
+		WebhookSource: webhookSource,
 
 		// Handlers
 		UserHandler:          userHandler,
@@ -95,6 +197,11 @@ func NewContainer(db *gorm.DB) *Container {
 		PlatformHandler:      platformHandler,
 		ChannelHandler:       channelHandler,
 		TradeSettingsHandler: tradeSettingsHandler,
+		SignalHandler:        signalHandler,
+		SymbolHandler:        symbolHandler,
+		PositionHandler:      positionHandler,
+		TradingHandler:       tradingHandler,
+		RiskHandler:          riskHandler,
 		WelcomeHandler:       welcomeHandler,
 		HealthHandler:        healthHandler,
 		NotFoundHandler:      notFoundHandler,
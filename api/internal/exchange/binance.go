@@ -0,0 +1,487 @@
+package exchange
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// BinanceFuturesURL is the production USDⓈ-M futures REST endpoint
+	BinanceFuturesURL = "https://fapi.binance.com"
+	// BinanceFuturesTestnetURL is the USDⓈ-M futures testnet REST endpoint
+	BinanceFuturesTestnetURL = "https://testnet.binancefuture.com"
+
+	// DefaultRecvWindow is how long after its timestamp Binance accepts a signed request
+	DefaultRecvWindow = 5 * time.Second
+
+	binanceAPIKeyHeader = "X-MBX-APIKEY"
+
+	binanceErrTimestamp     = -1021
+	binanceErrUnknownOrder  = -2011
+	binanceErrNoSuchOrder   = -2013
+	binanceErrInvalidSymbol = -1121
+	// binanceErrNoMarginChange is returned when the symbol already uses the requested margin type
+	binanceErrNoMarginChange = -4046
+	// binanceErrDuplicateID is returned when an open order already uses the client order ID
+	binanceErrDuplicateID = -4116
+)
+
+// BinanceFuturesConfig configures a BinanceFutures client; zero values fall back to defaults
+type BinanceFuturesConfig struct {
+	BaseURL    string
+	APIKey     string
+	APISecret  string
+	RecvWindow time.Duration
+	HTTPClient *http.Client
+}
+
+// BinanceFutures is an ExchangeClient for Binance USDⓈ-M futures over the REST API
+type BinanceFutures struct {
+	baseURL    string
+	apiKey     string
+	apiSecret  string
+	recvWindow time.Duration
+	client     *http.Client
+
+	// offset is added to the local clock so request timestamps match the server's
+	mu     sync.Mutex
+	offset time.Duration
+}
+
+// NewBinanceFutures creates a Binance futures client
+func NewBinanceFutures(cfg BinanceFuturesConfig) *BinanceFutures {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = BinanceFuturesURL
+	}
+	if cfg.RecvWindow <= 0 {
+		cfg.RecvWindow = DefaultRecvWindow
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &BinanceFutures{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		apiSecret:  cfg.APISecret,
+		recvWindow: cfg.RecvWindow,
+		client:     cfg.HTTPClient,
+	}
+}
+
+func (b *BinanceFutures) Name() string {
+	return "binance_futures"
+}
+
+type binanceBalance struct {
+	Asset            string `json:"asset"`
+	Balance          string `json:"balance"`
+	AvailableBalance string `json:"availableBalance"`
+	CrossUnPnl       string `json:"crossUnPnl"`
+}
+
+func (b *BinanceFutures) Balances(ctx context.Context) ([]Balance, error) {
+	var raw []binanceBalance
+	if err := b.signed(ctx, http.MethodGet, "/fapi/v2/balance", url.Values{}, &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch balances: %w", err)
+	}
+
+	balances := make([]Balance, 0, len(raw))
+	for _, r := range raw {
+		balances = append(balances, Balance{
+			Asset:         r.Asset,
+			Balance:       parseFloat(r.Balance),
+			Available:     parseFloat(r.AvailableBalance),
+			UnrealizedPnL: parseFloat(r.CrossUnPnl),
+		})
+	}
+	return balances, nil
+}
+
+type binancePosition struct {
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	EntryPrice       string `json:"entryPrice"`
+	MarkPrice        string `json:"markPrice"`
+	UnRealizedProfit string `json:"unRealizedProfit"`
+	Leverage         string `json:"leverage"`
+	MarginType       string `json:"marginType"`
+	LiquidationPrice string `json:"liquidationPrice"`
+}
+
+func (b *BinanceFutures) Positions(ctx context.Context) ([]Position, error) {
+	var raw []binancePosition
+	if err := b.signed(ctx, http.MethodGet, "/fapi/v2/positionRisk", url.Values{}, &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+
+	var positions []Position
+	for _, r := range raw {
+		qty := parseFloat(r.PositionAmt)
+		if qty == 0 {
+			continue
+		}
+		leverage, _ := strconv.Atoi(r.Leverage)
+		positions = append(positions, Position{
+			Symbol:           r.Symbol,
+			Quantity:         qty,
+			EntryPrice:       parseFloat(r.EntryPrice),
+			MarkPrice:        parseFloat(r.MarkPrice),
+			UnrealizedPnL:    parseFloat(r.UnRealizedProfit),
+			Leverage:         leverage,
+			MarginType:       r.MarginType,
+			LiquidationPrice: parseFloat(r.LiquidationPrice),
+		})
+	}
+	return positions, nil
+}
+
+type binanceOrder struct {
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Symbol        string `json:"symbol"`
+	Side          string `json:"side"`
+	Type          string `json:"type"`
+	Status        string `json:"status"`
+	Price         string `json:"price"`
+	StopPrice     string `json:"stopPrice"`
+	OrigQty       string `json:"origQty"`
+	ExecutedQty   string `json:"executedQty"`
+	AvgPrice      string `json:"avgPrice"`
+	ReduceOnly    bool   `json:"reduceOnly"`
+	UpdateTime    int64  `json:"updateTime"`
+}
+
+func (o *binanceOrder) toOrder() Order {
+	return Order{
+		OrderID:       strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID: o.ClientOrderID,
+		Symbol:        o.Symbol,
+		Side:          Side(o.Side),
+		Type:          OrderType(o.Type),
+		Status:        OrderStatus(o.Status),
+		Price:         parseFloat(o.Price),
+		StopPrice:     parseFloat(o.StopPrice),
+		Quantity:      parseFloat(o.OrigQty),
+		ExecutedQty:   parseFloat(o.ExecutedQty),
+		AvgPrice:      parseFloat(o.AvgPrice),
+		ReduceOnly:    o.ReduceOnly,
+		UpdatedAt:     time.UnixMilli(o.UpdateTime).UTC(),
+	}
+}
+
+func (b *BinanceFutures) PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", req.Symbol)
+	params.Set("side", string(req.Side))
+	params.Set("type", string(req.Type))
+
+	switch req.Type {
+	case OrderTypeLimit:
+		params.Set("timeInForce", "GTC")
+		params.Set("price", formatFloat(req.Price))
+	case OrderTypeStopMarket, OrderTypeTakeProfit:
+		params.Set("stopPrice", formatFloat(req.Price))
+		params.Set("workingType", "MARK_PRICE")
+	}
+
+	// closePosition orders carry neither a quantity nor reduceOnly
+	if req.ClosePosition {
+		params.Set("closePosition", "true")
+	} else {
+		params.Set("quantity", formatFloat(req.Quantity))
+		if req.ReduceOnly {
+			params.Set("reduceOnly", "true")
+		}
+	}
+	if req.ClientOrderID != "" {
+		params.Set("newClientOrderId", req.ClientOrderID)
+	}
+
+	var raw binanceOrder
+	if err := b.signed(ctx, http.MethodPost, "/fapi/v1/order", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to place %s %s order on %s: %w", req.Side, req.Type, req.Symbol, err)
+	}
+	order := raw.toOrder()
+	return &order, nil
+}
+
+func (b *BinanceFutures) Price(ctx context.Context, symbol string) (float64, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var raw struct {
+		Price string `json:"price"`
+	}
+	if err := b.public(ctx, "/fapi/v1/ticker/price", params, &raw); err != nil {
+		return 0, fmt.Errorf("failed to fetch %s price: %w", symbol, err)
+	}
+	return parseFloat(raw.Price), nil
+}
+
+func (b *BinanceFutures) GetOrder(ctx context.Context, symbol, orderID string) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", orderID)
+
+	var raw binanceOrder
+	if err := b.signed(ctx, http.MethodGet, "/fapi/v1/order", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to query order %s: %w", orderID, err)
+	}
+	order := raw.toOrder()
+	return &order, nil
+}
+
+func (b *BinanceFutures) GetOrderByClientID(ctx context.Context, symbol, clientOrderID string) (*Order, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("origClientOrderId", clientOrderID)
+
+	var raw binanceOrder
+	if err := b.signed(ctx, http.MethodGet, "/fapi/v1/order", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to query order %s: %w", clientOrderID, err)
+	}
+	order := raw.toOrder()
+	return &order, nil
+}
+
+func (b *BinanceFutures) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", orderID)
+
+	if err := b.signed(ctx, http.MethodDelete, "/fapi/v1/order", params, nil); err != nil {
+		return fmt.Errorf("failed to cancel order %s: %w", orderID, err)
+	}
+	return nil
+}
+
+func (b *BinanceFutures) OpenOrders(ctx context.Context, symbol string) ([]Order, error) {
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+
+	var raw []binanceOrder
+	if err := b.signed(ctx, http.MethodGet, "/fapi/v1/openOrders", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch open orders: %w", err)
+	}
+
+	orders := make([]Order, 0, len(raw))
+	for i := range raw {
+		orders = append(orders, raw[i].toOrder())
+	}
+	return orders, nil
+}
+
+type binanceExchangeInfo struct {
+	Symbols []struct {
+		Symbol     string `json:"symbol"`
+		Status     string `json:"status"`
+		BaseAsset  string `json:"baseAsset"`
+		QuoteAsset string `json:"quoteAsset"`
+		Filters    []struct {
+			FilterType string `json:"filterType"`
+			TickSize   string `json:"tickSize"`
+			StepSize   string `json:"stepSize"`
+			MinQty     string `json:"minQty"`
+			MaxQty     string `json:"maxQty"`
+			Notional   string `json:"notional"`
+		} `json:"filters"`
+	} `json:"symbols"`
+}
+
+func (b *BinanceFutures) SymbolInfo(ctx context.Context, symbol string) (*SymbolInfo, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	var raw binanceExchangeInfo
+	if err := b.public(ctx, "/fapi/v1/exchangeInfo", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange info: %w", err)
+	}
+
+	for _, s := range raw.Symbols {
+		if s.Symbol != symbol {
+			continue
+		}
+		info := &SymbolInfo{
+			Symbol:     s.Symbol,
+			Status:     s.Status,
+			BaseAsset:  s.BaseAsset,
+			QuoteAsset: s.QuoteAsset,
+		}
+		for _, f := range s.Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				info.TickSize = parseFloat(f.TickSize)
+			case "LOT_SIZE":
+				info.StepSize = parseFloat(f.StepSize)
+				info.MinQty = parseFloat(f.MinQty)
+				info.MaxQty = parseFloat(f.MaxQty)
+			case "MIN_NOTIONAL":
+				info.MinNotional = parseFloat(f.Notional)
+			}
+		}
+		return info, nil
+	}
+	return nil, fmt.Errorf("%s: %w", symbol, ErrUnknownSymbol)
+}
+
+func (b *BinanceFutures) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("leverage", strconv.Itoa(leverage))
+
+	if err := b.signed(ctx, http.MethodPost, "/fapi/v1/leverage", params, nil); err != nil {
+		return fmt.Errorf("failed to set %s leverage to %d: %w", symbol, leverage, err)
+	}
+	return nil
+}
+
+func (b *BinanceFutures) SetMarginType(ctx context.Context, symbol string, marginType MarginType) error {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("marginType", string(marginType))
+
+	err := b.signed(ctx, http.MethodPost, "/fapi/v1/marginType", params, nil)
+	var apiErr *APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.Code == binanceErrNoMarginChange) {
+		return fmt.Errorf("failed to set %s margin type to %s: %w", symbol, marginType, err)
+	}
+	return nil
+}
+
+// SyncTime measures the offset between the local clock and the server's, so signed requests
+// stay inside the receive window when the host clock drifts
+func (b *BinanceFutures) SyncTime(ctx context.Context) error {
+	var raw struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	before := time.Now()
+	if err := b.public(ctx, "/fapi/v1/time", url.Values{}, &raw); err != nil {
+		return fmt.Errorf("failed to fetch server time: %w", err)
+	}
+	// assume the server stamped the response halfway through the round trip
+	local := before.Add(time.Since(before) / 2)
+
+	b.mu.Lock()
+	b.offset = time.UnixMilli(raw.ServerTime).Sub(local)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *BinanceFutures) now() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().Add(b.offset)
+}
+
+// signed sends an authenticated request. A request rejected for falling outside the receive
+// window is retried once after re-synchronising the clock.
+func (b *BinanceFutures) signed(ctx context.Context, method, path string, params url.Values, out any) error {
+	err := b.doSigned(ctx, method, path, params, out)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == binanceErrTimestamp {
+		if syncErr := b.SyncTime(ctx); syncErr != nil {
+			return err
+		}
+		err = b.doSigned(ctx, method, path, params, out)
+	}
+	return err
+}
+
+func (b *BinanceFutures) doSigned(ctx context.Context, method, path string, params url.Values, out any) error {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set("recvWindow", strconv.FormatInt(b.recvWindow.Milliseconds(), 10))
+	query.Set("timestamp", strconv.FormatInt(b.now().UnixMilli(), 10))
+
+	payload := query.Encode()
+	payload += "&signature=" + b.sign(payload)
+
+	return b.do(ctx, method, path, payload, true, out)
+}
+
+func (b *BinanceFutures) public(ctx context.Context, path string, params url.Values, out any) error {
+	return b.do(ctx, http.MethodGet, path, params.Encode(), false, out)
+}
+
+// sign returns the hex HMAC-SHA256 of payload keyed by the API secret
+func (b *BinanceFutures) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(b.apiSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *BinanceFutures) do(ctx context.Context, method, path, payload string, auth bool, out any) error {
+	endpoint := b.baseURL + path
+	if payload != "" {
+		endpoint += "?" + payload
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if auth {
+		req.Header.Set(binanceAPIKeyHeader, b.apiKey)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{Status: resp.StatusCode}
+		if json.Unmarshal(body, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(body))
+		}
+		switch apiErr.Code {
+		case binanceErrUnknownOrder, binanceErrNoSuchOrder:
+			return fmt.Errorf("%w: %w", ErrOrderNotFound, apiErr)
+		case binanceErrInvalidSymbol:
+			return fmt.Errorf("%w: %w", ErrUnknownSymbol, apiErr)
+		case binanceErrDuplicateID:
+			return fmt.Errorf("%w: %w", ErrDuplicateClientOrderID, apiErr)
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func parseFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,170 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Side string
+
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+)
+
+type OrderType string
+
+const (
+	OrderTypeLimit      OrderType = "LIMIT"
+	OrderTypeMarket     OrderType = "MARKET"
+	OrderTypeStopMarket OrderType = "STOP_MARKET"
+	OrderTypeTakeProfit OrderType = "TAKE_PROFIT_MARKET"
+)
+
+// MarginType is how the margin of a symbol's position is held
+type MarginType string
+
+const (
+	MarginTypeIsolated MarginType = "ISOLATED"
+	MarginTypeCross    MarginType = "CROSSED"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCanceled        OrderStatus = "CANCELED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+)
+
+// Open reports whether the order can still fill
+func (s OrderStatus) Open() bool {
+	return s == OrderStatusNew || s == OrderStatusPartiallyFilled
+}
+
+// Balance is the wallet balance of one asset
+type Balance struct {
+	Asset         string  `json:"asset"`
+	Balance       float64 `json:"balance"`
+	Available     float64 `json:"available"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+}
+
+// Position is an open position; Quantity is negative for shorts
+type Position struct {
+	Symbol           string  `json:"symbol"`
+	Quantity         float64 `json:"quantity"`
+	EntryPrice       float64 `json:"entry_price"`
+	MarkPrice        float64 `json:"mark_price"`
+	UnrealizedPnL    float64 `json:"unrealized_pnl"`
+	Leverage         int     `json:"leverage"`
+	MarginType       string  `json:"margin_type"`
+	LiquidationPrice float64 `json:"liquidation_price"`
+}
+
+// OrderRequest describes an order to place. Price is the limit price of LIMIT orders and the
+// trigger price of stop and take profit orders.
+type OrderRequest struct {
+	Symbol        string    `json:"symbol"`
+	Side          Side      `json:"side"`
+	Type          OrderType `json:"type"`
+	Quantity      float64   `json:"quantity"`
+	Price         float64   `json:"price,omitempty"`
+	ReduceOnly    bool      `json:"reduce_only,omitempty"`
+	ClosePosition bool      `json:"close_position,omitempty"`
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+}
+
+// Order is an order as reported by the exchange
+type Order struct {
+	OrderID       string      `json:"order_id"`
+	ClientOrderID string      `json:"client_order_id"`
+	Symbol        string      `json:"symbol"`
+	Side          Side        `json:"side"`
+	Type          OrderType   `json:"type"`
+	Status        OrderStatus `json:"status"`
+	Price         float64     `json:"price"`
+	StopPrice     float64     `json:"stop_price"`
+	Quantity      float64     `json:"quantity"`
+	ExecutedQty   float64     `json:"executed_qty"`
+	AvgPrice      float64     `json:"avg_price"`
+	ReduceOnly    bool        `json:"reduce_only"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// SymbolInfo holds the trading rules of a contract
+type SymbolInfo struct {
+	Symbol      string  `json:"symbol"`
+	Status      string  `json:"status"`
+	BaseAsset   string  `json:"base_asset"`
+	QuoteAsset  string  `json:"quote_asset"`
+	TickSize    float64 `json:"tick_size"`
+	StepSize    float64 `json:"step_size"`
+	MinQty      float64 `json:"min_qty"`
+	MaxQty      float64 `json:"max_qty"`
+	MinNotional float64 `json:"min_notional"`
+}
+
+// ExchangeClient is the account-level API of a trading venue used to copy signals
+type ExchangeClient interface {
+	// Name identifies the venue, e.g. "binance_futures"
+	Name() string
+	Balances(ctx context.Context) ([]Balance, error)
+	// Positions returns the open (non-zero) positions of the account
+	Positions(ctx context.Context) ([]Position, error)
+	// Price returns the last traded price of symbol
+	Price(ctx context.Context, symbol string) (float64, error)
+	PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error)
+	// GetOrder returns an order in any state; ErrOrderNotFound when the exchange has no such order
+	GetOrder(ctx context.Context, symbol, orderID string) (*Order, error)
+	// GetOrderByClientID looks an order up by the client order ID it was placed with, so an order
+	// whose placement timed out can be found; ErrOrderNotFound when it was never placed
+	GetOrderByClientID(ctx context.Context, symbol, clientOrderID string) (*Order, error)
+	CancelOrder(ctx context.Context, symbol, orderID string) error
+	// OpenOrders returns open orders for symbol, or for every symbol when it is empty
+	OpenOrders(ctx context.Context, symbol string) ([]Order, error)
+	SymbolInfo(ctx context.Context, symbol string) (*SymbolInfo, error)
+	SetLeverage(ctx context.Context, symbol string, leverage int) error
+	// SetMarginType switches symbol to isolated or cross margin; setting the current type is not an error
+	SetMarginType(ctx context.Context, symbol string, marginType MarginType) error
+}
+
+var (
+	ErrUnknownSymbol       = errors.New("symbol is not traded on this exchange")
+	ErrOrderNotFound       = errors.New("order not found on exchange")
+	ErrUnsupportedExchange = errors.New("unsupported exchange")
+	// ErrDuplicateClientOrderID is returned when an open order already uses the client order ID
+	ErrDuplicateClientOrderID = errors.New("client order ID is already in use")
+)
+
+// APIError is an error returned by the exchange itself rather than by the transport
+type APIError struct {
+	Status  int    `json:"status"`
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("exchange error %d (http %d): %s", e.Code, e.Status, e.Message)
+}
+
+// OutcomeUnknown reports whether a failed request may still have been carried out by the
+// exchange, such as after a timeout or an internal exchange error, rather than being refused
+func OutcomeUnknown(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,82 @@
+package exchange
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+)
+
+// FactoryConfig holds the endpoints and transport settings shared by every client
+type FactoryConfig struct {
+	BinanceFuturesURL        string
+	BinanceFuturesTestnetURL string
+	RecvWindow               time.Duration
+	HTTPClient               *http.Client
+	Paper                    PaperConfig
+}
+
+// Factory builds the ExchangeClient for a user's platform. Paper platforms share one
+// simulated account per platform for the life of the process.
+type Factory struct {
+	conf FactoryConfig
+
+	mu    sync.Mutex
+	paper map[uuid.UUID]*PaperExchange
+}
+
+// NewFactory creates a client factory; empty URLs fall back to the public endpoints
+func NewFactory(conf FactoryConfig) *Factory {
+	if conf.BinanceFuturesURL == "" {
+		conf.BinanceFuturesURL = BinanceFuturesURL
+	}
+	if conf.BinanceFuturesTestnetURL == "" {
+		conf.BinanceFuturesTestnetURL = BinanceFuturesTestnetURL
+	}
+	return &Factory{
+		conf:  conf,
+		paper: make(map[uuid.UUID]*PaperExchange),
+	}
+}
+
+// Paper returns the simulated exchange of a paper platform, creating it on first use
+func (f *Factory) Paper(platformID uuid.UUID) *PaperExchange {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paper, ok := f.paper[platformID]
+	if !ok {
+		paper = NewPaperExchange(f.conf.Paper)
+		f.paper[platformID] = paper
+	}
+	return paper
+}
+
+// ForPlatform returns a client authenticated with the platform's API credentials, or the
+// simulation of a paper platform
+func (f *Factory) ForPlatform(platform *models.Platform) (ExchangeClient, error) {
+	if platform.Paper {
+		return f.Paper(platform.ID), nil
+	}
+
+	switch platform.Exchange {
+	case models.ExchangeBinanceFutures, "":
+		baseURL := f.conf.BinanceFuturesURL
+		if platform.Testnet {
+			baseURL = f.conf.BinanceFuturesTestnetURL
+		}
+		return NewBinanceFutures(BinanceFuturesConfig{
+			BaseURL:    baseURL,
+			APIKey:     platform.APIKey,
+			APISecret:  platform.APISecret,
+			RecvWindow: f.conf.RecvWindow,
+			HTTPClient: f.conf.HTTPClient,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedExchange, platform.Exchange)
+	}
+}
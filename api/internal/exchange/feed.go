@@ -0,0 +1,73 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePriceCSV reads ticks for replaying into a PaperExchange. Each record is
+// "time,symbol,price" where time is RFC 3339 or unix seconds/milliseconds and may be empty;
+// a header row is skipped.
+func ParsePriceCSV(r io.Reader) ([]Tick, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var ticks []Tick
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read price feed: %w", err)
+		}
+
+		price, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid price %q", line, record[2])
+		}
+		if price <= 0 {
+			return nil, fmt.Errorf("line %d: price must be positive", line)
+		}
+
+		at, err := parseTickTime(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		ticks = append(ticks, Tick{
+			Symbol: strings.ToUpper(strings.TrimSpace(record[1])),
+			Price:  price,
+			Time:   at,
+		})
+	}
+	return ticks, nil
+}
+
+func parseTickTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		// values this large are milliseconds
+		if n > 1e11 {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", s)
+	}
+	return t.UTC(), nil
+}
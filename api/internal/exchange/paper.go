@@ -0,0 +1,475 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPaperBalance  = 10000.0
+	DefaultPaperMakerFee = 0.0002
+	DefaultPaperTakerFee = 0.0004
+	DefaultPaperSlippage = 0.0005
+
+	paperQuoteAsset = "USDT"
+)
+
+// ErrNoPrice is returned when an order needs a market price the feed has not provided yet
+var ErrNoPrice = errors.New("no price received for symbol")
+
+// PaperConfig configures a simulated account; fees and slippage are fractions of notional
+// (0.0004 = 0.04%) and negative values disable them
+type PaperConfig struct {
+	Balance  float64
+	MakerFee float64
+	TakerFee float64
+	Slippage float64
+	// Symbols overrides the trading rules reported by SymbolInfo; others get permissive defaults
+	Symbols map[string]SymbolInfo
+}
+
+// Tick is one price observation of a symbol
+type Tick struct {
+	Symbol string    `json:"symbol" validate:"required,min=2,max=50"`
+	Price  float64   `json:"price" validate:"required,gt=0"`
+	Time   time.Time `json:"time"`
+}
+
+type paperPosition struct {
+	quantity   float64
+	entryPrice float64
+}
+
+// PaperExchange is an in-process ExchangeClient with simulated balances, positions and orders.
+// Orders fill against prices pushed with Tick: market orders at the last price plus slippage,
+// limit orders when the price trades through them, and stop and take profit orders when their
+// trigger is crossed. Positions are one-way and margined in USDT.
+type PaperExchange struct {
+	conf PaperConfig
+
+	mu        sync.Mutex
+	balance   float64
+	prices    map[string]float64
+	positions map[string]*paperPosition
+	leverage  map[string]int
+	margin    map[string]MarginType
+	orders    map[string]*Order
+	sequence  int64
+	now       time.Time
+}
+
+// NewPaperExchange creates a simulated account; zero config values fall back to the defaults
+func NewPaperExchange(conf PaperConfig) *PaperExchange {
+	if conf.Balance == 0 {
+		conf.Balance = DefaultPaperBalance
+	}
+	if conf.MakerFee == 0 {
+		conf.MakerFee = DefaultPaperMakerFee
+	}
+	if conf.TakerFee == 0 {
+		conf.TakerFee = DefaultPaperTakerFee
+	}
+	if conf.Slippage == 0 {
+		conf.Slippage = DefaultPaperSlippage
+	}
+	conf.MakerFee = math.Max(conf.MakerFee, 0)
+	conf.TakerFee = math.Max(conf.TakerFee, 0)
+	conf.Slippage = math.Max(conf.Slippage, 0)
+
+	return &PaperExchange{
+		conf:      conf,
+		balance:   conf.Balance,
+		prices:    make(map[string]float64),
+		positions: make(map[string]*paperPosition),
+		leverage:  make(map[string]int),
+		margin:    make(map[string]MarginType),
+		orders:    make(map[string]*Order),
+	}
+}
+
+func (p *PaperExchange) Name() string {
+	return "paper"
+}
+
+// Tick records a new price for a symbol and fills every resting order it reaches, oldest first
+func (p *PaperExchange) Tick(tick Tick) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	symbol := strings.ToUpper(tick.Symbol)
+	p.prices[symbol] = tick.Price
+	if !tick.Time.IsZero() {
+		p.now = tick.Time
+	}
+
+	for _, order := range p.restingOrders(symbol) {
+		switch {
+		case order.Type == OrderTypeLimit && limitReached(order, tick.Price):
+			p.fill(order, order.Price, p.conf.MakerFee)
+		case order.Type != OrderTypeLimit && triggerReached(order, tick.Price):
+			p.fill(order, p.slipped(order.Side, tick.Price), p.conf.TakerFee)
+		}
+	}
+}
+
+// Replay applies ticks in order
+func (p *PaperExchange) Replay(ticks []Tick) {
+	for _, tick := range ticks {
+		p.Tick(tick)
+	}
+}
+
+// Price returns the last price received for symbol
+func (p *PaperExchange) Price(ctx context.Context, symbol string) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	price, ok := p.prices[strings.ToUpper(symbol)]
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", symbol, ErrNoPrice)
+	}
+	return price, nil
+}
+
+func (p *PaperExchange) Balances(ctx context.Context) ([]Balance, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	unrealized, margin := 0.0, 0.0
+	for symbol, pos := range p.positions {
+		mark := p.markPrice(symbol, pos)
+		unrealized += (mark - pos.entryPrice) * pos.quantity
+		margin += math.Abs(pos.quantity) * mark / float64(p.leverageOf(symbol))
+	}
+
+	return []Balance{{
+		Asset:         paperQuoteAsset,
+		Balance:       p.balance,
+		Available:     p.balance + unrealized - margin,
+		UnrealizedPnL: unrealized,
+	}}, nil
+}
+
+func (p *PaperExchange) Positions(ctx context.Context) ([]Position, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	positions := make([]Position, 0, len(p.positions))
+	for symbol, pos := range p.positions {
+		mark := p.markPrice(symbol, pos)
+		positions = append(positions, Position{
+			Symbol:        symbol,
+			Quantity:      pos.quantity,
+			EntryPrice:    pos.entryPrice,
+			MarkPrice:     mark,
+			UnrealizedPnL: (mark - pos.entryPrice) * pos.quantity,
+			Leverage:      p.leverageOf(symbol),
+			MarginType:    p.marginTypeOf(symbol),
+		})
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Symbol < positions[j].Symbol })
+	return positions, nil
+}
+
+func (p *PaperExchange) PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	symbol := strings.ToUpper(req.Symbol)
+	if !req.ClosePosition && req.Quantity <= 0 {
+		return nil, &APIError{Status: 400, Code: -4003, Message: "Quantity less than or equal to zero."}
+	}
+	if req.Type != OrderTypeMarket && req.Price <= 0 {
+		return nil, &APIError{Status: 400, Code: -4014, Message: "Price must be greater than zero."}
+	}
+
+	if req.ClientOrderID != "" {
+		for _, existing := range p.orders {
+			if existing.ClientOrderID == req.ClientOrderID && existing.Status.Open() {
+				return nil, fmt.Errorf("%w: %w", ErrDuplicateClientOrderID, &APIError{Status: 400, Code: -4116, Message: "ClientOrderId is duplicated."})
+			}
+		}
+	}
+
+	last, hasPrice := p.prices[symbol]
+	if req.Type == OrderTypeMarket && !hasPrice {
+		return nil, fmt.Errorf("%s: %w", symbol, ErrNoPrice)
+	}
+
+	p.sequence++
+	order := &Order{
+		OrderID:       strconv.FormatInt(p.sequence, 10),
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		Status:        OrderStatusNew,
+		Quantity:      req.Quantity,
+		ReduceOnly:    req.ReduceOnly || req.ClosePosition,
+		UpdatedAt:     p.clock(),
+	}
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = "paper-" + order.OrderID
+	}
+	switch req.Type {
+	case OrderTypeLimit:
+		order.Price = req.Price
+	case OrderTypeStopMarket, OrderTypeTakeProfit:
+		order.StopPrice = req.Price
+	}
+	p.orders[order.OrderID] = order
+
+	// orders that are already marketable fill straight away as takers
+	switch {
+	case req.Type == OrderTypeMarket:
+		p.fill(order, p.slipped(order.Side, last), p.conf.TakerFee)
+	case !hasPrice:
+	case req.Type == OrderTypeLimit && limitReached(order, last):
+		p.fill(order, last, p.conf.TakerFee)
+	case req.Type != OrderTypeLimit && triggerReached(order, last):
+		p.fill(order, p.slipped(order.Side, last), p.conf.TakerFee)
+	}
+
+	result := *order
+	return &result, nil
+}
+
+func (p *PaperExchange) GetOrder(ctx context.Context, symbol, orderID string) (*Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, ok := p.orders[orderID]
+	if !ok || order.Symbol != strings.ToUpper(symbol) {
+		return nil, fmt.Errorf("order %s: %w", orderID, ErrOrderNotFound)
+	}
+	result := *order
+	return &result, nil
+}
+
+func (p *PaperExchange) GetOrderByClientID(ctx context.Context, symbol, clientOrderID string) (*Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// the latest order wins should an ID have been reused after its order ended
+	var (
+		found  *Order
+		latest int64
+	)
+	for _, order := range p.orders {
+		if order.ClientOrderID != clientOrderID || order.Symbol != strings.ToUpper(symbol) {
+			continue
+		}
+		if id, _ := strconv.ParseInt(order.OrderID, 10, 64); found == nil || id > latest {
+			found, latest = order, id
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("order %s: %w", clientOrderID, ErrOrderNotFound)
+	}
+	result := *found
+	return &result, nil
+}
+
+func (p *PaperExchange) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, ok := p.orders[orderID]
+	if !ok || !order.Status.Open() || order.Symbol != strings.ToUpper(symbol) {
+		return fmt.Errorf("order %s: %w", orderID, ErrOrderNotFound)
+	}
+	order.Status = OrderStatusCanceled
+	order.UpdatedAt = p.clock()
+	return nil
+}
+
+func (p *PaperExchange) OpenOrders(ctx context.Context, symbol string) ([]Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var orders []Order
+	for _, order := range p.restingOrders(strings.ToUpper(symbol)) {
+		orders = append(orders, *order)
+	}
+	return orders, nil
+}
+
+func (p *PaperExchange) SymbolInfo(ctx context.Context, symbol string) (*SymbolInfo, error) {
+	symbol = strings.ToUpper(symbol)
+	if info, ok := p.conf.Symbols[symbol]; ok {
+		return &info, nil
+	}
+
+	quote := paperQuoteAsset
+	for _, q := range []string{"USDT", "USDC", "BUSD"} {
+		if strings.HasSuffix(symbol, q) {
+			quote = q
+			break
+		}
+	}
+	return &SymbolInfo{
+		Symbol:     symbol,
+		Status:     "TRADING",
+		BaseAsset:  strings.TrimSuffix(symbol, quote),
+		QuoteAsset: quote,
+	}, nil
+}
+
+func (p *PaperExchange) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	if leverage < 1 || leverage > 125 {
+		return &APIError{Status: 400, Code: -4028, Message: "Leverage is not valid."}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.leverage[strings.ToUpper(symbol)] = leverage
+	return nil
+}
+
+func (p *PaperExchange) SetMarginType(ctx context.Context, symbol string, marginType MarginType) error {
+	if marginType != MarginTypeIsolated && marginType != MarginTypeCross {
+		return &APIError{Status: 400, Code: -1116, Message: "Invalid marginType."}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.margin[strings.ToUpper(symbol)] = marginType
+	return nil
+}
+
+// restingOrders returns open orders of symbol (or all symbols when empty) in placement order
+func (p *PaperExchange) restingOrders(symbol string) []*Order {
+	var orders []*Order
+	for _, order := range p.orders {
+		if order.Status.Open() && (symbol == "" || order.Symbol == symbol) {
+			orders = append(orders, order)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		a, _ := strconv.ParseInt(orders[i].OrderID, 10, 64)
+		b, _ := strconv.ParseInt(orders[j].OrderID, 10, 64)
+		return a < b
+	})
+	return orders
+}
+
+// fill executes the whole order at price, updating the position and charging the fee.
+// Reduce-only orders are trimmed to the position and expire when there is nothing to reduce.
+func (p *PaperExchange) fill(order *Order, price, feeRate float64) {
+	pos := p.positions[order.Symbol]
+	signed := order.Quantity
+	if order.Side == SideSell {
+		signed = -signed
+	}
+
+	if order.ReduceOnly {
+		if pos == nil || (order.Side == SideSell) != (pos.quantity > 0) {
+			order.Status = OrderStatusExpired
+			order.UpdatedAt = p.clock()
+			return
+		}
+		if order.Quantity == 0 || math.Abs(signed) > math.Abs(pos.quantity) {
+			signed = -pos.quantity
+		}
+	}
+
+	qty := math.Abs(signed)
+	p.balance -= qty * price * feeRate
+
+	switch {
+	case pos == nil:
+		p.positions[order.Symbol] = &paperPosition{quantity: signed, entryPrice: price}
+	case sameSign(pos.quantity, signed):
+		total := pos.quantity + signed
+		pos.entryPrice = (pos.entryPrice*pos.quantity + price*signed) / total
+		pos.quantity = total
+	default:
+		closed := math.Min(qty, math.Abs(pos.quantity))
+		direction := math.Copysign(1, pos.quantity)
+		p.balance += (price - pos.entryPrice) * closed * direction
+
+		remaining := pos.quantity + signed
+		switch {
+		case math.Abs(remaining) < 1e-12:
+			delete(p.positions, order.Symbol)
+		case sameSign(remaining, pos.quantity):
+			pos.quantity = remaining
+		default:
+			// the order flipped the position; the excess opens at the fill price
+			pos.quantity = remaining
+			pos.entryPrice = price
+		}
+	}
+
+	order.Status = OrderStatusFilled
+	order.ExecutedQty = qty
+	order.AvgPrice = price
+	order.UpdatedAt = p.clock()
+}
+
+// slipped moves price against the taker
+func (p *PaperExchange) slipped(side Side, price float64) float64 {
+	if side == SideBuy {
+		return price * (1 + p.conf.Slippage)
+	}
+	return price * (1 - p.conf.Slippage)
+}
+
+func (p *PaperExchange) markPrice(symbol string, pos *paperPosition) float64 {
+	if price, ok := p.prices[symbol]; ok {
+		return price
+	}
+	return pos.entryPrice
+}
+
+func (p *PaperExchange) leverageOf(symbol string) int {
+	if leverage, ok := p.leverage[symbol]; ok {
+		return leverage
+	}
+	return 1
+}
+
+// marginTypeOf reports the margin type of symbol the way Binance's position endpoint does
+func (p *PaperExchange) marginTypeOf(symbol string) string {
+	if p.margin[symbol] == MarginTypeIsolated {
+		return "isolated"
+	}
+	return "cross"
+}
+
+// clock is the time of the last tick when the feed carries timestamps, so replays are deterministic
+func (p *PaperExchange) clock() time.Time {
+	if !p.now.IsZero() {
+		return p.now
+	}
+	return time.Now().UTC()
+}
+
+// limitReached reports whether price trades through a limit order
+func limitReached(order *Order, price float64) bool {
+	if order.Side == SideBuy {
+		return price <= order.Price
+	}
+	return price >= order.Price
+}
+
+// triggerReached reports whether price crosses a stop or take profit trigger. A sell stop protects
+// a long and fires on the way down; a sell take profit fires on the way up, and buys the reverse.
+func triggerReached(order *Order, price float64) bool {
+	falling := order.Side == SideSell
+	if order.Type == OrderTypeTakeProfit {
+		falling = !falling
+	}
+	if falling {
+		return price <= order.StopPrice
+	}
+	return price >= order.StopPrice
+}
+
+func sameSign(a, b float64) bool {
+	return (a > 0) == (b > 0)
+}
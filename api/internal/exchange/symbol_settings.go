@@ -0,0 +1,62 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+)
+
+// SymbolSettings are the per-symbol account settings applied before entering a position
+type SymbolSettings struct {
+	Leverage   int        `json:"leverage"`
+	MarginType MarginType `json:"margin_type"`
+}
+
+// SettingsCache remembers the settings last applied to each account and symbol, so that
+// entering repeatedly on the same symbol does not call the exchange every time
+type SettingsCache struct {
+	mu      sync.Mutex
+	applied map[string]SymbolSettings
+}
+
+// NewSettingsCache creates an empty settings cache
+func NewSettingsCache() *SettingsCache {
+	return &SettingsCache{applied: make(map[string]SymbolSettings)}
+}
+
+// Apply sets the margin type and leverage of symbol on the account identified by account, calling
+// the exchange only for values that differ from what was last applied. A failed call forgets the
+// symbol's state so the next attempt starts over.
+func (c *SettingsCache) Apply(ctx context.Context, client ExchangeClient, account, symbol string, want SymbolSettings) error {
+	key := account + "/" + symbol
+
+	c.mu.Lock()
+	current, known := c.applied[key]
+	c.mu.Unlock()
+
+	if want.MarginType != "" && (!known || current.MarginType != want.MarginType) {
+		if err := client.SetMarginType(ctx, symbol, want.MarginType); err != nil {
+			c.Forget(account, symbol)
+			return err
+		}
+		current.MarginType = want.MarginType
+	}
+	if want.Leverage > 0 && (!known || current.Leverage != want.Leverage) {
+		if err := client.SetLeverage(ctx, symbol, want.Leverage); err != nil {
+			c.Forget(account, symbol)
+			return err
+		}
+		current.Leverage = want.Leverage
+	}
+
+	c.mu.Lock()
+	c.applied[key] = current
+	c.mu.Unlock()
+	return nil
+}
+
+// Forget drops what is known about symbol on account, e.g. after it was changed outside the copier
+func (c *SettingsCache) Forget(account, symbol string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.applied, account+"/"+symbol)
+}
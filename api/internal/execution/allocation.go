@@ -0,0 +1,58 @@
+package execution
+
+import (
+	"copier/internal/database/models"
+)
+
+// Allocation is one platform a signal is executed on, with the settings its positions are sized by
+type Allocation struct {
+	Platform *models.Platform
+	Settings *models.TradeSettings
+}
+
+// Allocate pairs the platforms a channel routes to with the settings each one sizes positions by.
+// Without routes every platform of the user is used with the user's settings; with routes only
+// the platforms of enabled routes are used, in route order.
+func Allocate(platforms []*models.Platform, routes []*models.ChannelRoute, settings *models.TradeSettings) []Allocation {
+	if len(routes) == 0 {
+		allocations := make([]Allocation, 0, len(platforms))
+		for _, platform := range platforms {
+			allocations = append(allocations, Allocation{Platform: platform, Settings: settings})
+		}
+		return allocations
+	}
+
+	byID := make(map[string]*models.Platform, len(platforms))
+	for _, platform := range platforms {
+		byID[platform.ID.String()] = platform
+	}
+	var allocations []Allocation
+	for _, route := range routes {
+		platform, ok := byID[route.PlatformID.String()]
+		if !ok || route.Disabled {
+			continue
+		}
+		allocations = append(allocations, Allocation{Platform: platform, Settings: AllocateSettings(settings, route)})
+	}
+	return allocations
+}
+
+// AllocateSettings returns a copy of settings sized for a route. A fixed amount trades that quote
+// notional whatever the sizing mode; a weight scales the amount, balance share, risk or quantity
+// of the sizing mode in percent, with the balance share capped at 100%. MaxNotional still caps
+// every position.
+func AllocateSettings(settings *models.TradeSettings, route *models.ChannelRoute) *models.TradeSettings {
+	allocated := *settings
+	switch {
+	case route.FixedAmount > 0:
+		allocated.SizingMode = models.SizingModeFixedAmount
+		allocated.PerTradeAmount = route.FixedAmount
+	case route.Weight > 0:
+		factor := route.Weight / 100
+		allocated.PerTradeAmount *= factor
+		allocated.BalancePercentage = min(allocated.BalancePercentage*factor, 100)
+		allocated.RiskAmount *= factor
+		allocated.FixedQuantity *= factor
+	}
+	return &allocated
+}
@@ -0,0 +1,42 @@
+package execution
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+)
+
+// ClientOrderIDPrefix marks the client order IDs generated by the copier
+const ClientOrderIDPrefix = "cp-"
+
+// clientOrderIDHashLength keeps IDs within the 36 characters Binance accepts
+const clientOrderIDHashLength = 24
+
+var purposeCodes = map[models.OrderPurpose]string{
+	models.OrderPurposeEntry:      "e",
+	models.OrderPurposeStopLoss:   "s",
+	models.OrderPurposeTakeProfit: "t",
+	models.OrderPurposeClose:      "c",
+}
+
+// ClientOrderID derives the client order ID of one order leg from its signal, position, purpose,
+// leg and revision. The same leg always gets the same ID, so an order whose placement outcome is
+// unknown can be looked up on the exchange instead of being sent twice.
+func ClientOrderID(signalID, positionID uuid.UUID, purpose models.OrderPurpose, leg, revision int) string {
+	code, ok := purposeCodes[purpose]
+	if !ok {
+		code = "x"
+	}
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%s|%s|%d|%d", signalID, positionID, purpose, leg, revision))
+	return fmt.Sprintf("%s%s%d-%s", ClientOrderIDPrefix, code, leg, hex.EncodeToString(sum[:])[:clientOrderIDHashLength])
+}
+
+// IsCopierClientOrderID reports whether a client order ID was generated by ClientOrderID
+func IsCopierClientOrderID(id string) bool {
+	return strings.HasPrefix(id, ClientOrderIDPrefix)
+}
@@ -0,0 +1,204 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// MaxScaledEntries bounds how many limit orders a scaled entry places
+const MaxScaledEntries = 10
+
+// EntryMode says how a signal is entered at the current market price
+type EntryMode string
+
+const (
+	EntryMarket EntryMode = "market"
+	EntryLimit  EntryMode = "limit"
+	EntrySkip   EntryMode = "skip"
+	// EntryScaled enters with several limit orders spread across the entry range
+	EntryScaled EntryMode = "scaled"
+)
+
+// EntryDecision is the entry order to place for a signal, or the reason not to trade it
+type EntryDecision struct {
+	Mode   EntryMode `json:"mode"`
+	Price  float64   `json:"price"`
+	Reason string    `json:"reason,omitempty"`
+	// Legs are the orders of a scaled entry, nearest price first; Price is their weighted average
+	Legs []EntryLeg `json:"legs,omitempty"`
+}
+
+// EntryLeg is one limit order of a scaled entry
+type EntryLeg struct {
+	Price float64 `json:"price"`
+	// Weight is the percentage of the position entered at Price
+	Weight float64 `json:"weight"`
+}
+
+// DecideEntry compares the market price with the signal's entry range. Inside the range, on its
+// favourable side or up to overRangePct beyond it, the signal is entered at market. Further beyond,
+// a limit order waits at the edge of the range. Signals whose stop or first target the price has
+// already reached are skipped.
+func DecideEntry(sig *models.Signal, price, overRangePct float64) EntryDecision {
+	low, high := sig.EntryLow, sig.EntryHigh
+	if high == 0 {
+		high = low
+	}
+	long := sig.Side != models.SignalSideShort
+
+	if sig.StopLoss != nil && ((long && price <= *sig.StopLoss) || (!long && price >= *sig.StopLoss)) {
+		return EntryDecision{Mode: EntrySkip, Price: price, Reason: fmt.Sprintf("price %v has already reached the stop loss %v", price, *sig.StopLoss)}
+	}
+	if len(sig.Targets) > 0 && ((long && price >= sig.Targets[0]) || (!long && price <= sig.Targets[0])) {
+		return EntryDecision{Mode: EntrySkip, Price: price, Reason: fmt.Sprintf("price %v has already reached TP1 %v", price, sig.Targets[0])}
+	}
+
+	if long {
+		if price <= high*(1+overRangePct/100) {
+			return EntryDecision{Mode: EntryMarket, Price: price}
+		}
+		return EntryDecision{
+			Mode:   EntryLimit,
+			Price:  high,
+			Reason: fmt.Sprintf("price %v is %.2f%% above the entry range; waiting at %v", price, (price/high-1)*100, high),
+		}
+	}
+
+	if price >= low*(1-overRangePct/100) {
+		return EntryDecision{Mode: EntryMarket, Price: price}
+	}
+	return EntryDecision{
+		Mode:   EntryLimit,
+		Price:  low,
+		Reason: fmt.Sprintf("price %v is %.2f%% below the entry range; waiting at %v", price, (1-price/low)*100, low),
+	}
+}
+
+// DecideEntryStrategy decides how a signal is entered at the market price with the user's entry
+// strategy. Signals DecideEntry skips are skipped by every strategy, and a price already past the
+// whole entry range is entered at market.
+func DecideEntryStrategy(sig *models.Signal, settings *models.TradeSettings, price float64) EntryDecision {
+	decision := DecideEntry(sig, price, settings.OverRangePercentage)
+	if decision.Mode == EntrySkip {
+		return decision
+	}
+	long := sig.Side != models.SignalSideShort
+
+	switch settings.EntryStrategy {
+	case models.EntryStrategyMidpoint:
+		mid := sig.EntryPrice()
+		if (long && price <= mid) || (!long && price >= mid) {
+			return EntryDecision{Mode: EntryMarket, Price: price}
+		}
+		return EntryDecision{Mode: EntryLimit, Price: mid, Reason: fmt.Sprintf("waiting at the middle of the entry range at %v", mid)}
+	case models.EntryStrategyScaled:
+		legs := ScaledEntries(sig, settings.ScaledEntryCount, settings.ScaledEntryWeights)
+		if len(legs) < 2 {
+			return decision
+		}
+		if far := legs[len(legs)-1].Price; (long && price <= far) || (!long && price >= far) {
+			return decision
+		}
+		return scaledDecision(legs)
+	}
+	return decision
+}
+
+// ScaledEntries spreads count orders evenly across the signal's entry range, from the edge the
+// price reaches first to the far edge, weighted by weights or evenly when there are none. A range
+// without width gets a single order.
+func ScaledEntries(sig *models.Signal, count int, weights []float64) []EntryLeg {
+	low, high := sig.EntryLow, sig.EntryHigh
+	if high == 0 {
+		high = low
+	}
+	if count < 2 || high <= low {
+		return []EntryLeg{{Price: sig.EntryPrice(), Weight: 100}}
+	}
+
+	near, far := high, low
+	if sig.Side == models.SignalSideShort {
+		near, far = low, high
+	}
+	legs := make([]EntryLeg, count)
+	for i := range legs {
+		legs[i].Price = near + (far-near)*float64(i)/float64(count-1)
+		legs[i].Weight = 100 / float64(count)
+		if len(weights) == count {
+			legs[i].Weight = weights[i]
+		}
+	}
+	return legs
+}
+
+// scaledDecision enters with legs at their weighted average price
+func scaledDecision(legs []EntryLeg) EntryDecision {
+	var cost, weight float64
+	for _, leg := range legs {
+		cost += leg.Price * leg.Weight
+		weight += leg.Weight
+	}
+	return EntryDecision{
+		Mode:   EntryScaled,
+		Price:  cost / weight,
+		Legs:   legs,
+		Reason: fmt.Sprintf("%d scaled entries from %v to %v", len(legs), legs[0].Price, legs[len(legs)-1].Price),
+	}
+}
+
+// ValidateEntryStrategy checks that a scaled entry strategy has a usable order count and weights
+func ValidateEntryStrategy(settings *models.TradeSettings) error {
+	switch settings.EntryStrategy {
+	case "", models.EntryStrategyFirstPrice, models.EntryStrategyMidpoint:
+		return nil
+	case models.EntryStrategyScaled:
+	default:
+		return fmt.Errorf("%w: unknown entry strategy %q", exceptions.ErrInvalidEntry, settings.EntryStrategy)
+	}
+
+	if settings.ScaledEntryCount < 2 || settings.ScaledEntryCount > MaxScaledEntries {
+		return fmt.Errorf("%w: scaled entries need between 2 and %d orders", exceptions.ErrInvalidEntry, MaxScaledEntries)
+	}
+	if len(settings.ScaledEntryWeights) == 0 {
+		return nil
+	}
+	if len(settings.ScaledEntryWeights) != settings.ScaledEntryCount {
+		return fmt.Errorf("%w: %d weights configured for %d scaled entries", exceptions.ErrInvalidEntry, len(settings.ScaledEntryWeights), settings.ScaledEntryCount)
+	}
+	total := 0.0
+	for _, weight := range settings.ScaledEntryWeights {
+		if weight <= 0 {
+			return fmt.Errorf("%w: scaled entry weights must be positive", exceptions.ErrInvalidEntry)
+		}
+		total += weight
+	}
+	if math.Abs(total-100) > 0.0001 {
+		return fmt.Errorf("%w: scaled entry weights add up to %v%%, not 100%%", exceptions.ErrInvalidEntry, total)
+	}
+	return nil
+}
+
+// ScaleSignal returns a copy of sig priced in a contract traded as symbol, whose prices are
+// multiplier times the signal's (e.g. 1000 for 1000PEPEUSDT)
+func ScaleSignal(sig *models.Signal, symbol string, multiplier float64) *models.Signal {
+	scaled := *sig
+	scaled.Symbol = symbol
+	if multiplier <= 0 || multiplier == 1 {
+		return &scaled
+	}
+
+	scaled.EntryLow *= multiplier
+	scaled.EntryHigh *= multiplier
+	scaled.Targets = make([]float64, len(sig.Targets))
+	for i, target := range sig.Targets {
+		scaled.Targets[i] = target * multiplier
+	}
+	if sig.StopLoss != nil {
+		stop := *sig.StopLoss * multiplier
+		scaled.StopLoss = &stop
+	}
+	return &scaled
+}
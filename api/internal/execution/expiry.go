@@ -0,0 +1,50 @@
+package execution
+
+import (
+	"time"
+
+	"copier/internal/database/models"
+)
+
+// ResolveExpiry combines the expiry rules of a signal's channel with the user's own. Limits the
+// channel sets win over the user's, and TP1 cancellation applies when either asks for it.
+func ResolveExpiry(channel, settings models.ExpiryRules) models.ExpiryRules {
+	rules := settings
+	if channel.EntryTimeoutMinutes > 0 {
+		rules.EntryTimeoutMinutes = channel.EntryTimeoutMinutes
+	}
+	if channel.MaxHoldingHours > 0 {
+		rules.MaxHoldingHours = channel.MaxHoldingHours
+	}
+	rules.CancelEntryAtTP1 = rules.CancelEntryAtTP1 || channel.CancelEntryAtTP1
+	return rules
+}
+
+// EntryDeadline returns when unfilled entries placed at from are cancelled, or nil without a timeout
+func EntryDeadline(rules models.ExpiryRules, from time.Time) *time.Time {
+	if rules.EntryTimeoutMinutes <= 0 {
+		return nil
+	}
+	deadline := from.Add(time.Duration(rules.EntryTimeoutMinutes) * time.Minute)
+	return &deadline
+}
+
+// HoldingDeadline returns when a position opened at from is closed, or nil without a limit
+func HoldingDeadline(rules models.ExpiryRules, from time.Time) *time.Time {
+	if rules.MaxHoldingHours <= 0 {
+		return nil
+	}
+	deadline := from.Add(time.Duration(rules.MaxHoldingHours) * time.Hour)
+	return &deadline
+}
+
+// ReachedTarget reports whether price has reached target in the direction of a position's side
+func ReachedTarget(side models.SignalSide, target, price float64) bool {
+	if target <= 0 {
+		return false
+	}
+	if side == models.SignalSideShort {
+		return price <= target
+	}
+	return price >= target
+}
@@ -0,0 +1,122 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// ValidateTakeProfitLadder checks that a take profit ladder has one positive percentage per step
+// and that the percentages split the whole position
+func ValidateTakeProfitLadder(step int, percentages []float64) error {
+	if step < 1 {
+		return fmt.Errorf("%w: take profit step must be at least 1", exceptions.ErrInvalidTakeProfit)
+	}
+	if len(percentages) != step {
+		return fmt.Errorf("%w: %d percentages configured for %d steps", exceptions.ErrInvalidTakeProfit, len(percentages), step)
+	}
+
+	total := 0.0
+	for i, pct := range percentages {
+		if pct <= 0 {
+			return fmt.Errorf("%w: TP%d percentage must be greater than zero", exceptions.ErrInvalidTakeProfit, i+1)
+		}
+		total += pct
+	}
+	if math.Abs(total-100) > 0.0001 {
+		return fmt.Errorf("%w: percentages add up to %v%%, not 100%%", exceptions.ErrInvalidTakeProfit, total)
+	}
+
+	return nil
+}
+
+// LadderPercentages splits a position across a signal's targets using the take profit ladder.
+// When the signal has fewer targets than steps, the share of the missing steps goes to its last
+// target so the whole position is still taken off.
+func LadderPercentages(settings *models.TradeSettings, targets int) []float64 {
+	steps := min(settings.TakeProfitStep, len(settings.TPPercentage), targets)
+	if steps <= 0 {
+		return nil
+	}
+
+	ladder := append([]float64(nil), settings.TPPercentage[:steps]...)
+	for _, pct := range settings.TPPercentage[steps:min(settings.TakeProfitStep, len(settings.TPPercentage))] {
+		ladder[steps-1] += pct
+	}
+	return ladder
+}
+
+// ValidateStopRules rejects combinations of stop rules that would fight over the stop
+func ValidateStopRules(rules models.StopRules) error {
+	if rules.TrailingStopPercentage < 0 || rules.TrailingStopPercentage > 50 {
+		return fmt.Errorf("%w: trailing stop percentage must be between 0 and 50", exceptions.ErrInvalidStopRules)
+	}
+	if rules.TrailToPreviousTP && rules.TrailingStopPercentage > 0 {
+		return fmt.Errorf("%w: trail to previous TP and a percentage trailing stop cannot both be enabled", exceptions.ErrInvalidStopRules)
+	}
+
+	return nil
+}
+
+// ImprovesStop reports whether moving a stop from current to candidate locks in more of the move;
+// a zero current stop means the position has none
+func ImprovesStop(side models.SignalSide, current, candidate float64) bool {
+	if candidate <= 0 {
+		return false
+	}
+	if current <= 0 {
+		return true
+	}
+	if side == models.SignalSideShort {
+		return candidate < current
+	}
+	return candidate > current
+}
+
+// StopAfterTarget returns where the position's stop rules put the stop once take profit leg has
+// filled; targets are the take profit prices by leg. It reports false when the stop should stay.
+func StopAfterTarget(pos *models.Position, targets []float64, leg int) (float64, bool) {
+	var stop float64
+	switch {
+	case pos.StopRules.TrailToPreviousTP && leg >= 2 && leg-2 < len(targets):
+		stop = targets[leg-2]
+	case pos.StopRules.TrailToPreviousTP || (pos.StopRules.BreakEvenAfterTP1 && leg >= 1):
+		stop = pos.EntryPrice
+	default:
+		return 0, false
+	}
+
+	if !ImprovesStop(pos.Side, pos.StopPrice, stop) {
+		return 0, false
+	}
+	return stop, true
+}
+
+// TrailingStop follows price with the position's percentage trailing stop. It returns the new peak
+// price and, when the stop has moved at least a quarter of the trailing distance, the new stop.
+func TrailingStop(pos *models.Position, price float64) (peak, stop float64, moved bool) {
+	pct := pos.StopRules.TrailingStopPercentage / 100
+	if pct <= 0 || price <= 0 {
+		return pos.PeakPrice, 0, false
+	}
+
+	peak = pos.PeakPrice
+	short := pos.Side == models.SignalSideShort
+	if peak == 0 || (short && price < peak) || (!short && price > peak) {
+		peak = price
+	}
+
+	stop = peak * (1 - pct)
+	if short {
+		stop = peak * (1 + pct)
+	}
+	if !ImprovesStop(pos.Side, pos.StopPrice, stop) {
+		return peak, 0, false
+	}
+	if pos.StopPrice > 0 && math.Abs(stop-pos.StopPrice) < peak*pct/4 {
+		return peak, 0, false
+	}
+	return peak, stop, true
+}
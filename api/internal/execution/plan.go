@@ -0,0 +1,257 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+
+	"copier/internal/database/models"
+)
+
+type OrderPurpose string
+
+const (
+	PurposeEntry      OrderPurpose = "entry"
+	PurposeStopLoss   OrderPurpose = "stop_loss"
+	PurposeTakeProfit OrderPurpose = "take_profit"
+)
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+)
+
+type OrderType string
+
+const (
+	OrderTypeLimit      OrderType = "LIMIT"
+	OrderTypeMarket     OrderType = "MARKET"
+	OrderTypeStopMarket OrderType = "STOP_MARKET"
+	OrderTypeTakeProfit OrderType = "TAKE_PROFIT_MARKET"
+)
+
+// PlannedOrder is an order derived from a signal and the user's trade settings
+type PlannedOrder struct {
+	Purpose    OrderPurpose `json:"purpose"`
+	Leg        int          `json:"leg"`
+	Symbol     string       `json:"symbol"`
+	Side       OrderSide    `json:"side"`
+	Type       OrderType    `json:"type"`
+	Price      float64      `json:"price"`
+	Quantity   float64      `json:"quantity"`
+	ReduceOnly bool         `json:"reduce_only"`
+}
+
+// Plan is the full set of orders a signal would produce, with any problems found. The entry
+// orders come first.
+type Plan struct {
+	Orders   []PlannedOrder `json:"orders"`
+	Warnings []string       `json:"warnings"`
+	// Leverage is the leverage the orders were sized for
+	Leverage int `json:"leverage"`
+}
+
+// Entries returns the entry orders of the plan
+func (p *Plan) Entries() []PlannedOrder {
+	n := 0
+	for n < len(p.Orders) && p.Orders[n].Purpose == PurposeEntry {
+		n++
+	}
+	return p.Orders[:n]
+}
+
+// EntryQuantity returns the quantity of all entry orders together
+func (p *Plan) EntryQuantity() float64 {
+	total := 0.0
+	for _, order := range p.Entries() {
+		total += order.Quantity
+	}
+	return total
+}
+
+// EntryNotional returns the notional of all entry orders together at their prices
+func (p *Plan) EntryNotional() float64 {
+	total := 0.0
+	for _, order := range p.Entries() {
+		total += order.Quantity * order.Price
+	}
+	return total
+}
+
+// EntrySide returns the order side that opens a position in the signal's direction
+func EntrySide(side models.SignalSide) OrderSide {
+	if side == models.SignalSideShort {
+		return OrderSideSell
+	}
+	return OrderSideBuy
+}
+
+// ExitSide returns the order side that reduces a position in the signal's direction
+func ExitSide(side models.SignalSide) OrderSide {
+	if side == models.SignalSideShort {
+		return OrderSideBuy
+	}
+	return OrderSideSell
+}
+
+// StopLossPrice returns the signal's stop, or one derived from StopLossPercentage when it has none
+func StopLossPrice(sig *models.Signal, settings *models.TradeSettings, entry float64) float64 {
+	if sig.StopLoss != nil {
+		return *sig.StopLoss
+	}
+	pct := float64(settings.StopLossPercentage) / 100
+	if sig.Side == models.SignalSideShort {
+		return entry * (1 + pct)
+	}
+	return entry * (1 - pct)
+}
+
+// PlanOrders derives entry, stop loss and take profit orders for a signal from trade settings,
+// entering with a limit order at the middle of the entry range, or with the scaled entries of a
+// scaled entry strategy
+func PlanOrders(sig *models.Signal, settings *models.TradeSettings, account Account) *Plan {
+	decision := EntryDecision{Mode: EntryLimit, Price: sig.EntryPrice()}
+	if settings.EntryStrategy == models.EntryStrategyScaled {
+		if legs := ScaledEntries(sig, settings.ScaledEntryCount, settings.ScaledEntryWeights); len(legs) > 1 {
+			decision = scaledDecision(legs)
+		}
+	}
+	return PlanOrdersAt(sig, settings, decision, account)
+}
+
+// PlanOrdersAt derives the orders for a signal entered as decided by DecideEntry, sized by
+// SizePosition for account
+func PlanOrdersAt(sig *models.Signal, settings *models.TradeSettings, decision EntryDecision, account Account) *Plan {
+	plan := &Plan{Warnings: ValidateSignal(sig)}
+
+	entry := decision.Price
+	if entry <= 0 {
+		plan.Warnings = append(plan.Warnings, "signal has no entry price; no orders derived")
+		return plan
+	}
+
+	var stop float64
+	if sig.StopLoss != nil || settings.StopLossStatus {
+		stop = StopLossPrice(sig, settings, entry)
+	}
+	leverage := Leverage(sig, settings)
+	if sig.Leverage != nil && *sig.Leverage > leverage && settings.LeverageSource != models.LeverageSourceDefault {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("signal leverage %dx capped at %dx", *sig.Leverage, leverage))
+	}
+	size, err := SizePosition(settings, entry, stop, leverage, account)
+	if err != nil {
+		plan.Warnings = append(plan.Warnings, err.Error()+"; no orders derived")
+		return plan
+	}
+	plan.Warnings = append(plan.Warnings, size.Warnings...)
+	plan.Leverage = size.Leverage
+
+	entryType := OrderTypeLimit
+	if decision.Mode == EntryMarket {
+		entryType = OrderTypeMarket
+	}
+
+	quantity := size.Quantity
+	legs := decision.Legs
+	if len(legs) == 0 {
+		legs = []EntryLeg{{Price: entry, Weight: 100}}
+	}
+	for i, leg := range legs {
+		plan.Orders = append(plan.Orders, PlannedOrder{
+			Purpose:  PurposeEntry,
+			Leg:      i + 1,
+			Symbol:   sig.Symbol,
+			Side:     EntrySide(sig.Side),
+			Type:     entryType,
+			Price:    leg.Price,
+			Quantity: quantity * leg.Weight / 100,
+		})
+	}
+
+	switch {
+	case settings.StopLossStatus:
+		if sig.StopLoss == nil {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("signal has no stop loss; using %d%% from entry", settings.StopLossPercentage))
+		}
+		plan.Orders = append(plan.Orders, PlannedOrder{
+			Purpose:    PurposeStopLoss,
+			Leg:        1,
+			Symbol:     sig.Symbol,
+			Side:       ExitSide(sig.Side),
+			Type:       OrderTypeStopMarket,
+			Price:      StopLossPrice(sig, settings, entry),
+			Quantity:   quantity,
+			ReduceOnly: true,
+		})
+	case sig.StopLoss == nil:
+		plan.Warnings = append(plan.Warnings, "signal has no stop loss and stop loss is disabled in trade settings")
+	}
+
+	if settings.TakeProfitStatus {
+		plan.Warnings = append(plan.Warnings, validateTakeProfit(sig, settings)...)
+
+		for i, pct := range LadderPercentages(settings, len(sig.Targets)) {
+			plan.Orders = append(plan.Orders, PlannedOrder{
+				Purpose:    PurposeTakeProfit,
+				Leg:        i + 1,
+				Symbol:     sig.Symbol,
+				Side:       ExitSide(sig.Side),
+				Type:       OrderTypeTakeProfit,
+				Price:      sig.Targets[i],
+				Quantity:   quantity * pct / 100,
+				ReduceOnly: true,
+			})
+		}
+	}
+
+	return plan
+}
+
+// ValidateSignal reports price levels that sit on the wrong side of the entry range
+func ValidateSignal(sig *models.Signal) []string {
+	var warnings []string
+	long := sig.Side != models.SignalSideShort
+
+	if sig.StopLoss != nil {
+		if long && *sig.StopLoss >= sig.EntryLow {
+			warnings = append(warnings, fmt.Sprintf("stop loss %v is not below the entry range of a long signal", *sig.StopLoss))
+		}
+		if !long && *sig.StopLoss <= sig.EntryHigh {
+			warnings = append(warnings, fmt.Sprintf("stop loss %v is not above the entry range of a short signal", *sig.StopLoss))
+		}
+	}
+
+	for i, target := range sig.Targets {
+		if (long && target <= sig.EntryHigh) || (!long && target >= sig.EntryLow) {
+			warnings = append(warnings, fmt.Sprintf("TP%d %v is on the wrong side of the entry range", i+1, target))
+		}
+	}
+
+	return warnings
+}
+
+// validateTakeProfit compares the signal's targets with the configured take profit ladder
+func validateTakeProfit(sig *models.Signal, settings *models.TradeSettings) []string {
+	var warnings []string
+
+	if len(sig.Targets) == 0 {
+		warnings = append(warnings, "signal has no take profit targets")
+	} else if len(sig.Targets) < settings.TakeProfitStep {
+		warnings = append(warnings, fmt.Sprintf("signal has %d targets but take profit step is %d", len(sig.Targets), settings.TakeProfitStep))
+	}
+
+	if len(settings.TPPercentage) != settings.TakeProfitStep {
+		warnings = append(warnings, fmt.Sprintf("%d TP percentages configured for %d take profit steps", len(settings.TPPercentage), settings.TakeProfitStep))
+	}
+
+	total := 0.0
+	for _, pct := range settings.TPPercentage {
+		total += pct
+	}
+	if math.Abs(total-100) > 0.0001 {
+		warnings = append(warnings, fmt.Sprintf("TP percentages add up to %v%%, not 100%%", total))
+	}
+
+	return warnings
+}
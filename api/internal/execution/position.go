@@ -0,0 +1,160 @@
+package execution
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// quantityEpsilon absorbs float rounding when comparing filled and closed quantities
+const quantityEpsilon = 1e-9
+
+// positionColumns reads the columns a position change may write
+var positionColumns = []struct {
+	name  string
+	value func(*models.Position) any
+}{
+	{"status", func(p *models.Position) any { return p.Status }},
+	{"filled_quantity", func(p *models.Position) any { return p.FilledQuantity }},
+	{"closed_quantity", func(p *models.Position) any { return p.ClosedQuantity }},
+	{"entry_price", func(p *models.Position) any { return p.EntryPrice }},
+	{"exit_price", func(p *models.Position) any { return p.ExitPrice }},
+	{"realized_pnl", func(p *models.Position) any { return p.RealizedPnL }},
+	{"protected_quantity", func(p *models.Position) any { return p.ProtectedQuantity }},
+	{"stop_price", func(p *models.Position) any { return p.StopPrice }},
+	{"targets_hit", func(p *models.Position) any { return p.TargetsHit }},
+	{"peak_price", func(p *models.Position) any { return p.PeakPrice }},
+	{"close_reason", func(p *models.Position) any { return p.CloseReason }},
+	{"entry_expires_at", func(p *models.Position) any { return p.EntryExpiresAt }},
+	{"expires_at", func(p *models.Position) any { return p.ExpiresAt }},
+	{"opened_at", func(p *models.Position) any { return p.OpenedAt }},
+	{"closed_at", func(p *models.Position) any { return p.ClosedAt }},
+}
+
+// ChangedColumns returns the columns that differ between two states of a position, so a change
+// is stored without overwriting what other writers stored in the meantime
+func ChangedColumns(before, after *models.Position) []string {
+	var columns []string
+	for _, column := range positionColumns {
+		if !reflect.DeepEqual(column.value(before), column.value(after)) {
+			columns = append(columns, column.name)
+		}
+	}
+	return columns
+}
+
+// positionTransitions lists the statuses each position status may move to; statuses without an
+// entry are terminal. Partial statuses may repeat as more quantity trades.
+var positionTransitions = map[models.PositionStatus][]models.PositionStatus{
+	models.PositionStatusPendingEntry: {
+		models.PositionStatusPartiallyFilled,
+		models.PositionStatusOpen,
+		models.PositionStatusCancelled,
+		models.PositionStatusExpired,
+	},
+	models.PositionStatusPartiallyFilled: {
+		models.PositionStatusPartiallyFilled,
+		models.PositionStatusOpen,
+		models.PositionStatusPartiallyClosed,
+		models.PositionStatusClosed,
+		models.PositionStatusStopped,
+	},
+	models.PositionStatusOpen: {
+		models.PositionStatusPartiallyClosed,
+		models.PositionStatusClosed,
+		models.PositionStatusStopped,
+	},
+	models.PositionStatusPartiallyClosed: {
+		models.PositionStatusPartiallyClosed,
+		models.PositionStatusClosed,
+		models.PositionStatusStopped,
+	},
+}
+
+// CanTransition reports whether a position may move from one status to another
+func CanTransition(from, to models.PositionStatus) bool {
+	return slices.Contains(positionTransitions[from], to)
+}
+
+// IsTerminal reports whether a position status ends the position's lifecycle
+func IsTerminal(status models.PositionStatus) bool {
+	_, ok := positionTransitions[status]
+	return !ok
+}
+
+// TransitionPosition moves pos to status to, or fails with ErrInvalidTransition. The first
+// transition with a fill records when the position opened and when its holding time runs out.
+func TransitionPosition(pos *models.Position, to models.PositionStatus) error {
+	if !CanTransition(pos.Status, to) {
+		return fmt.Errorf("%w: %s to %s", exceptions.ErrInvalidTransition, pos.Status, to)
+	}
+
+	now := time.Now()
+	if pos.OpenedAt == nil && pos.FilledQuantity > 0 {
+		pos.OpenedAt = &now
+		pos.ExpiresAt = HoldingDeadline(pos.Expiry, now)
+	}
+	if IsTerminal(to) {
+		pos.ClosedAt = &now
+	}
+	pos.Status = to
+	return nil
+}
+
+// ApplyEntryFill records the cumulative filled quantity and average price of the entry orders.
+// A final fill opens the position with whatever quantity traded. Entries still filling after a
+// target was hit add to the position without changing its status.
+func ApplyEntryFill(pos *models.Position, filled, avgPrice float64, final bool) error {
+	if filled <= 0 {
+		return nil
+	}
+	pos.FilledQuantity = filled
+	pos.EntryPrice = avgPrice
+
+	if pos.Status == models.PositionStatusPartiallyClosed {
+		return nil
+	}
+	if final || filled >= pos.Quantity-quantityEpsilon {
+		if pos.Status == models.PositionStatusOpen {
+			return nil
+		}
+		return TransitionPosition(pos, models.PositionStatusOpen)
+	}
+	return TransitionPosition(pos, models.PositionStatusPartiallyFilled)
+}
+
+// ApplyExitFill records quantity closed at price and the profit it realised. The position is
+// closed, or stopped when stop is set, once nothing remains.
+func ApplyExitFill(pos *models.Position, quantity, price float64, stop bool) error {
+	quantity = min(quantity, pos.Remaining())
+	if quantity <= 0 {
+		return nil
+	}
+
+	direction := 1.0
+	if pos.Side == models.SignalSideShort {
+		direction = -1
+	}
+
+	closed := pos.ClosedQuantity + quantity
+	to := models.PositionStatusPartiallyClosed
+	switch {
+	case closed < pos.FilledQuantity-quantityEpsilon:
+	case stop:
+		to = models.PositionStatusStopped
+	default:
+		to = models.PositionStatusClosed
+	}
+	if err := TransitionPosition(pos, to); err != nil {
+		return err
+	}
+
+	pos.ExitPrice = (pos.ExitPrice*pos.ClosedQuantity + price*quantity) / closed
+	pos.ClosedQuantity = closed
+	pos.RealizedPnL += (price - pos.EntryPrice) * quantity * direction
+	return nil
+}
@@ -0,0 +1,128 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"copier/internal/exchange"
+	"copier/internal/shared/exceptions"
+)
+
+// RoundDown rounds v down to a multiple of step; a zero step leaves v unchanged.
+// Quantities are always rounded down so a position is never larger than it was sized.
+func RoundDown(v, step float64) float64 {
+	if step <= 0 {
+		return v
+	}
+	return roundDecimals(math.Floor(v/step+quantityEpsilon)*step, step)
+}
+
+// RoundToTick rounds price to the nearest multiple of tick; a zero tick leaves price unchanged
+func RoundToTick(price, tick float64) float64 {
+	if tick <= 0 {
+		return price
+	}
+	return roundDecimals(math.Round(price/tick)*tick, tick)
+}
+
+// roundDecimals drops the float noise of a multiplication by keeping as many decimals as step has
+func roundDecimals(v, step float64) float64 {
+	decimals := 0
+	if _, frac, ok := strings.Cut(strconv.FormatFloat(step, 'f', -1, 64), "."); ok {
+		decimals = len(frac)
+	}
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
+
+// CheckMinimums rejects a quantity the exchange would refuse. The minimum notional only applies
+// to orders that open exposure; reduce-only orders are exempt from it.
+func CheckMinimums(info *exchange.SymbolInfo, quantity, price float64, reduceOnly bool) error {
+	if quantity <= 0 || quantity < info.MinQty {
+		return fmt.Errorf("%w: quantity %v is below the minimum of %v for %s", exceptions.ErrBelowMinimum, quantity, info.MinQty, info.Symbol)
+	}
+	if !reduceOnly && info.MinNotional > 0 && quantity*price < info.MinNotional {
+		return fmt.Errorf("%w: notional %.2f is below the minimum of %v for %s", exceptions.ErrBelowMinimum, quantity*price, info.MinNotional, info.Symbol)
+	}
+	return nil
+}
+
+// ApplySymbolRules rounds every price of the plan to the symbol's tick size and every quantity to
+// its lot step, and rejects an entry below the minimum quantity or notional. Scaled entries too
+// small to trade are merged into the entry before them. The take profit ladder is rounded so its
+// legs add up to the entry; legs too small to trade are merged into the previous one, the same way
+// missing targets are folded into the last.
+func ApplySymbolRules(plan *Plan, info *exchange.SymbolInfo) error {
+	entries := len(plan.Entries())
+	if entries == 0 {
+		return nil
+	}
+
+	for i := range plan.Orders[:entries] {
+		entry := &plan.Orders[i]
+		entry.Price = RoundToTick(entry.Price, info.TickSize)
+		entry.Quantity = RoundDown(entry.Quantity, info.StepSize)
+		if info.MaxQty > 0 && entry.Quantity > info.MaxQty {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("quantity %v capped at the maximum of %v for %s", entry.Quantity, info.MaxQty, info.Symbol))
+			entry.Quantity = RoundDown(info.MaxQty, info.StepSize)
+		}
+	}
+	for i := entries - 1; i > 0; i-- {
+		leg := plan.Orders[i]
+		if CheckMinimums(info, leg.Quantity, leg.Price, false) == nil {
+			continue
+		}
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("entry %d merged into entry %d: leg is below the minimum for %s", leg.Leg, plan.Orders[i-1].Leg, info.Symbol))
+		plan.Orders[i-1].Quantity = RoundDown(plan.Orders[i-1].Quantity+leg.Quantity, info.StepSize)
+		plan.Orders = append(plan.Orders[:i], plan.Orders[i+1:]...)
+		entries--
+	}
+	for i := range plan.Orders[:entries] {
+		plan.Orders[i].Leg = i + 1
+	}
+	if err := CheckMinimums(info, plan.Orders[0].Quantity, plan.Orders[0].Price, false); err != nil {
+		return err
+	}
+	quantity := plan.EntryQuantity()
+
+	var targets []int
+	for i := entries; i < len(plan.Orders); i++ {
+		order := &plan.Orders[i]
+		order.Price = RoundToTick(order.Price, info.TickSize)
+		switch order.Purpose {
+		case PurposeStopLoss:
+			order.Quantity = quantity
+		case PurposeTakeProfit:
+			targets = append(targets, i)
+		}
+	}
+
+	for len(targets) > 0 {
+		remaining := quantity
+		fits := true
+		for n, i := range targets {
+			order := &plan.Orders[i]
+			if n == len(targets)-1 {
+				order.Quantity = RoundDown(remaining, info.StepSize)
+			} else {
+				order.Quantity = min(RoundDown(order.Quantity, info.StepSize), remaining)
+				remaining -= order.Quantity
+			}
+			if CheckMinimums(info, order.Quantity, order.Price, true) != nil {
+				fits = false
+			}
+		}
+		if fits || len(targets) == 1 {
+			break
+		}
+
+		last := targets[len(targets)-1]
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("TP%d merged into TP%d: leg is below the minimum quantity of %v", plan.Orders[last].Leg, plan.Orders[last].Leg-1, info.MinQty))
+		plan.Orders = append(plan.Orders[:last], plan.Orders[last+1:]...)
+		targets = targets[:len(targets)-1]
+	}
+
+	return nil
+}
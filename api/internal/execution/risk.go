@@ -0,0 +1,183 @@
+package execution
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// RiskBook is what a user's positions hold and have lost, as measured against their risk limits
+type RiskBook struct {
+	OpenPositions  int                           `json:"open_positions"`
+	TotalNotional  float64                       `json:"total_notional"`
+	SymbolNotional map[string]float64            `json:"symbol_notional"`
+	SideNotional   map[models.SignalSide]float64 `json:"side_notional"`
+	// DailyPnL and WeeklyPnL are the profit realised by positions closed in the current UTC day
+	// and week; losses are negative
+	DailyPnL  float64 `json:"daily_pnl"`
+	WeeklyPnL float64 `json:"weekly_pnl"`
+
+	// mu serialises Reserve and Release while a signal is placed on several platforms at once
+	mu sync.Mutex
+}
+
+// NewRiskBook creates an empty risk book
+func NewRiskBook() *RiskBook {
+	return &RiskBook{
+		SymbolNotional: make(map[string]float64),
+		SideNotional:   make(map[models.SignalSide]float64),
+	}
+}
+
+// Add counts one more position of notional in symbol on side
+func (b *RiskBook) Add(symbol string, side models.SignalSide, notional float64) {
+	b.OpenPositions++
+	b.TotalNotional += notional
+	b.SymbolNotional[strings.ToUpper(symbol)] += notional
+	b.SideNotional[side] += notional
+}
+
+// Reserve adds a position to the book if the exposure limits allow it, checking and adding in one
+// step so platforms executing in parallel cannot together exceed a limit
+func (b *RiskBook) Reserve(limits models.RiskLimits, symbol string, side models.SignalSide, notional float64) *RiskViolation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if violation := CheckExposure(limits, b, symbol, side, notional); violation != nil {
+		return violation
+	}
+	b.Add(symbol, side, notional)
+	return nil
+}
+
+// Release removes a reserved position that was never placed
+func (b *RiskBook) Release(symbol string, side models.SignalSide, notional float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.OpenPositions--
+	b.TotalNotional -= notional
+	b.SymbolNotional[strings.ToUpper(symbol)] -= notional
+	b.SideNotional[side] -= notional
+}
+
+// PositionExposure returns the notional a position holds plus what its working entries may still
+// add, priced at the entry fill or, for unfilled entries, at their limit price
+func PositionExposure(pos *models.Position) float64 {
+	exposure := max(pos.FilledQuantity-pos.ClosedQuantity, 0) * pos.EntryPrice
+	for _, order := range pos.Orders {
+		if order.Purpose != models.OrderPurposeEntry {
+			continue
+		}
+		switch order.Status {
+		case models.OrderStatusPending, models.OrderStatusNew, models.OrderStatusPartiallyFilled:
+			price := order.Price
+			if price == 0 {
+				price = pos.EntryPrice
+			}
+			exposure += max(order.Quantity-order.FilledQuantity, 0) * price
+		}
+	}
+	return exposure
+}
+
+// RiskPeriods returns the start of the UTC day and of the UTC week, starting Monday, holding now
+func RiskPeriods(now time.Time) (day, week time.Time) {
+	now = now.UTC()
+	day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	week = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	return day, week
+}
+
+// RiskViolation is a risk limit a signal would break
+type RiskViolation struct {
+	Rule  models.RiskRule
+	Limit float64
+	Value float64
+	// Until is when a loss limit stops pausing copying
+	Until *time.Time
+}
+
+func (v *RiskViolation) Error() string {
+	if v.Until != nil {
+		return fmt.Sprintf("%s %v reached with %v, copying resumes at %s", v.Rule, v.Limit, v.Value, v.Until.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s %v would be exceeded with %v", v.Rule, v.Limit, v.Value)
+}
+
+func (v *RiskViolation) Unwrap() error {
+	return exceptions.ErrRiskLimit
+}
+
+// CheckLossLimits reports the loss limit the book has reached, pausing copying until the next
+// period, or nil
+func CheckLossLimits(limits models.RiskLimits, book *RiskBook, now time.Time) *RiskViolation {
+	day, week := RiskPeriods(now)
+	if limits.WeeklyLossLimit > 0 && -book.WeeklyPnL >= limits.WeeklyLossLimit {
+		until := week.AddDate(0, 0, 7)
+		return &RiskViolation{Rule: models.RiskRuleWeeklyLossLimit, Limit: limits.WeeklyLossLimit, Value: -book.WeeklyPnL, Until: &until}
+	}
+	if limits.DailyLossLimit > 0 && -book.DailyPnL >= limits.DailyLossLimit {
+		until := day.AddDate(0, 0, 1)
+		return &RiskViolation{Rule: models.RiskRuleDailyLossLimit, Limit: limits.DailyLossLimit, Value: -book.DailyPnL, Until: &until}
+	}
+	return nil
+}
+
+// CheckExposure reports the first position or notional limit a new position of notional in symbol
+// on side would break, or nil
+func CheckExposure(limits models.RiskLimits, book *RiskBook, symbol string, side models.SignalSide, notional float64) *RiskViolation {
+	if limits.MaxOpenPositions > 0 && book.OpenPositions+1 > limits.MaxOpenPositions {
+		return &RiskViolation{Rule: models.RiskRuleMaxOpenPositions, Limit: float64(limits.MaxOpenPositions), Value: float64(book.OpenPositions + 1)}
+	}
+	if total := book.TotalNotional + notional; limits.MaxTotalNotional > 0 && total > limits.MaxTotalNotional {
+		return &RiskViolation{Rule: models.RiskRuleMaxTotalNotional, Limit: limits.MaxTotalNotional, Value: total}
+	}
+	if held := book.SymbolNotional[strings.ToUpper(symbol)] + notional; limits.MaxSymbolNotional > 0 && held > limits.MaxSymbolNotional {
+		return &RiskViolation{Rule: models.RiskRuleMaxSymbolNotional, Limit: limits.MaxSymbolNotional, Value: held}
+	}
+	if held := book.SideNotional[side] + notional; limits.MaxSideNotional > 0 && held > limits.MaxSideNotional {
+		return &RiskViolation{Rule: models.RiskRuleMaxSideNotional, Limit: limits.MaxSideNotional, Value: held}
+	}
+	return nil
+}
+
+// RiskBudget is what is left of a user's risk limits; remaining values are nil for disabled limits
+type RiskBudget struct {
+	Limits models.RiskLimits `json:"limits"`
+	Book   *RiskBook         `json:"book"`
+
+	RemainingPositions  *int     `json:"remaining_positions,omitempty"`
+	RemainingNotional   *float64 `json:"remaining_notional,omitempty"`
+	RemainingDailyLoss  *float64 `json:"remaining_daily_loss,omitempty"`
+	RemainingWeeklyLoss *float64 `json:"remaining_weekly_loss,omitempty"`
+	// PausedBy and PausedUntil name the loss limit pausing copying and when it lifts
+	PausedBy    models.RiskRule `json:"paused_by,omitempty"`
+	PausedUntil *time.Time      `json:"paused_until,omitempty"`
+}
+
+// BuildRiskBudget measures book against limits at now
+func BuildRiskBudget(limits models.RiskLimits, book *RiskBook, now time.Time) *RiskBudget {
+	budget := &RiskBudget{Limits: limits, Book: book}
+	if limits.MaxOpenPositions > 0 {
+		remaining := max(limits.MaxOpenPositions-book.OpenPositions, 0)
+		budget.RemainingPositions = &remaining
+	}
+	budget.RemainingNotional = remainingLimit(limits.MaxTotalNotional, book.TotalNotional)
+	budget.RemainingDailyLoss = remainingLimit(limits.DailyLossLimit, -book.DailyPnL)
+	budget.RemainingWeeklyLoss = remainingLimit(limits.WeeklyLossLimit, -book.WeeklyPnL)
+	if violation := CheckLossLimits(limits, book, now); violation != nil {
+		budget.PausedBy, budget.PausedUntil = violation.Rule, violation.Until
+	}
+	return budget
+}
+
+func remainingLimit(limit, used float64) *float64 {
+	if limit <= 0 {
+		return nil
+	}
+	remaining := max(limit-used, 0)
+	return &remaining
+}
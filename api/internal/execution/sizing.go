@@ -0,0 +1,119 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// MinStopDistance is the closest a stop may sit to entry, as a fraction of entry, when sizing from
+// risk; tighter stops would turn a small risk budget into an outsized position
+const MinStopDistance = 0.001
+
+// Account is what the sizing calculator knows about the account a position is opened from
+type Account struct {
+	// Available is the free quote balance; 0 when unknown, e.g. in a preview
+	Available float64 `json:"available"`
+}
+
+// Size is the result of sizing a position
+type Size struct {
+	Quantity float64 `json:"quantity"`
+	Notional float64 `json:"notional"`
+	Leverage int     `json:"leverage"`
+	// Warnings describe caps that reduced the position
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Leverage returns the leverage a signal is traded with: what the signal asks for unless the
+// user always uses their default, capped by the user's maximum
+func Leverage(sig *models.Signal, settings *models.TradeSettings) int {
+	leverage := max(settings.DefaultLeverage, 1)
+	if settings.LeverageSource != models.LeverageSourceDefault && sig.Leverage != nil && *sig.Leverage > 0 {
+		leverage = *sig.Leverage
+	}
+	if settings.MaxLeverage > 0 {
+		leverage = min(leverage, settings.MaxLeverage)
+	}
+	return leverage
+}
+
+// ValidateSizing checks that the settings carry the value their sizing mode needs
+func ValidateSizing(settings *models.TradeSettings) error {
+	switch settings.SizingMode {
+	case "", models.SizingModeFixedAmount:
+		if settings.PerTradeAmount <= 0 {
+			return fmt.Errorf("%w: fixed_amount sizing needs a per trade amount", exceptions.ErrInvalidSizing)
+		}
+	case models.SizingModeBalancePercentage:
+		if settings.BalancePercentage <= 0 || settings.BalancePercentage > 100 {
+			return fmt.Errorf("%w: balance_percentage sizing needs a balance percentage between 0 and 100", exceptions.ErrInvalidSizing)
+		}
+	case models.SizingModeFixedRisk:
+		if settings.RiskAmount <= 0 {
+			return fmt.Errorf("%w: fixed_risk sizing needs a risk amount", exceptions.ErrInvalidSizing)
+		}
+	case models.SizingModeFixedQuantity:
+		if settings.FixedQuantity <= 0 {
+			return fmt.Errorf("%w: fixed_quantity sizing needs a fixed quantity", exceptions.ErrInvalidSizing)
+		}
+	default:
+		return fmt.Errorf("%w: unknown sizing mode %q", exceptions.ErrInvalidSizing, settings.SizingMode)
+	}
+	if settings.MaxLeverage > 0 && settings.DefaultLeverage > settings.MaxLeverage {
+		return fmt.Errorf("%w: default leverage %dx is above the maximum of %dx", exceptions.ErrInvalidSizing, settings.DefaultLeverage, settings.MaxLeverage)
+	}
+
+	return nil
+}
+
+// SizePosition computes the quantity to enter at entry with the user's sizing mode, then caps
+// its notional at MaxNotional and at the available balance times leverage. stop is the stop loss
+// price, 0 when the position has none; fixed_risk sizing cannot work without one.
+func SizePosition(settings *models.TradeSettings, entry, stop float64, leverage int, account Account) (Size, error) {
+	if entry <= 0 {
+		return Size{}, fmt.Errorf("%w: entry price must be positive", exceptions.ErrInvalidSizing)
+	}
+	if err := ValidateSizing(settings); err != nil {
+		return Size{}, err
+	}
+	leverage = max(leverage, 1)
+
+	var notional float64
+	switch settings.SizingMode {
+	case "", models.SizingModeFixedAmount:
+		notional = settings.PerTradeAmount
+	case models.SizingModeBalancePercentage:
+		if account.Available <= 0 {
+			return Size{}, fmt.Errorf("%w: balance_percentage sizing needs the available balance", exceptions.ErrInvalidSizing)
+		}
+		notional = account.Available * settings.BalancePercentage / 100 * float64(leverage)
+	case models.SizingModeFixedRisk:
+		if stop <= 0 {
+			return Size{}, fmt.Errorf("%w: fixed_risk sizing needs a stop loss", exceptions.ErrInvalidSizing)
+		}
+		distance := math.Abs(entry-stop) / entry
+		if distance < MinStopDistance {
+			return Size{}, fmt.Errorf("%w: stop %v is within %.2f%% of entry %v", exceptions.ErrInvalidSizing, stop, MinStopDistance*100, entry)
+		}
+		notional = settings.RiskAmount / distance
+	case models.SizingModeFixedQuantity:
+		notional = settings.FixedQuantity * entry
+	}
+
+	size := Size{Leverage: leverage}
+	if settings.MaxNotional > 0 && notional > settings.MaxNotional {
+		size.Warnings = append(size.Warnings, fmt.Sprintf("notional %.2f capped at the maximum of %.2f", notional, settings.MaxNotional))
+		notional = settings.MaxNotional
+	}
+	if limit := account.Available * float64(leverage); account.Available > 0 && notional > limit {
+		size.Warnings = append(size.Warnings, fmt.Sprintf("notional %.2f capped at %.2f available at %dx leverage", notional, limit, leverage))
+		notional = limit
+	}
+
+	size.Notional = notional
+	size.Quantity = notional / entry
+	return size, nil
+}
@@ -0,0 +1,80 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"copier/internal/signal"
+)
+
+// Handler processes one raw message emitted by a source
+type Handler func(ctx context.Context, msg signal.Message) error
+
+// SignalSource is a transport that delivers raw channel messages to the copier
+type SignalSource interface {
+	// Name identifies the source in logs
+	Name() string
+	// Run delivers messages to handle until ctx is cancelled or the source is exhausted
+	Run(ctx context.Context, handle Handler) error
+}
+
+// Registry holds the configured sources and feeds their messages into a single handler
+type Registry struct {
+	handle Handler
+
+	mu      sync.Mutex
+	sources []SignalSource
+}
+
+// NewRegistry creates a registry that passes every emitted message to handle
+func NewRegistry(handle Handler) *Registry {
+	return &Registry{handle: handle}
+}
+
+// Register adds sources to be started by Run
+func (r *Registry) Register(sources ...SignalSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append(r.sources, sources...)
+}
+
+// Sources returns the registered sources
+func (r *Registry) Sources() []SignalSource {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]SignalSource(nil), r.sources...)
+}
+
+// Run starts every registered source and blocks until they have all stopped
+func (r *Registry) Run(ctx context.Context) error {
+	sources := r.Sources()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			slog.Info("Signal source started", "source", src.Name())
+			err := src.Run(ctx, r.handle)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("Signal source failed", "source", src.Name(), "error", err)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("source %s: %w", src.Name(), err))
+				mu.Unlock()
+				return
+			}
+			slog.Info("Signal source stopped", "source", src.Name())
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
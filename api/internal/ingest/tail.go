@@ -0,0 +1,114 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"copier/internal/signal"
+)
+
+// StdinPath makes a FileSource read from standard input
+const StdinPath = "-"
+
+// FileSource replays newline-delimited JSON messages from a file or stdin, optionally following new lines
+type FileSource struct {
+	path         string
+	follow       bool
+	pollInterval time.Duration
+}
+
+// NewFileSource creates a tailer for path; with follow set it keeps waiting for appended lines
+func NewFileSource(path string, follow bool) *FileSource {
+	return &FileSource{
+		path:         path,
+		follow:       follow,
+		pollInterval: 500 * time.Millisecond,
+	}
+}
+
+func (s *FileSource) Name() string {
+	return "file:" + s.path
+}
+
+// Run emits one message per line, skipping blank and malformed lines
+func (s *FileSource) Run(ctx context.Context, handle Handler) error {
+	var in io.Reader = os.Stdin
+	if s.path != StdinPath {
+		f, err := os.Open(s.path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", s.path, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	reader := bufio.NewReader(in)
+	var partial []byte
+	lineNo := 0
+
+	for {
+		chunk, err := reader.ReadBytes('\n')
+		partial = append(partial, chunk...)
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read %s: %w", s.path, err)
+		}
+
+		// Without follow a trailing line lacking a newline is still a complete message
+		if err == nil || !s.follow {
+			if len(partial) > 0 {
+				lineNo++
+				s.emit(ctx, handle, partial, lineNo)
+				partial = partial[:0]
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			if !s.follow {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(s.pollInterval):
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *FileSource) emit(ctx context.Context, handle Handler, line []byte, lineNo int) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+
+	var msg signal.Message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		slog.Warn("Skipping malformed message line", "source", s.Name(), "line", lineNo, "error", err)
+		return
+	}
+	if msg.ChannelID == "" {
+		slog.Warn("Skipping message without channel_id", "source", s.Name(), "line", lineNo)
+		return
+	}
+	if msg.MessageID == "" {
+		msg.MessageID = fmt.Sprintf("line-%d", lineNo)
+	}
+
+	if err := handle(ctx, msg); err != nil {
+		slog.Error("Failed to ingest message", "source", s.Name(), "line", lineNo, "error", err)
+	}
+}
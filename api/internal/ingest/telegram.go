@@ -0,0 +1,106 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"copier/internal/signal"
+)
+
+// TelegramExport is the subset of a Telegram Desktop "result.json" channel export the copier reads
+type TelegramExport struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	ID       int64             `json:"id"`
+	Messages []TelegramMessage `json:"messages"`
+}
+
+// TelegramMessage is one entry of an export; service entries (joins, pins) have Type "service"
+type TelegramMessage struct {
+	ID               int64        `json:"id"`
+	Type             string       `json:"type"`
+	Date             string       `json:"date"`
+	DateUnixtime     string       `json:"date_unixtime"`
+	ReplyToMessageID int64        `json:"reply_to_message_id"`
+	Text             TelegramText `json:"text"`
+}
+
+// TelegramText is exported either as a plain string or as a list of strings and formatted entities
+type TelegramText string
+
+func (t *TelegramText) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		*t = TelegramText(plain)
+		return nil
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return fmt.Errorf("unsupported text format: %w", err)
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		var s string
+		if err := json.Unmarshal(part, &s); err == nil {
+			b.WriteString(s)
+			continue
+		}
+		var entity struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(part, &entity); err == nil {
+			b.WriteString(entity.Text)
+		}
+	}
+	*t = TelegramText(b.String())
+	return nil
+}
+
+// ParseTelegramExport decodes a Telegram Desktop JSON export
+func ParseTelegramExport(r io.Reader) (*TelegramExport, error) {
+	var export TelegramExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to decode telegram export: %w", err)
+	}
+	return &export, nil
+}
+
+// SignalMessages converts the export's text messages into historical messages of channelID, oldest first
+func (e *TelegramExport) SignalMessages(channelID string) []signal.Message {
+	msgs := make([]signal.Message, 0, len(e.Messages))
+	for _, m := range e.Messages {
+		if m.Type != "message" || strings.TrimSpace(string(m.Text)) == "" {
+			continue
+		}
+
+		msg := signal.Message{
+			ChannelID:  channelID,
+			MessageID:  strconv.FormatInt(m.ID, 10),
+			Text:       string(m.Text),
+			PostedAt:   m.postedAt(),
+			Historical: true,
+		}
+		if m.ReplyToMessageID != 0 {
+			msg.ReplyToID = strconv.FormatInt(m.ReplyToMessageID, 10)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// postedAt prefers the exact unix time; older exports only carry a zone-less local date
+func (m *TelegramMessage) postedAt() time.Time {
+	if sec, err := strconv.ParseInt(m.DateUnixtime, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC()
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05", m.Date); err == nil {
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,156 @@
+package ingest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"copier/internal/services"
+	AppError "copier/internal/shared/error"
+	"copier/internal/shared/response"
+	"copier/internal/shared/utils"
+	"copier/internal/signal"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of the raw request body, keyed by the channel's webhook secret
+	SignatureHeader = "X-Signature"
+
+	maxWebhookBody = 64 << 10
+)
+
+// WebhookPayload is the JSON body accepted from alert services and custom bots
+type WebhookPayload struct {
+	MessageID string             `json:"message_id" validate:"max=255"`
+	ReplyToID string             `json:"reply_to_id" validate:"max=255"`
+	Text      string             `json:"text" validate:"required_unless=Kind delete"`
+	Kind      signal.MessageKind `json:"kind" validate:"omitempty,oneof=new edit delete"`
+	PostedAt  *time.Time         `json:"posted_at"`
+}
+
+// WebhookSource receives signed messages over HTTP for a single user channel
+type WebhookSource struct {
+	channelService services.ChannelService
+
+	mu     sync.RWMutex
+	handle Handler
+}
+
+// NewWebhookSource creates a webhook source that verifies requests against each channel's secret
+func NewWebhookSource(channelService services.ChannelService) *WebhookSource {
+	return &WebhookSource{channelService: channelService}
+}
+
+func (s *WebhookSource) Name() string {
+	return "webhook"
+}
+
+// Run accepts webhook deliveries until ctx is cancelled
+func (s *WebhookSource) Run(ctx context.Context, handle Handler) error {
+	s.mu.Lock()
+	s.handle = handle
+	s.mu.Unlock()
+
+	<-ctx.Done()
+
+	s.mu.Lock()
+	s.handle = nil
+	s.mu.Unlock()
+	return nil
+}
+
+// ServeHTTP verifies the signature of a delivery and emits it for the channel in the path
+func (s *WebhookSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := utils.ResolvePathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	s.mu.RLock()
+	handle := s.handle
+	s.mu.RUnlock()
+	if handle == nil {
+		AppError.ServiceUnavailable("Webhook ingestion is not running").WriteToResponse(w)
+		return
+	}
+
+	channel, err := s.channelService.GetChannelByID(r.Context(), id)
+	if err != nil || channel.WebhookSecret == "" {
+		AppError.ResourceNotFound("Channel", id.String()).WriteToResponse(w)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil || len(body) > maxWebhookBody {
+		AppError.BadRequest("Invalid request body").WriteToResponse(w)
+		return
+	}
+
+	if !VerifySignature(channel.WebhookSecret, body, r.Header.Get(SignatureHeader)) {
+		AppError.Unauthorized("Invalid webhook signature").WriteToResponse(w)
+		return
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		AppError.BadRequest("Invalid request body").WriteToResponse(w)
+		return
+	}
+	if errs := utils.ValidateStruct(&payload); errs != nil {
+		AppError.MultipleValidationErrors(errs).WriteToResponse(w)
+		return
+	}
+
+	msg := signal.Message{
+		ChannelID: channel.ChannelID,
+		TargetID:  channel.ID,
+		MessageID: payload.MessageID,
+		ReplyToID: payload.ReplyToID,
+		Text:      payload.Text,
+		Kind:      payload.Kind,
+	}
+	if msg.MessageID == "" {
+		// Alerts rarely carry an ID; derive one from the body so redeliveries map to the same message
+		sum := sha256.Sum256(body)
+		msg.MessageID = "wh-" + hex.EncodeToString(sum[:8])
+	}
+	if payload.PostedAt != nil {
+		msg.PostedAt = *payload.PostedAt
+	}
+
+	if err := handle(r.Context(), msg); err != nil {
+		AppError.InternalServerErrorWithError("Failed to ingest message", err).WriteToResponse(w)
+		return
+	}
+
+	response.WriteOK(w, "Message accepted", map[string]interface{}{
+		"message_id": msg.MessageID,
+	})
+}
+
+// Sign returns the hex HMAC-SHA256 of body keyed by secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a signature header value, with or without a "sha256=" prefix
+func VerifySignature(secret string, body []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
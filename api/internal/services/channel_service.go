@@ -2,39 +2,56 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
 
 	"copier/database/repositories"
 	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+	"copier/internal/signal"
 
 	"github.com/google/uuid"
 )
 
 // ChannelService defines channel business logic operations
 type ChannelService interface {
-	CreateChannel(ctx context.Context, userID uuid.UUID, name, channelID string) (*models.Channel, error)
+	CreateChannel(ctx context.Context, channel *models.Channel) (*models.Channel, error)
 	GetChannelsByUser(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error)
 	GetChannelByID(ctx context.Context, id uuid.UUID) (*models.Channel, error)
 	UpdateChannel(ctx context.Context, id uuid.UUID, update *models.Channel) (*models.Channel, error)
+	UpdateParserRules(ctx context.Context, id uuid.UUID, rules []models.ParserRule) (*models.Channel, error)
+	UpdateFilter(ctx context.Context, id uuid.UUID, filter models.ChannelFilter) (*models.Channel, error)
+	UpdateExpiry(ctx context.Context, id uuid.UUID, expiry models.ExpiryRules) (*models.Channel, error)
+	GetRoutes(ctx context.Context, id uuid.UUID) ([]*models.ChannelRoute, error)
+	ReplaceRoutes(ctx context.Context, channel *models.Channel, routes []*models.ChannelRoute) ([]*models.ChannelRoute, error)
+	RotateWebhookSecret(ctx context.Context, id uuid.UUID) (string, error)
 	DeleteChannel(ctx context.Context, id uuid.UUID) error
 }
 
 type channelService struct {
-	channelRepo repositories.ChannelRepository
+	channelRepo  repositories.ChannelRepository
+	platformRepo repositories.PlatformRepository
 }
 
 // NewChannelService creates a new channel service instance
-func NewChannelService(channelRepo repositories.ChannelRepository) ChannelService {
+func NewChannelService(channelRepo repositories.ChannelRepository, platformRepo repositories.PlatformRepository) ChannelService {
 	return &channelService{
-		channelRepo: channelRepo,
+		channelRepo:  channelRepo,
+		platformRepo: platformRepo,
 	}
 }
 
-func (s *channelService) CreateChannel(ctx context.Context, userID uuid.UUID, name, channelID string) (*models.Channel, error) {
-	channel := &models.Channel{
-		UserID:    userID,
-		Name:      name,
-		ChannelID: channelID,
+func (s *channelService) CreateChannel(ctx context.Context, channel *models.Channel) (*models.Channel, error) {
+	if err := signal.ValidateRules(channel.ParserRules); err != nil {
+		return nil, err
+	}
+
+	if channel.DeletePolicy == "" {
+		channel.DeletePolicy = models.DeletePolicyIgnore
 	}
+	channel.Filter = normalizeFilter(channel.Filter)
 
 	err := s.channelRepo.CreateChannel(ctx, channel)
 	if err != nil {
@@ -53,6 +70,12 @@ func (s *channelService) GetChannelByID(ctx context.Context, id uuid.UUID) (*mod
 }
 
 func (s *channelService) UpdateChannel(ctx context.Context, id uuid.UUID, update *models.Channel) (*models.Channel, error) {
+	if err := signal.ValidateRules(update.ParserRules); err != nil {
+		return nil, err
+	}
+
+	update.Filter = normalizeFilter(update.Filter)
+
 	err := s.channelRepo.UpdateChannel(ctx, id, update)
 	if err != nil {
 		return nil, err
@@ -60,6 +83,109 @@ func (s *channelService) UpdateChannel(ctx context.Context, id uuid.UUID, update
 	return s.channelRepo.FindByIDTyped(ctx, id)
 }
 
+// UpdateParserRules replaces a channel's parser rules, clearing them when rules is empty
+func (s *channelService) UpdateParserRules(ctx context.Context, id uuid.UUID, rules []models.ParserRule) (*models.Channel, error) {
+	if err := signal.ValidateRules(rules); err != nil {
+		return nil, err
+	}
+
+	err := s.channelRepo.UpdateParserRules(ctx, id, rules)
+	if err != nil {
+		return nil, err
+	}
+	return s.channelRepo.FindByIDTyped(ctx, id)
+}
+
+// UpdateFilter replaces the policy deciding which of a channel's signals are copied
+func (s *channelService) UpdateFilter(ctx context.Context, id uuid.UUID, filter models.ChannelFilter) (*models.Channel, error) {
+	err := s.channelRepo.UpdateFilter(ctx, id, normalizeFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	return s.channelRepo.FindByIDTyped(ctx, id)
+}
+
+// UpdateExpiry replaces the expiry rules applied to a channel's signals
+func (s *channelService) UpdateExpiry(ctx context.Context, id uuid.UUID, expiry models.ExpiryRules) (*models.Channel, error) {
+	err := s.channelRepo.UpdateExpiry(ctx, id, expiry)
+	if err != nil {
+		return nil, err
+	}
+	return s.channelRepo.FindByIDTyped(ctx, id)
+}
+
+// GetRoutes returns the platform routes of a channel
+func (s *channelService) GetRoutes(ctx context.Context, id uuid.UUID) ([]*models.ChannelRoute, error) {
+	return s.channelRepo.FindRoutes(ctx, id)
+}
+
+// ReplaceRoutes replaces the platforms a channel's signals are copied to. Every route must name a
+// different platform of the channel's user; an empty list copies signals to all platforms again.
+func (s *channelService) ReplaceRoutes(ctx context.Context, channel *models.Channel, routes []*models.ChannelRoute) ([]*models.ChannelRoute, error) {
+	platforms, err := s.platformRepo.FindByUserID(ctx, channel.UserID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[uuid.UUID]bool, len(platforms))
+	for _, platform := range platforms {
+		owned[platform.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(routes))
+	for _, route := range routes {
+		switch {
+		case !owned[route.PlatformID]:
+			return nil, fmt.Errorf("%w: platform %s is not one of the user's platforms", exceptions.ErrInvalidRoute, route.PlatformID)
+		case seen[route.PlatformID]:
+			return nil, fmt.Errorf("%w: platform %s is routed more than once", exceptions.ErrInvalidRoute, route.PlatformID)
+		}
+		seen[route.PlatformID] = true
+		route.ID = uuid.Nil
+		route.UserID = channel.UserID
+		route.ChannelID = channel.ID
+	}
+
+	if err := s.channelRepo.ReplaceRoutes(ctx, channel.ID, routes); err != nil {
+		return nil, err
+	}
+	return s.channelRepo.FindRoutes(ctx, channel.ID)
+}
+
+// normalizeFilter upper-cases symbol and quote lists so matching is case-insensitive. Unset lists
+// stay nil, so a partial channel update without a filter leaves the stored one alone.
+func normalizeFilter(filter models.ChannelFilter) models.ChannelFilter {
+	upper := func(list []string) []string {
+		if list == nil {
+			return nil
+		}
+		out := make([]string, 0, len(list))
+		for _, v := range list {
+			if v = strings.ToUpper(strings.TrimSpace(v)); v != "" {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	filter.AllowSymbols = upper(filter.AllowSymbols)
+	filter.DenySymbols = upper(filter.DenySymbols)
+	filter.QuoteAssets = upper(filter.QuoteAssets)
+	return filter
+}
+
+// RotateWebhookSecret generates a new webhook secret for a channel, invalidating the previous one
+func (s *channelService) RotateWebhookSecret(ctx context.Context, id uuid.UUID) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	secret := hex.EncodeToString(buf)
+
+	if err := s.channelRepo.UpdateWebhookSecret(ctx, id, secret); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
 func (s *channelService) DeleteChannel(ctx context.Context, id uuid.UUID) error {
 	return s.channelRepo.DeleteChannel(ctx, id)
 }
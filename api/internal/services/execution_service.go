@@ -0,0 +1,1418 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// executionBatchSize bounds how many signals or orders one worker pass handles
+	executionBatchSize = 100
+	// unconfirmedOrderGrace gives an order in flight time to reach the exchange before the worker
+	// looks it up again
+	unconfirmedOrderGrace = 30 * time.Second
+)
+
+// ExecutionService turns live signals into exchange orders on each of the user's platforms
+type ExecutionService interface {
+	// ExecuteSignal places the orders of a new signal; signals already claimed by another worker are
+	// ignored, and signals arriving while trading is halted are skipped
+	ExecuteSignal(ctx context.Context, sig *models.Signal) error
+	// ExecuteDue executes every signal whose copy delay has passed and returns how many were handled
+	ExecuteDue(ctx context.Context) (int, error)
+	// SyncOrders polls resting orders, moving their positions along and placing protective
+	// orders once an entry fills
+	SyncOrders(ctx context.Context) error
+	// ApplyActions carries out pending close and cancel commands on the positions of their signals
+	ApplyActions(ctx context.Context) error
+	// TrailStops moves percentage trailing stops of positions whose first target has filled
+	TrailStops(ctx context.Context) error
+	// SyncOrder refreshes one resting order from the exchange and moves its position along
+	SyncOrder(ctx context.Context, order *models.Order) error
+	// MarkOrderMissing records a resting order the exchange no longer knows as cancelled with
+	// reason, keeping what it filled, and moves its position along
+	MarkOrderMissing(ctx context.Context, order *models.Order, reason string) error
+	// SettleExternalClose closes a position that was closed outside the copier at price and
+	// cancels its remaining orders
+	SettleExternalClose(ctx context.Context, pos *models.Position, price float64, reason string) error
+	// CancelEntries cancels the entries of a position that have not filled, recording reason on
+	// them; a position without a fill is cancelled with them
+	CancelEntries(ctx context.Context, pos *models.Position, reason string) error
+	// ClosePosition cancels a position's unfilled entries and closes what it holds at market,
+	// recording reason as why it closed
+	ClosePosition(ctx context.Context, pos *models.Position, reason string) error
+	// ResolveUnconfirmed looks up orders whose placement outcome is unknown by their client order
+	// ID, adopting those the exchange received and resending the others
+	ResolveUnconfirmed(ctx context.Context) error
+	// RunWorker calls ExecuteDue, ApplyActions, ResolveUnconfirmed, SyncOrders and TrailStops every
+	// interval until ctx is cancelled
+	RunWorker(ctx context.Context, interval time.Duration)
+}
+
+// positionActions lists the management commands that act on positions
+var positionActions = []models.SignalActionType{
+	models.SignalActionCancelPending,
+	models.SignalActionCloseAll,
+	models.SignalActionClosePartial,
+	models.SignalActionMoveStop,
+}
+
+type executionService struct {
+	signalRepo      repositories.SignalRepository
+	orderRepo       repositories.OrderRepository
+	positionRepo    repositories.PositionRepository
+	settingsRepo    repositories.TradeSettingsRepository
+	platformRepo    repositories.PlatformRepository
+	channelRepo     repositories.ChannelRepository
+	platformService PlatformService
+	symbolService   SymbolService
+	symbolInfo      SymbolInfoService
+	killSwitch      KillSwitch
+	riskService     RiskService
+	maxSignalAge    time.Duration
+	// symbolSettings remembers the leverage and margin type applied per platform and symbol
+	symbolSettings *exchange.SettingsCache
+}
+
+// NewExecutionService creates a new execution service instance; signals older than maxSignalAge
+// when they become due are skipped, and zero disables the check
+func NewExecutionService(signalRepo repositories.SignalRepository, orderRepo repositories.OrderRepository, positionRepo repositories.PositionRepository, settingsRepo repositories.TradeSettingsRepository, platformRepo repositories.PlatformRepository, channelRepo repositories.ChannelRepository, platformService PlatformService, symbolService SymbolService, symbolInfo SymbolInfoService, killSwitch KillSwitch, riskService RiskService, maxSignalAge time.Duration) ExecutionService {
+	return &executionService{
+		signalRepo:      signalRepo,
+		orderRepo:       orderRepo,
+		positionRepo:    positionRepo,
+		settingsRepo:    settingsRepo,
+		platformRepo:    platformRepo,
+		channelRepo:     channelRepo,
+		platformService: platformService,
+		symbolService:   symbolService,
+		symbolInfo:      symbolInfo,
+		killSwitch:      killSwitch,
+		riskService:     riskService,
+		maxSignalAge:    maxSignalAge,
+		symbolSettings:  exchange.NewSettingsCache(),
+	}
+}
+
+// platformOutcome is the result of executing a signal on one platform
+type platformOutcome struct {
+	placed  bool
+	skipped bool
+	reason  string
+}
+
+func (s *executionService) ExecuteSignal(ctx context.Context, sig *models.Signal) error {
+	// the halt is read before every claim so it takes effect on all instances at once; while it
+	// cannot be read the signal is left for the next run
+	halt, err := s.killSwitch.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	claimed, err := s.signalRepo.TransitionStatus(ctx, sig.ID, []models.SignalStatus{models.SignalStatusNew}, models.SignalStatusActive, "")
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	status, reason := models.SignalStatusSkipped, ""
+	var results []models.PlatformResult
+	if halt != nil {
+		reason = fmt.Sprintf("trading is halted: %s", halt.Reason)
+	} else {
+		status, reason, results = s.execute(ctx, sig)
+	}
+	if len(results) > 0 {
+		// the orders are placed whether or not their summary is saved, so the status still follows
+		err := s.signalRepo.UpdateSignalColumns(ctx, sig.ID, &models.Signal{PlatformResults: results}, "platform_results")
+		if err != nil {
+			slog.Error("Failed to save signal platform results", "signal_id", sig.ID, "error", err)
+		}
+		sig.PlatformResults = results
+	}
+	if _, err := s.signalRepo.TransitionStatus(ctx, sig.ID, []models.SignalStatus{models.SignalStatusActive}, status, reason); err != nil {
+		return err
+	}
+	sig.Status, sig.StatusReason = status, reason
+
+	slog.Info("Signal executed", "signal_id", sig.ID, "symbol", sig.Symbol, "status", status, "reason", reason)
+	return nil
+}
+
+// execute places the signal on every platform its channel routes to, in parallel, and returns the
+// resulting signal status with the outcome on each platform
+func (s *executionService) execute(ctx context.Context, sig *models.Signal) (models.SignalStatus, string, []models.PlatformResult) {
+	if s.maxSignalAge > 0 && !sig.PostedAt.IsZero() && time.Since(sig.PostedAt) > s.maxSignalAge {
+		return models.SignalStatusSkipped, fmt.Sprintf("signal is older than %s", s.maxSignalAge), nil
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, sig.UserID)
+	if err != nil {
+		return models.SignalStatusSkipped, "user has no trade settings", nil
+	}
+	if settings.TradingPaused {
+		return models.SignalStatusSkipped, "trading is paused", nil
+	}
+	platforms, err := s.platformRepo.FindByUserID(ctx, sig.UserID)
+	if err != nil {
+		return models.SignalStatusFailed, err.Error(), nil
+	}
+	if len(platforms) == 0 {
+		return models.SignalStatusSkipped, "user has no platforms", nil
+	}
+	routes, err := s.channelRepo.FindRoutes(ctx, sig.ChannelID)
+	if err != nil {
+		return models.SignalStatusFailed, err.Error(), nil
+	}
+	allocations := execution.Allocate(platforms, routes, settings)
+	if len(allocations) == 0 {
+		return models.SignalStatusSkipped, "channel has no enabled route to a platform", nil
+	}
+	// the risk book is measured once and grows with each platform the signal is placed on
+	var book *execution.RiskBook
+	if settings.RiskLimits != (models.RiskLimits{}) {
+		now := time.Now()
+		if book, err = s.riskService.Book(ctx, sig.UserID, now); err != nil {
+			return models.SignalStatusFailed, err.Error(), nil
+		}
+		if violation := execution.CheckLossLimits(settings.RiskLimits, book, now); violation != nil {
+			s.riskService.Reject(ctx, sig, nil, sig.Symbol, violation)
+			return models.SignalStatusSkipped, violation.Error(), nil
+		}
+	}
+	expiry := settings.ExpiryRules
+	if channel, err := s.channelRepo.FindByIDTyped(ctx, sig.ChannelID); err == nil {
+		expiry = execution.ResolveExpiry(channel.Expiry, settings.ExpiryRules)
+	} else {
+		slog.Warn("Failed to load signal channel, using the user's expiry rules", "signal_id", sig.ID, "error", err)
+	}
+
+	results := make([]models.PlatformResult, len(allocations))
+	var wg sync.WaitGroup
+	for i, allocation := range allocations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.executeAllocation(ctx, sig, allocation, expiry, book)
+		}()
+	}
+	wg.Wait()
+
+	var (
+		placed  bool
+		failed  bool
+		reasons []string
+	)
+	for _, result := range results {
+		switch result.Status {
+		case models.PlatformResultPlaced:
+			placed = true
+		case models.PlatformResultFailed:
+			failed = true
+		}
+		if result.Reason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", result.Name, result.Reason))
+		}
+	}
+
+	reason := strings.Join(reasons, "; ")
+	switch {
+	case placed:
+		return models.SignalStatusActive, reason, results
+	case failed:
+		return models.SignalStatusFailed, reason, results
+	default:
+		return models.SignalStatusSkipped, reason, results
+	}
+}
+
+// executeAllocation executes the signal on one allocated platform. A panic fails only that
+// platform, leaving the signal's other platforms to finish.
+func (s *executionService) executeAllocation(ctx context.Context, sig *models.Signal, allocation execution.Allocation, expiry models.ExpiryRules, book *execution.RiskBook) (result models.PlatformResult) {
+	platform := allocation.Platform
+	result = models.PlatformResult{PlatformID: platform.ID, Name: platform.Name}
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Panic while executing signal on platform", "signal_id", sig.ID, "platform_id", platform.ID, "panic", r)
+			result.Status, result.Reason = models.PlatformResultFailed, fmt.Sprintf("internal error: %v", r)
+		}
+	}()
+
+	outcome := s.executeOnPlatform(ctx, sig, allocation.Settings, expiry, book, platform)
+	switch {
+	case outcome.placed:
+		result.Status = models.PlatformResultPlaced
+	case outcome.skipped:
+		result.Status = models.PlatformResultSkipped
+	default:
+		result.Status = models.PlatformResultFailed
+	}
+	result.Reason = outcome.reason
+	return result
+}
+
+// executeOnPlatform records the signal's planned orders for one platform, places the entry and,
+// once the entry has filled, its protective orders. The position keeps the expiry rules it was
+// created with. With a risk book, positions the user's risk limits do not allow are not placed and
+// the exposure of the others is reserved in the book until the position is created.
+func (s *executionService) executeOnPlatform(ctx context.Context, sig *models.Signal, settings *models.TradeSettings, expiry models.ExpiryRules, book *execution.RiskBook, platform *models.Platform) platformOutcome {
+	resolved, err := s.symbolService.Resolve(ctx, platform.Exchange, sig.Symbol)
+	if err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+	scaled := execution.ScaleSignal(sig, resolved.Symbol, resolved.Multiplier)
+
+	client, err := s.platformService.Client(platform)
+	if err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+	price, err := client.Price(ctx, resolved.Symbol)
+	if err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+
+	decision := execution.DecideEntryStrategy(scaled, settings, price)
+	if decision.Mode == execution.EntrySkip {
+		return platformOutcome{skipped: true, reason: decision.Reason}
+	}
+
+	balances, err := client.Balances(ctx)
+	if err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+	account := execution.Account{Available: availableQuote(balances, resolved.Symbol)}
+
+	plan := execution.PlanOrdersAt(scaled, settings, decision, account)
+	if len(plan.Orders) == 0 {
+		return platformOutcome{skipped: true, reason: strings.Join(plan.Warnings, "; ")}
+	}
+	info, err := s.symbolInfo.Info(ctx, client, scaled.Symbol)
+	if err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+	if err := execution.ApplySymbolRules(plan, info); err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+	// kept is set once a position holds the exposure reserved in the risk book
+	kept := false
+	if book != nil {
+		notional := plan.EntryNotional()
+		if violation := book.Reserve(settings.RiskLimits, scaled.Symbol, sig.Side, notional); violation != nil {
+			s.riskService.Reject(ctx, sig, &platform.ID, scaled.Symbol, violation)
+			return platformOutcome{skipped: true, reason: violation.Error()}
+		}
+		defer func() {
+			if !kept {
+				book.Release(scaled.Symbol, sig.Side, notional)
+			}
+		}()
+	}
+
+	marginMode := settings.MarginMode
+	if marginMode == "" {
+		marginMode = models.MarginModeCross
+	}
+	err = s.symbolSettings.Apply(ctx, client, platform.ID.String(), scaled.Symbol, exchange.SymbolSettings{
+		Leverage:   plan.Leverage,
+		MarginType: marginType(marginMode),
+	})
+	if err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+
+	pos := &models.Position{
+		UserID:     sig.UserID,
+		SignalID:   sig.ID,
+		PlatformID: platform.ID,
+		Symbol:     scaled.Symbol,
+		Side:       sig.Side,
+		Status:     models.PositionStatusPendingEntry,
+		Quantity:   plan.EntryQuantity(),
+		Leverage:   plan.Leverage,
+		MarginMode: marginMode,
+		StopRules:  settings.StopRules,
+		Expiry:     expiry,
+	}
+	pos.EntryExpiresAt = execution.EntryDeadline(expiry, time.Now())
+	if len(scaled.Targets) > 0 {
+		pos.FirstTarget = scaled.Targets[0]
+	}
+	for _, planned := range plan.Orders {
+		if planned.Purpose == execution.PurposeStopLoss {
+			pos.StopPrice = planned.Price
+		}
+	}
+	created := &models.PositionEvent{
+		ToStatus: models.PositionStatusPendingEntry,
+		Trigger:  models.PositionTriggerCreated,
+		Detail:   fmt.Sprintf("%s entry at %v with %dx %s margin", decision.Mode, decision.Price, plan.Leverage, marginMode),
+	}
+	if err := s.positionRepo.CreatePosition(ctx, pos, created); err != nil {
+		return platformOutcome{reason: err.Error()}
+	}
+	kept = true
+
+	orders := make([]*models.Order, 0, len(plan.Orders))
+	for _, planned := range plan.Orders {
+		order := &models.Order{
+			UserID:     sig.UserID,
+			SignalID:   sig.ID,
+			PlatformID: platform.ID,
+			PositionID: &pos.ID,
+			Purpose:    models.OrderPurpose(planned.Purpose),
+			Leg:        planned.Leg,
+			Symbol:     planned.Symbol,
+			Side:       string(planned.Side),
+			Type:       string(planned.Type),
+			Price:      planned.Price,
+			Quantity:   planned.Quantity,
+			ReduceOnly: planned.ReduceOnly,
+			Status:     models.OrderStatusPending,
+		}
+		order.ClientOrderID = execution.ClientOrderID(sig.ID, pos.ID, order.Purpose, order.Leg, order.Revision)
+		if err := s.orderRepo.CreateOrder(ctx, order); err != nil {
+			return platformOutcome{reason: err.Error()}
+		}
+		orders = append(orders, order)
+	}
+
+	entries, protection := orders[:len(plan.Entries())], orders[len(plan.Entries()):]
+	for _, entry := range entries {
+		s.submit(ctx, client, entry)
+	}
+	rejected := true
+	for _, entry := range entries {
+		s.followEntry(ctx, client, pos, entry, entries, protection)
+		rejected = rejected && entry.Status == models.OrderStatusRejected
+	}
+	if rejected {
+		return platformOutcome{reason: entries[0].Error}
+	}
+
+	return platformOutcome{placed: true, reason: decision.Reason}
+}
+
+// followEntry moves a position along with its entry orders after entry changed. The fills of all
+// entries add up on the position. Each time an entry ends while the position holds a fill, the
+// protective orders are placed or resized to it; once every entry ended without a fill, the
+// protection is cancelled with the position.
+func (s *executionService) followEntry(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, entry *models.Order, entries, protection []*models.Order) {
+	if execution.IsTerminal(pos.Status) || entry.Status == models.OrderStatusPending {
+		// an entry sent without a confirmed outcome is resolved by the worker
+		return
+	}
+
+	var filled, cost float64
+	working := false
+	for _, order := range entries {
+		filled += order.FilledQuantity
+		cost += order.FilledQuantity * order.AvgFillPrice
+		switch order.Status {
+		case models.OrderStatusPending, models.OrderStatusNew, models.OrderStatusPartiallyFilled:
+			working = true
+		}
+	}
+
+	if filled > 0 && (filled-pos.FilledQuantity > reconcileQuantityTolerance || (!working && pos.Status == models.PositionStatusPartiallyFilled)) {
+		s.transition(ctx, pos, models.PositionTriggerEntryFill, fmt.Sprintf("filled %v at %v", filled, cost/filled), func(pos *models.Position) error {
+			return execution.ApplyEntryFill(pos, filled, cost/filled, !working)
+		})
+	}
+	if entry.Status == models.OrderStatusNew || entry.Status == models.OrderStatusPartiallyFilled {
+		return
+	}
+
+	if filled > 0 {
+		s.protect(ctx, client, pos, protection)
+		return
+	}
+	if working {
+		return
+	}
+
+	to, trigger, detail := models.PositionStatusCancelled, models.PositionTriggerEntryCancelled, "entry order was cancelled"
+	switch entry.Status {
+	case models.OrderStatusRejected:
+		trigger, detail = models.PositionTriggerEntryRejected, entry.Error
+	case models.OrderStatusExpired:
+		to, trigger, detail = models.PositionStatusExpired, models.PositionTriggerEntryExpired, "entry order expired"
+	default:
+		// entries the copier cancelled carry its reason, and a timed-out entry expires the position
+		if entry.CancelReason != "" {
+			detail = entry.CancelReason
+		}
+		if pos.EntryExpiresAt != nil && !time.Now().Before(*pos.EntryExpiresAt) {
+			to, trigger = models.PositionStatusExpired, models.PositionTriggerEntryExpired
+		}
+	}
+	s.cancelPending(ctx, protection, detail)
+	s.transition(ctx, pos, trigger, detail, func(pos *models.Position) error {
+		pos.CloseReason = detail
+		return execution.TransitionPosition(pos, to)
+	})
+}
+
+// protect sizes the stop loss and take profit orders of a position to the quantity it has filled.
+// Protection that was never sent is placed for the filled share of the planned entry; protection
+// already working is replaced once another entry added to the position.
+func (s *executionService) protect(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, protection []*models.Order) {
+	if math.Abs(pos.FilledQuantity-pos.ProtectedQuantity) <= reconcileQuantityTolerance {
+		return
+	}
+
+	for _, order := range protection {
+		if order.Status != models.OrderStatusPending || order.SubmittedAt != nil {
+			s.resizeProtection(ctx, client, pos, protection)
+			return
+		}
+	}
+
+	ratio := 1.0
+	if pos.Quantity > 0 {
+		ratio = pos.FilledQuantity / pos.Quantity
+	}
+	for _, order := range protection {
+		order.Quantity *= ratio
+		s.submit(ctx, client, order)
+	}
+	pos.ProtectedQuantity = pos.FilledQuantity
+	if err := s.positionRepo.UpdatePositionColumns(ctx, pos.ID, pos, "protected_quantity"); err != nil {
+		slog.Error("Failed to record protected quantity", "position_id", pos.ID, "error", err)
+	}
+}
+
+// resizeProtection replaces the working stop loss and take profit orders of a position with ones
+// sized to what it holds. Each target keeps its share of the position.
+func (s *executionService) resizeProtection(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, protection []*models.Order) {
+	remaining := pos.Remaining()
+	var targets []*models.Order
+	var sized float64
+	stopWorking := false
+	for _, order := range protection {
+		switch {
+		case order.Purpose == models.OrderPurposeTakeProfit && order.Status == models.OrderStatusNew && order.FilledQuantity == 0:
+			targets = append(targets, order)
+			sized += order.Quantity
+		case order.Purpose == models.OrderPurposeStopLoss && (order.Status == models.OrderStatusNew || order.Status == models.OrderStatusPartiallyFilled):
+			stopWorking = true
+		}
+	}
+
+	for _, target := range targets {
+		s.replaceTarget(ctx, client, pos, target, target.Quantity*remaining/sized)
+	}
+	if stopWorking {
+		s.moveStop(ctx, client, pos, protection, pos.StopPrice, "entry filled further")
+	}
+	s.transition(ctx, pos, models.PositionTriggerProtectionResized, fmt.Sprintf("stop loss and targets resized to %v", remaining), func(pos *models.Position) error {
+		pos.ProtectedQuantity = pos.FilledQuantity
+		return nil
+	})
+}
+
+// replaceTarget cancels a working take profit order and places its target again for quantity.
+// A target that traded while it was being cancelled is kept as it is.
+func (s *executionService) replaceTarget(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, target *models.Order, quantity float64) {
+	if err := client.CancelOrder(ctx, target.Symbol, target.ExchangeOrderID); err != nil && !errors.Is(err, exchange.ErrOrderNotFound) {
+		slog.Warn("Failed to cancel target for resizing", "order_id", target.ID, "error", err)
+		return
+	}
+	current, err := client.GetOrder(ctx, target.Symbol, target.ExchangeOrderID)
+	if err == nil {
+		target.CancelReason = fmt.Sprintf("resized to %v", quantity)
+		err = s.updateOrder(ctx, client, target, current)
+	}
+	if err != nil {
+		slog.Warn("Failed to refresh cancelled target", "order_id", target.ID, "error", err)
+		return
+	}
+	if target.Status != models.OrderStatusCancelled || target.FilledQuantity > 0 {
+		return
+	}
+
+	resized := &models.Order{
+		UserID:     target.UserID,
+		SignalID:   target.SignalID,
+		PlatformID: target.PlatformID,
+		PositionID: &pos.ID,
+		Purpose:    target.Purpose,
+		Leg:        target.Leg,
+		Revision:   target.Revision + 1,
+		Symbol:     target.Symbol,
+		Side:       target.Side,
+		Type:       target.Type,
+		Price:      target.Price,
+		Quantity:   quantity,
+		ReduceOnly: true,
+		Status:     models.OrderStatusPending,
+	}
+	resized.ClientOrderID = execution.ClientOrderID(resized.SignalID, pos.ID, resized.Purpose, resized.Leg, resized.Revision)
+	if err := s.orderRepo.CreateOrder(ctx, resized); err != nil {
+		slog.Error("Failed to record resized target", "position_id", pos.ID, "error", err)
+		return
+	}
+	s.submit(ctx, client, resized)
+}
+
+// followExit records the quantity an exit order filled since it was last seen and, once the
+// position has nothing left, cancels its remaining orders
+func (s *executionService) followExit(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, exit *models.Order, prevFilled float64) {
+	delta := exit.FilledQuantity - prevFilled
+	if delta <= 0 {
+		return
+	}
+
+	trigger := models.PositionTriggerTakeProfit
+	switch exit.Purpose {
+	case models.OrderPurposeStopLoss:
+		trigger = models.PositionTriggerStopLoss
+	case models.OrderPurposeClose:
+		trigger = models.PositionTriggerCommand
+	}
+	stop := exit.Purpose == models.OrderPurposeStopLoss
+	targetHit := exit.Purpose == models.OrderPurposeTakeProfit && exit.Status == models.OrderStatusFilled
+	s.transition(ctx, pos, trigger, fmt.Sprintf("%s leg %d closed %v at %v", exit.Purpose, exit.Leg, delta, exit.AvgFillPrice), func(pos *models.Position) error {
+		if targetHit {
+			pos.TargetsHit = max(pos.TargetsHit, exit.Leg)
+		}
+		return execution.ApplyExitFill(pos, delta, exit.AvgFillPrice, stop)
+	})
+
+	switch {
+	case execution.IsTerminal(pos.Status):
+		s.closeOut(ctx, client, pos.ID, exit.ID, fmt.Sprintf("position %s", pos.Status))
+	case targetHit:
+		if exit.Leg == 1 {
+			if err := s.cancelEntries(ctx, client, pos, "TP1 was hit"); err != nil {
+				slog.Warn("Failed to cancel entries after TP1", "position_id", pos.ID, "error", err)
+			}
+		}
+		s.applyStopRules(ctx, client, pos, exit)
+	}
+}
+
+// cancelEntries cancels the entries of a position that are still waiting, e.g. once its first
+// target is hit so the position is not added to on the way back, recording reason on them.
+// Cancelling them resizes the stop loss and targets to what filled, or cancels a position that
+// has no fill; pos is reloaded afterwards.
+func (s *executionService) cancelEntries(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, reason string) error {
+	orders, err := s.orderRepo.FindByPosition(ctx, pos.ID)
+	if err != nil {
+		return err
+	}
+
+	var pending, working []*models.Order
+	for _, order := range orders {
+		if order.Purpose != models.OrderPurposeEntry {
+			continue
+		}
+		switch order.Status {
+		case models.OrderStatusPending:
+			pending = append(pending, order)
+		case models.OrderStatusNew, models.OrderStatusPartiallyFilled:
+			working = append(working, order)
+		}
+	}
+	if len(working) == 0 && len(pending) == 0 {
+		return nil
+	}
+
+	s.cancelPending(ctx, pending, reason)
+	var errs []error
+	for _, order := range working {
+		if err := client.CancelOrder(ctx, order.Symbol, order.ExchangeOrderID); err != nil && !errors.Is(err, exchange.ErrOrderNotFound) {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.ID, err))
+			continue
+		}
+		order.CancelReason = reason
+		if err := s.SyncOrder(ctx, order); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.ID, err))
+		}
+	}
+	if len(working) == 0 {
+		// entries never sent have no exchange update to move the position along
+		if err := s.follow(ctx, client, pending[0], 0); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	fresh, err := s.positionRepo.FindByIDTyped(ctx, pos.ID)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	*pos = *fresh
+	return errors.Join(errs...)
+}
+
+// applyStopRules moves the stop of a position after one of its take profit targets filled,
+// following the rules it was opened with
+func (s *executionService) applyStopRules(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, target *models.Order) {
+	orders, err := s.orderRepo.FindByPosition(ctx, pos.ID)
+	if err != nil {
+		slog.Error("Failed to load position orders", "position_id", pos.ID, "error", err)
+		return
+	}
+
+	var targets []float64
+	for _, order := range orders {
+		if order.Purpose == models.OrderPurposeTakeProfit {
+			targets = append(targets, order.Price)
+		}
+	}
+
+	stop, moved := execution.StopAfterTarget(pos, targets, target.Leg)
+	detail := fmt.Sprintf("TP%d filled", target.Leg)
+	if pos.StopRules.TrailingStopPercentage > 0 {
+		peak, trailed, ok := execution.TrailingStop(pos, target.AvgFillPrice)
+		pos.PeakPrice = peak
+		if ok && (!moved || execution.ImprovesStop(pos.Side, stop, trailed)) {
+			stop, moved = trailed, true
+			detail = fmt.Sprintf("TP%d filled, trailing %v%% below %v", target.Leg, pos.StopRules.TrailingStopPercentage, peak)
+		}
+	}
+	if moved {
+		s.moveStop(ctx, client, pos, orders, stop, detail)
+	}
+}
+
+// moveStop replaces the working stop loss of a position with one at price sized to what remains.
+// The new stop is placed before the old one is cancelled so the position is never unprotected;
+// when it cannot be placed the previous stop is kept and an error returned.
+func (s *executionService) moveStop(ctx context.Context, client exchange.ExchangeClient, pos *models.Position, orders []*models.Order, price float64, detail string) error {
+	leg := 0
+	var previous []*models.Order
+	for _, order := range orders {
+		if order.Purpose != models.OrderPurposeStopLoss {
+			continue
+		}
+		leg = max(leg, order.Leg)
+		if order.Status == models.OrderStatusPending || order.Status == models.OrderStatusNew || order.Status == models.OrderStatusPartiallyFilled {
+			previous = append(previous, order)
+		}
+	}
+
+	stop := &models.Order{
+		UserID:     pos.UserID,
+		SignalID:   pos.SignalID,
+		PlatformID: pos.PlatformID,
+		PositionID: &pos.ID,
+		Purpose:    models.OrderPurposeStopLoss,
+		Leg:        leg + 1,
+		Symbol:     pos.Symbol,
+		Side:       string(execution.ExitSide(pos.Side)),
+		Type:       string(execution.OrderTypeStopMarket),
+		Price:      price,
+		Quantity:   pos.Remaining(),
+		ReduceOnly: true,
+		Status:     models.OrderStatusPending,
+	}
+	stop.ClientOrderID = execution.ClientOrderID(stop.SignalID, pos.ID, stop.Purpose, stop.Leg, stop.Revision)
+	if err := s.orderRepo.CreateOrder(ctx, stop); err != nil {
+		slog.Error("Failed to record moved stop", "position_id", pos.ID, "error", err)
+		return err
+	}
+	s.submit(ctx, client, stop)
+	if stop.Status == models.OrderStatusRejected || stop.Status == models.OrderStatusPending {
+		slog.Warn("Moved stop not placed, keeping the previous stop", "position_id", pos.ID, "price", price, "error", stop.Error)
+		return fmt.Errorf("stop at %v not placed: %s", price, stop.Error)
+	}
+	price = stop.Price
+
+	var pending []*models.Order
+	for _, order := range previous {
+		if order.Status == models.OrderStatusPending {
+			pending = append(pending, order)
+		} else {
+			s.cancelWorking(ctx, client, order, fmt.Sprintf("stop moved to %v", price))
+		}
+	}
+	s.cancelPending(ctx, pending, fmt.Sprintf("stop moved to %v", price))
+
+	event := fmt.Sprintf("stop moved from %v to %v: %s", pos.StopPrice, price, detail)
+	if price == pos.StopPrice {
+		event = fmt.Sprintf("stop at %v resized to %v: %s", price, stop.Quantity, detail)
+	}
+	s.transition(ctx, pos, models.PositionTriggerStopMoved, event, func(pos *models.Position) error {
+		pos.StopPrice = price
+		return nil
+	})
+	return nil
+}
+
+func (s *executionService) TrailStops(ctx context.Context) error {
+	positions, err := s.positionRepo.FindTrailing(ctx, executionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pos := range positions {
+		if err := s.trailStop(ctx, pos); err != nil {
+			errs = append(errs, fmt.Errorf("position %s: %w", pos.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// trailStop follows the current price with a position's percentage trailing stop
+func (s *executionService) trailStop(ctx context.Context, pos *models.Position) error {
+	platform, err := s.platformRepo.FindByIDTyped(ctx, pos.PlatformID)
+	if err != nil {
+		return err
+	}
+	client, err := s.platformService.Client(platform)
+	if err != nil {
+		return err
+	}
+	price, err := client.Price(ctx, pos.Symbol)
+	if err != nil {
+		return err
+	}
+
+	peak, stop, moved := execution.TrailingStop(pos, price)
+	if !moved {
+		if peak != pos.PeakPrice {
+			return s.positionRepo.UpdatePositionColumns(ctx, pos.ID, &models.Position{PeakPrice: peak}, "peak_price")
+		}
+		return nil
+	}
+
+	orders, err := s.orderRepo.FindByPosition(ctx, pos.ID)
+	if err != nil {
+		return err
+	}
+	pos.PeakPrice = peak
+	return s.moveStop(ctx, client, pos, orders, stop, fmt.Sprintf("trailing %v%% below %v", pos.StopRules.TrailingStopPercentage, peak))
+}
+
+// closeOut cancels every working or pending order of a finished position except keep, recording
+// reason on them
+func (s *executionService) closeOut(ctx context.Context, client exchange.ExchangeClient, positionID, keep uuid.UUID, reason string) {
+	orders, err := s.orderRepo.FindByPosition(ctx, positionID)
+	if err != nil {
+		slog.Error("Failed to load position orders", "position_id", positionID, "error", err)
+		return
+	}
+
+	var pending []*models.Order
+	for _, order := range orders {
+		switch {
+		case order.ID == keep:
+		case order.Status == models.OrderStatusPending:
+			pending = append(pending, order)
+		case order.Status == models.OrderStatusNew || order.Status == models.OrderStatusPartiallyFilled:
+			s.cancelWorking(ctx, client, order, reason)
+		}
+	}
+	s.cancelPending(ctx, pending, reason)
+}
+
+// cancelWorking cancels an order resting on the exchange and records it as cancelled with reason
+func (s *executionService) cancelWorking(ctx context.Context, client exchange.ExchangeClient, order *models.Order, reason string) {
+	if err := client.CancelOrder(ctx, order.Symbol, order.ExchangeOrderID); err != nil && !errors.Is(err, exchange.ErrOrderNotFound) {
+		slog.Warn("Failed to cancel order", "order_id", order.ID, "error", err)
+		return
+	}
+	order.Status, order.CancelReason = models.OrderStatusCancelled, reason
+	if err := s.orderRepo.UpdateOrderColumns(ctx, order.ID, order, "status", "cancel_reason"); err != nil {
+		slog.Error("Failed to record cancelled order", "order_id", order.ID, "error", err)
+	}
+}
+
+// transition applies change to a position and persists the result with an event recording the
+// trigger. Changes the lifecycle rejects leave the position untouched. Only the columns the change
+// touched are written, and only while the position keeps the status it had, so concurrent workers
+// do not overwrite each other's updates.
+func (s *executionService) transition(ctx context.Context, pos *models.Position, trigger models.PositionTrigger, detail string, change func(*models.Position) error) {
+	before := *pos
+	if err := change(pos); err != nil {
+		*pos = before
+		slog.Warn("Position change rejected", "position_id", pos.ID, "trigger", trigger, "error", err)
+		return
+	}
+
+	event := &models.PositionEvent{
+		FromStatus: before.Status,
+		ToStatus:   pos.Status,
+		Trigger:    trigger,
+		Detail:     detail,
+	}
+	saved, err := s.positionRepo.SaveTransition(ctx, pos, before.Status, event, execution.ChangedColumns(&before, pos)...)
+	switch {
+	case err != nil:
+		slog.Error("Failed to save position", "position_id", pos.ID, "error", err)
+	case !saved:
+		// another worker moved the position on; continue from what it stored rather than overwrite it
+		slog.Warn("Position changed concurrently, change dropped", "position_id", pos.ID, "trigger", trigger, "detail", detail)
+		if stored, err := s.positionRepo.FindByIDTyped(ctx, pos.ID); err == nil {
+			*pos = *stored
+		} else {
+			*pos = before
+		}
+	}
+}
+
+// submit places a recorded order and stores the exchange's answer on it. The order's client
+// order ID and submission time are stored before it is sent, and an order sent before is looked
+// up by that ID first, so a retry or restart never places the same leg twice. When the outcome
+// cannot be told, the order stays pending for ResolveUnconfirmed.
+func (s *executionService) submit(ctx context.Context, client exchange.ExchangeClient, order *models.Order) {
+	resend := order.SubmittedAt != nil
+	now := time.Now()
+	order.SubmittedAt = &now
+	if order.ClientOrderID == "" && order.PositionID != nil {
+		order.ClientOrderID = execution.ClientOrderID(order.SignalID, *order.PositionID, order.Purpose, order.Leg, order.Revision)
+	}
+	if err := s.orderRepo.UpdateOrderColumns(ctx, order.ID, order, "client_order_id", "submitted_at"); err != nil {
+		slog.Error("Failed to record order before sending", "order_id", order.ID, "error", err)
+		return
+	}
+
+	var placed *exchange.Order
+	var err error
+	unknown := false
+	if resend {
+		placed, err = s.lookupSent(ctx, client, order)
+		unknown = err != nil
+	}
+	if placed == nil && err == nil {
+		err = s.roundOrder(ctx, client, order)
+		if err == nil {
+			placed, err = client.PlaceOrder(ctx, exchange.OrderRequest{
+				Symbol:        order.Symbol,
+				Side:          exchange.Side(order.Side),
+				Type:          exchange.OrderType(order.Type),
+				Quantity:      order.Quantity,
+				Price:         order.Price,
+				ReduceOnly:    order.ReduceOnly,
+				ClientOrderID: order.ClientOrderID,
+			})
+		}
+		unknown = errors.Is(err, exchange.ErrDuplicateClientOrderID) || exchange.OutcomeUnknown(err)
+		if unknown {
+			if found, lookupErr := s.lookupSent(ctx, client, order); lookupErr == nil && found != nil {
+				placed, err = found, nil
+			}
+		}
+	}
+
+	switch {
+	case err == nil:
+		order.ExchangeOrderID = placed.OrderID
+		order.Error = ""
+		applyExchangeOrder(order, placed)
+	case unknown:
+		order.Error = err.Error()
+		slog.Warn("Order outcome unknown, checking again later", "order_id", order.ID, "client_order_id", order.ClientOrderID, "error", err)
+	default:
+		order.Status = models.OrderStatusRejected
+		order.Error = err.Error()
+		slog.Warn("Order rejected", "order_id", order.ID, "symbol", order.Symbol, "purpose", order.Purpose, "error", err)
+		// the exchange may have changed the symbol's rules since they were cached
+		var apiErr *exchange.APIError
+		if errors.As(err, &apiErr) {
+			s.symbolInfo.Invalidate(ctx, client, order.Symbol)
+		}
+	}
+
+	if err := s.orderRepo.UpdateOrderColumns(ctx, order.ID, order,
+		"status", "error", "exchange_order_id", "filled_quantity", "avg_fill_price", "price", "quantity"); err != nil {
+		slog.Error("Failed to record order", "order_id", order.ID, "error", err)
+	}
+}
+
+// lookupSent looks an order that may have reached the exchange up by its client order ID; nil
+// when the exchange never received it
+func (s *executionService) lookupSent(ctx context.Context, client exchange.ExchangeClient, order *models.Order) (*exchange.Order, error) {
+	found, err := client.GetOrderByClientID(ctx, order.Symbol, order.ClientOrderID)
+	if errors.Is(err, exchange.ErrOrderNotFound) {
+		return nil, nil
+	}
+	return found, err
+}
+
+// roundOrder rounds an order's price and quantity to the symbol's trading rules and rejects it
+// without calling the exchange when it is below the minimum. When the rules cannot be loaded the
+// order is sent as it is and the exchange validates it.
+func (s *executionService) roundOrder(ctx context.Context, client exchange.ExchangeClient, order *models.Order) error {
+	info, err := s.symbolInfo.Info(ctx, client, order.Symbol)
+	if err != nil {
+		slog.Warn("Failed to load symbol info, submitting order unrounded", "order_id", order.ID, "symbol", order.Symbol, "error", err)
+		return nil
+	}
+	order.Price = execution.RoundToTick(order.Price, info.TickSize)
+	order.Quantity = execution.RoundDown(order.Quantity, info.StepSize)
+	return execution.CheckMinimums(info, order.Quantity, order.Price, order.ReduceOnly)
+}
+
+// cancelPending marks recorded orders that were never sent as cancelled with reason. Orders sent
+// without a confirmed outcome are left to ResolveUnconfirmed, which cancels them on the exchange.
+func (s *executionService) cancelPending(ctx context.Context, orders []*models.Order, reason string) {
+	for _, order := range orders {
+		if order.Status != models.OrderStatusPending || order.SubmittedAt != nil {
+			continue
+		}
+		order.Status, order.CancelReason = models.OrderStatusCancelled, reason
+		if err := s.orderRepo.UpdateOrderColumns(ctx, order.ID, order, "status", "cancel_reason"); err != nil {
+			slog.Error("Failed to cancel pending order", "order_id", order.ID, "error", err)
+		}
+	}
+}
+
+func (s *executionService) ExecuteDue(ctx context.Context) (int, error) {
+	signals, err := s.signalRepo.FindDueForExecution(ctx, time.Now(), executionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, sig := range signals {
+		if err := s.ExecuteSignal(ctx, sig); err != nil {
+			errs = append(errs, fmt.Errorf("signal %s: %w", sig.ID, err))
+		}
+	}
+
+	return len(signals), errors.Join(errs...)
+}
+
+func (s *executionService) SyncOrders(ctx context.Context) error {
+	orders, err := s.orderRepo.FindWorking(ctx, executionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, order := range orders {
+		if err := s.SyncOrder(ctx, order); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *executionService) SyncOrder(ctx context.Context, order *models.Order) error {
+	client, err := s.clientFor(ctx, order.PlatformID)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.GetOrder(ctx, order.Symbol, order.ExchangeOrderID)
+	if err != nil {
+		return err
+	}
+	return s.updateOrder(ctx, client, order, current)
+}
+
+func (s *executionService) MarkOrderMissing(ctx context.Context, order *models.Order, reason string) error {
+	client, err := s.clientFor(ctx, order.PlatformID)
+	if err != nil {
+		return err
+	}
+
+	order.Error = reason
+	return s.updateOrder(ctx, client, order, &exchange.Order{
+		Status:      exchange.OrderStatusCanceled,
+		ExecutedQty: order.FilledQuantity,
+		AvgPrice:    order.AvgFillPrice,
+	})
+}
+
+func (s *executionService) SettleExternalClose(ctx context.Context, pos *models.Position, price float64, reason string) error {
+	client, err := s.clientFor(ctx, pos.PlatformID)
+	if err != nil {
+		return err
+	}
+
+	s.transition(ctx, pos, models.PositionTriggerReconcile, reason, func(pos *models.Position) error {
+		pos.CloseReason = reason
+		return execution.ApplyExitFill(pos, pos.Remaining(), price, false)
+	})
+	if !execution.IsTerminal(pos.Status) {
+		return fmt.Errorf("position %s could not be closed from %s", pos.ID, pos.Status)
+	}
+	s.closeOut(ctx, client, pos.ID, uuid.Nil, reason)
+	return nil
+}
+
+func (s *executionService) ResolveUnconfirmed(ctx context.Context) error {
+	orders, err := s.orderRepo.FindUnconfirmed(ctx, time.Now().Add(-unconfirmedOrderGrace), executionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, order := range orders {
+		if err := s.resolveOrder(ctx, order); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// resolveOrder settles one order sent without a confirmed outcome. Orders of positions that have
+// ended since are cancelled wherever they are; the others are looked up and resent if missing.
+func (s *executionService) resolveOrder(ctx context.Context, order *models.Order) error {
+	client, err := s.clientFor(ctx, order.PlatformID)
+	if err != nil {
+		return err
+	}
+
+	var pos *models.Position
+	if order.PositionID != nil {
+		if pos, err = s.positionRepo.FindByIDTyped(ctx, *order.PositionID); err != nil {
+			return err
+		}
+	}
+	if pos == nil || !execution.IsTerminal(pos.Status) {
+		s.submit(ctx, client, order)
+		if order.Status == models.OrderStatusPending {
+			return nil
+		}
+		return s.follow(ctx, client, order, 0)
+	}
+
+	found, err := s.lookupSent(ctx, client, order)
+	if err != nil {
+		return err
+	}
+	if found == nil {
+		order.Status = models.OrderStatusCancelled
+		return s.orderRepo.UpdateOrderColumns(ctx, order.ID, order, "status")
+	}
+	if found.Status.Open() {
+		if err := client.CancelOrder(ctx, order.Symbol, found.OrderID); err != nil && !errors.Is(err, exchange.ErrOrderNotFound) {
+			return err
+		}
+		if found, err = client.GetOrder(ctx, order.Symbol, found.OrderID); err != nil {
+			return err
+		}
+	}
+	order.ExchangeOrderID = found.OrderID
+	return s.updateOrder(ctx, client, order, found)
+}
+
+// clientFor returns the exchange client of a platform
+func (s *executionService) clientFor(ctx context.Context, platformID uuid.UUID) (exchange.ExchangeClient, error) {
+	platform, err := s.platformRepo.FindByIDTyped(ctx, platformID)
+	if err != nil {
+		return nil, err
+	}
+	return s.platformService.Client(platform)
+}
+
+// updateOrder stores the state the exchange reports for a resting order and follows up on its position
+func (s *executionService) updateOrder(ctx context.Context, client exchange.ExchangeClient, order *models.Order, current *exchange.Order) error {
+	prevFilled := order.FilledQuantity
+	applyExchangeOrder(order, current)
+	if err := s.orderRepo.UpdateOrderColumns(ctx, order.ID, order, "status", "exchange_order_id", "filled_quantity", "avg_fill_price", "error", "cancel_reason"); err != nil {
+		return err
+	}
+	return s.follow(ctx, client, order, prevFilled)
+}
+
+// follow moves the position of an order along after the order changed
+func (s *executionService) follow(ctx context.Context, client exchange.ExchangeClient, order *models.Order, prevFilled float64) error {
+	if order.PositionID == nil {
+		return nil
+	}
+
+	pos, err := s.positionRepo.FindByIDTyped(ctx, *order.PositionID)
+	if err != nil {
+		return err
+	}
+	if order.Purpose != models.OrderPurposeEntry {
+		s.followExit(ctx, client, pos, order, prevFilled)
+		return nil
+	}
+
+	var entries, protection []*models.Order
+	for i := range pos.Orders {
+		switch {
+		case pos.Orders[i].ID == order.ID:
+			entries = append(entries, order)
+		case pos.Orders[i].Purpose == models.OrderPurposeEntry:
+			entries = append(entries, &pos.Orders[i])
+		case pos.Orders[i].Purpose == models.OrderPurposeStopLoss || pos.Orders[i].Purpose == models.OrderPurposeTakeProfit:
+			protection = append(protection, &pos.Orders[i])
+		}
+	}
+	s.followEntry(ctx, client, pos, order, entries, protection)
+	return nil
+}
+
+func (s *executionService) ApplyActions(ctx context.Context) error {
+	actions, err := s.signalRepo.FindPendingActions(ctx, positionActions, executionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, action := range actions {
+		status, note := s.applyAction(ctx, action)
+		now := time.Now()
+		update := &models.SignalAction{Status: status, Note: note, AppliedAt: &now}
+		if err := s.signalRepo.UpdateActionColumns(ctx, action.ID, update, "status", "note", "applied_at"); err != nil {
+			errs = append(errs, fmt.Errorf("action %s: %w", action.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyAction carries out a management command on every active position of its signal
+func (s *executionService) applyAction(ctx context.Context, action *models.SignalAction) (models.SignalActionStatus, string) {
+	positions, err := s.positionRepo.FindActiveBySignal(ctx, action.SignalID)
+	if err != nil {
+		return models.SignalActionStatusFailed, err.Error()
+	}
+	if len(positions) == 0 {
+		return models.SignalActionStatusSkipped, "signal has no active positions"
+	}
+
+	var failures []string
+	for _, pos := range positions {
+		if err := s.applyToPosition(ctx, action, pos); err != nil {
+			failures = append(failures, fmt.Sprintf("position %s: %v", pos.ID, err))
+		}
+	}
+	if len(failures) > 0 {
+		return models.SignalActionStatusFailed, strings.Join(failures, "; ")
+	}
+	return models.SignalActionStatusApplied, action.Note
+}
+
+// applyToPosition cancels a position's waiting entries, moves its stop for move stop commands
+// and, for close commands, reduces what it holds with a market order
+func (s *executionService) applyToPosition(ctx context.Context, action *models.SignalAction, pos *models.Position) error {
+	reason := fmt.Sprintf("%s command", action.Type)
+	switch action.Type {
+	case models.SignalActionCancelPending:
+		return s.CancelEntries(ctx, pos, reason)
+	case models.SignalActionMoveStop:
+		return s.moveStopTo(ctx, action, pos, reason)
+	}
+
+	fraction := 1.0
+	if action.Type == models.SignalActionClosePartial && action.Percent != nil {
+		fraction = *action.Percent / 100
+	}
+	return s.exitPosition(ctx, pos, fraction, reason)
+}
+
+// moveStopTo moves a position's stop loss where a move stop command asks. Break-even moves go to
+// the position's own entry fill; other prices are scaled to the position's contract like the
+// signal was. A stop still waiting for its entry to fill is repriced before it is placed.
+func (s *executionService) moveStopTo(ctx context.Context, action *models.SignalAction, pos *models.Position, reason string) error {
+	if action.StopPrice == nil {
+		return errors.New("command has no stop price")
+	}
+	platform, err := s.platformRepo.FindByIDTyped(ctx, pos.PlatformID)
+	if err != nil {
+		return err
+	}
+	client, err := s.platformService.Client(platform)
+	if err != nil {
+		return err
+	}
+
+	price := *action.StopPrice
+	if action.BreakEven && pos.EntryPrice > 0 {
+		price = pos.EntryPrice
+	} else {
+		sig, err := s.signalRepo.FindByIDTyped(ctx, pos.SignalID)
+		if err != nil {
+			return err
+		}
+		resolved, err := s.symbolService.Resolve(ctx, platform.Exchange, sig.Symbol)
+		if err != nil {
+			return err
+		}
+		if resolved.Multiplier > 0 {
+			price *= resolved.Multiplier
+		}
+	}
+
+	orders, err := s.orderRepo.FindByPosition(ctx, pos.ID)
+	if err != nil {
+		return err
+	}
+	if pos.Remaining() > 0 {
+		return s.moveStop(ctx, client, pos, orders, price, reason)
+	}
+
+	for _, order := range orders {
+		if order.Purpose != models.OrderPurposeStopLoss || order.Status != models.OrderStatusPending || order.SubmittedAt != nil {
+			continue
+		}
+		order.Price = price
+		if err := s.orderRepo.UpdateOrderColumns(ctx, order.ID, order, "price"); err != nil {
+			return err
+		}
+	}
+	s.transition(ctx, pos, models.PositionTriggerStopMoved, fmt.Sprintf("waiting stop moved from %v to %v: %s", pos.StopPrice, price, reason), func(pos *models.Position) error {
+		pos.StopPrice = price
+		return nil
+	})
+	return nil
+}
+
+func (s *executionService) CancelEntries(ctx context.Context, pos *models.Position, reason string) error {
+	client, err := s.clientFor(ctx, pos.PlatformID)
+	if err != nil {
+		return err
+	}
+	return s.cancelEntries(ctx, client, pos, reason)
+}
+
+func (s *executionService) ClosePosition(ctx context.Context, pos *models.Position, reason string) error {
+	return s.exitPosition(ctx, pos, 1, reason)
+}
+
+// exitPosition cancels a position's waiting entries and closes fraction of what it holds with a
+// market order. Closing everything records reason as why the position closed.
+func (s *executionService) exitPosition(ctx context.Context, pos *models.Position, fraction float64, reason string) error {
+	client, err := s.clientFor(ctx, pos.PlatformID)
+	if err != nil {
+		return err
+	}
+
+	// stop the entry first so the position settles on what has filled so far
+	if err := s.cancelEntries(ctx, client, pos, reason); err != nil {
+		return err
+	}
+	quantity := pos.Remaining() * fraction
+	if quantity <= 0 || execution.IsTerminal(pos.Status) {
+		return nil
+	}
+
+	orders, err := s.orderRepo.FindByPosition(ctx, pos.ID)
+	if err != nil {
+		return err
+	}
+	// every close gets its own leg so its client order ID differs from earlier closes
+	leg := 1
+	for _, order := range orders {
+		if order.Purpose == models.OrderPurposeClose {
+			leg = max(leg, order.Leg+1)
+		}
+	}
+
+	closing := &models.Order{
+		UserID:     pos.UserID,
+		SignalID:   pos.SignalID,
+		PlatformID: pos.PlatformID,
+		PositionID: &pos.ID,
+		Purpose:    models.OrderPurposeClose,
+		Leg:        leg,
+		Symbol:     pos.Symbol,
+		Side:       string(execution.ExitSide(pos.Side)),
+		Type:       string(execution.OrderTypeMarket),
+		Quantity:   quantity,
+		ReduceOnly: true,
+		Status:     models.OrderStatusPending,
+	}
+	closing.ClientOrderID = execution.ClientOrderID(closing.SignalID, pos.ID, closing.Purpose, closing.Leg, closing.Revision)
+	if err := s.orderRepo.CreateOrder(ctx, closing); err != nil {
+		return err
+	}
+	s.submit(ctx, client, closing)
+	if closing.Status == models.OrderStatusRejected {
+		return errors.New(closing.Error)
+	}
+	if fraction >= 1 {
+		pos.CloseReason = reason
+	}
+	s.followExit(ctx, client, pos, closing, 0)
+	return nil
+}
+
+func (s *executionService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("Execution worker started", "interval", interval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Execution worker stopped")
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.ExecuteDue(ctx); err != nil {
+			slog.Error("Failed to execute due signals", "error", err)
+		}
+		if err := s.ApplyActions(ctx); err != nil {
+			slog.Error("Failed to apply signal actions", "error", err)
+		}
+		if err := s.ResolveUnconfirmed(ctx); err != nil {
+			slog.Error("Failed to resolve unconfirmed orders", "error", err)
+		}
+		if err := s.SyncOrders(ctx); err != nil {
+			slog.Error("Failed to sync orders", "error", err)
+		}
+		if err := s.TrailStops(ctx); err != nil {
+			slog.Error("Failed to trail stops", "error", err)
+		}
+	}
+}
+
+// applyExchangeOrder copies the state reported by the exchange onto a recorded order
+func applyExchangeOrder(order *models.Order, placed *exchange.Order) {
+	order.Status = orderStatusFromExchange(placed.Status)
+	order.FilledQuantity = placed.ExecutedQty
+	order.AvgFillPrice = placed.AvgPrice
+}
+
+func orderStatusFromExchange(status exchange.OrderStatus) models.OrderStatus {
+	switch status {
+	case exchange.OrderStatusPartiallyFilled:
+		return models.OrderStatusPartiallyFilled
+	case exchange.OrderStatusFilled:
+		return models.OrderStatusFilled
+	case exchange.OrderStatusCanceled:
+		return models.OrderStatusCancelled
+	case exchange.OrderStatusRejected:
+		return models.OrderStatusRejected
+	case exchange.OrderStatusExpired:
+		return models.OrderStatusExpired
+	default:
+		return models.OrderStatusNew
+	}
+}
+
+// availableQuote returns the available balance of the asset symbol is quoted in
+func availableQuote(balances []exchange.Balance, symbol string) float64 {
+	for _, balance := range balances {
+		if balance.Asset != "" && strings.HasSuffix(symbol, balance.Asset) {
+			return balance.Available
+		}
+	}
+	return 0
+}
+
+// marginType maps a user's margin mode onto the exchange's margin type
+func marginType(mode models.MarginMode) exchange.MarginType {
+	if mode == models.MarginModeIsolated {
+		return exchange.MarginTypeIsolated
+	}
+	return exchange.MarginTypeCross
+}
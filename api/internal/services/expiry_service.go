@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+
+	"github.com/google/uuid"
+)
+
+// ExpiryReport summarises one expiry run
+type ExpiryReport struct {
+	// EntriesTimedOut counts positions whose unfilled entries were cancelled after their time-out
+	EntriesTimedOut int `json:"entries_timed_out"`
+	// EntriesPassedTP1 counts positions whose entries were cancelled because the price reached TP1
+	EntriesPassedTP1 int `json:"entries_passed_tp1"`
+	// PositionsClosed counts positions closed after their maximum holding time
+	PositionsClosed int `json:"positions_closed"`
+}
+
+// ExpiryService enforces the expiry rules positions were created with: entries that do not fill
+// in time or whose TP1 is reached first are cancelled, and positions held too long are closed
+type ExpiryService interface {
+	// EnforceExpiry applies every expiry rule that is due
+	EnforceExpiry(ctx context.Context) (*ExpiryReport, error)
+	// RunWorker calls EnforceExpiry every interval until ctx is cancelled
+	RunWorker(ctx context.Context, interval time.Duration)
+}
+
+type expiryService struct {
+	positionRepo     repositories.PositionRepository
+	platformRepo     repositories.PlatformRepository
+	platformService  PlatformService
+	executionService ExecutionService
+}
+
+// NewExpiryService creates a new expiry service instance
+func NewExpiryService(positionRepo repositories.PositionRepository, platformRepo repositories.PlatformRepository, platformService PlatformService, executionService ExecutionService) ExpiryService {
+	return &expiryService{
+		positionRepo:     positionRepo,
+		platformRepo:     platformRepo,
+		platformService:  platformService,
+		executionService: executionService,
+	}
+}
+
+func (s *expiryService) EnforceExpiry(ctx context.Context) (*ExpiryReport, error) {
+	report := &ExpiryReport{}
+	now := time.Now()
+	var errs []error
+
+	expired, err := s.positionRepo.FindEntryExpired(ctx, now, executionBatchSize)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, pos := range expired {
+		reason := fmt.Sprintf("entry not filled within %s", time.Duration(pos.Expiry.EntryTimeoutMinutes)*time.Minute)
+		if err := s.executionService.CancelEntries(ctx, pos, reason); err != nil {
+			errs = append(errs, fmt.Errorf("position %s: %w", pos.ID, err))
+			continue
+		}
+		report.EntriesTimedOut++
+	}
+
+	passed, err := s.cancelPassedTP1(ctx)
+	report.EntriesPassedTP1 = passed
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	held, err := s.positionRepo.FindHoldingExpired(ctx, now, executionBatchSize)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, pos := range held {
+		reason := fmt.Sprintf("held longer than %s", time.Duration(pos.Expiry.MaxHoldingHours)*time.Hour)
+		if err := s.executionService.ClosePosition(ctx, pos, reason); err != nil {
+			errs = append(errs, fmt.Errorf("position %s: %w", pos.ID, err))
+			continue
+		}
+		report.PositionsClosed++
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// cancelPassedTP1 cancels the entries of positions asking for it whose TP1 the price has reached,
+// fetching each platform's price of a symbol once
+func (s *expiryService) cancelPassedTP1(ctx context.Context) (int, error) {
+	positions, err := s.positionRepo.FindAwaitingEntryAtTP1(ctx, executionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	clients := make(map[uuid.UUID]exchange.ExchangeClient)
+	prices := make(map[string]float64)
+	cancelled := 0
+	var errs []error
+	for _, pos := range positions {
+		tp1 := firstTarget(pos)
+		if tp1 == 0 {
+			continue
+		}
+
+		key := pos.PlatformID.String() + "|" + pos.Symbol
+		price, ok := prices[key]
+		if !ok {
+			client, ok := clients[pos.PlatformID]
+			if !ok {
+				platform, err := s.platformRepo.FindByIDTyped(ctx, pos.PlatformID)
+				if err == nil {
+					client, err = s.platformService.Client(platform)
+				}
+				if err != nil {
+					errs = append(errs, fmt.Errorf("platform %s: %w", pos.PlatformID, err))
+					continue
+				}
+				clients[pos.PlatformID] = client
+			}
+			if price, err = client.Price(ctx, pos.Symbol); err != nil {
+				errs = append(errs, fmt.Errorf("price of %s: %w", pos.Symbol, err))
+				continue
+			}
+			prices[key] = price
+		}
+		if !execution.ReachedTarget(pos.Side, tp1, price) {
+			continue
+		}
+
+		reason := fmt.Sprintf("price %v reached TP1 %v before the entry filled", price, tp1)
+		if err := s.executionService.CancelEntries(ctx, pos, reason); err != nil {
+			errs = append(errs, fmt.Errorf("position %s: %w", pos.ID, err))
+			continue
+		}
+		cancelled++
+	}
+
+	return cancelled, errors.Join(errs...)
+}
+
+// firstTarget returns the signal's TP1 stored on a position, which exists whether or not take
+// profit orders were placed. Positions stored without it fall back to their first take profit
+// order, or zero without one.
+func firstTarget(pos *models.Position) float64 {
+	if pos.FirstTarget > 0 {
+		return pos.FirstTarget
+	}
+	for _, order := range pos.Orders {
+		if order.Purpose == models.OrderPurposeTakeProfit && order.Leg == 1 {
+			return order.Price
+		}
+	}
+	return 0
+}
+
+func (s *expiryService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("Expiry worker started", "interval", interval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Expiry worker stopped")
+			return
+		case <-ticker.C:
+		}
+
+		report, err := s.EnforceExpiry(ctx)
+		if err != nil {
+			slog.Error("Failed to enforce expiry rules", "error", err)
+		}
+		if report != nil && report.EntriesTimedOut+report.EntriesPassedTP1+report.PositionsClosed > 0 {
+			slog.Info("Expiry rules enforced", "entries_timed_out", report.EntriesTimedOut, "entries_passed_tp1", report.EntriesPassedTP1, "positions_closed", report.PositionsClosed)
+		}
+	}
+}
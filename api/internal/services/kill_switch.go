@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"copier/internal/shared/exceptions"
+	"copier/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+const killSwitchKey = "copier:trading:halt"
+
+// TradingHalt records who stopped all new executions and why
+type TradingHalt struct {
+	Reason   string    `json:"reason"`
+	HaltedBy uuid.UUID `json:"halted_by"`
+	HaltedAt time.Time `json:"halted_at"`
+}
+
+// KillSwitch is the global halt shared by every API instance through the cache. While it is set no
+// signal is executed; positions already open are still managed.
+type KillSwitch interface {
+	// Halt stops all new executions until Resume is called
+	Halt(ctx context.Context, halt TradingHalt) error
+	// Resume lifts the halt
+	Resume(ctx context.Context) error
+	// Status returns the active halt, or nil when trading is allowed
+	Status(ctx context.Context) (*TradingHalt, error)
+}
+
+type killSwitch struct {
+	cache cache.Cache
+}
+
+// NewKillSwitch creates a kill switch stored in c. With a nil cache trading can never be halted.
+func NewKillSwitch(c cache.Cache) KillSwitch {
+	return &killSwitch{cache: c}
+}
+
+func (k *killSwitch) Halt(ctx context.Context, halt TradingHalt) error {
+	if k.cache == nil {
+		return exceptions.ErrKillSwitchUnavailable
+	}
+	raw, err := json.Marshal(halt)
+	if err != nil {
+		return err
+	}
+	// the halt has no expiry; it holds until it is lifted explicitly
+	if err := k.cache.Set(ctx, killSwitchKey, string(raw), 0); err != nil {
+		return fmt.Errorf("failed to set trading halt: %w", err)
+	}
+	return nil
+}
+
+func (k *killSwitch) Resume(ctx context.Context) error {
+	if k.cache == nil {
+		return exceptions.ErrKillSwitchUnavailable
+	}
+	if err := k.cache.Delete(ctx, killSwitchKey); err != nil {
+		return fmt.Errorf("failed to lift trading halt: %w", err)
+	}
+	return nil
+}
+
+func (k *killSwitch) Status(ctx context.Context) (*TradingHalt, error) {
+	if k.cache == nil {
+		return nil, nil
+	}
+	exists, err := k.cache.Exists(ctx, killSwitchKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check trading halt: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	raw, err := k.cache.Get(ctx, killSwitchKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trading halt: %w", err)
+	}
+	var halt TradingHalt
+	if err := json.Unmarshal([]byte(raw), &halt); err != nil {
+		// an unreadable flag still halts trading
+		return &TradingHalt{Reason: raw}, nil
+	}
+	return &halt, nil
+}
@@ -5,36 +5,42 @@ import (
 
 	"copier/database/repositories"
 	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/shared/exceptions"
 
 	"github.com/google/uuid"
 )
 
 // PlatformService defines platform business logic operations
 type PlatformService interface {
-	CreatePlatform(ctx context.Context, userID uuid.UUID, name, apiKey, apiSecret string) (*models.Platform, error)
+	CreatePlatform(ctx context.Context, platform *models.Platform) (*models.Platform, error)
 	GetPlatformsByUser(ctx context.Context, userID uuid.UUID) ([]*models.Platform, error)
 	GetPlatformByID(ctx context.Context, id uuid.UUID) (*models.Platform, error)
 	UpdatePlatform(ctx context.Context, id uuid.UUID, update *models.Platform) (*models.Platform, error)
 	DeletePlatform(ctx context.Context, id uuid.UUID) error
+	// Client returns an exchange client authenticated with the platform's credentials
+	Client(platform *models.Platform) (exchange.ExchangeClient, error)
+	Balances(ctx context.Context, platform *models.Platform) ([]exchange.Balance, error)
+	// ReplayPrices feeds ticks to a paper platform's simulation and returns its resulting positions
+	ReplayPrices(ctx context.Context, platform *models.Platform, ticks []exchange.Tick) ([]exchange.Position, error)
 }
 
 type platformService struct {
 	platformRepo repositories.PlatformRepository
+	exchanges    *exchange.Factory
 }
 
 // NewPlatformService creates a new platform service instance
-func NewPlatformService(platformRepo repositories.PlatformRepository) PlatformService {
+func NewPlatformService(platformRepo repositories.PlatformRepository, exchanges *exchange.Factory) PlatformService {
 	return &platformService{
 		platformRepo: platformRepo,
+		exchanges:    exchanges,
 	}
 }
 
-func (s *platformService) CreatePlatform(ctx context.Context, userID uuid.UUID, name, apiKey, apiSecret string) (*models.Platform, error) {
-	platform := &models.Platform{
-		UserID:    userID,
-		Name:      name,
-		APIKey:    apiKey,
-		APISecret: apiSecret,
+func (s *platformService) CreatePlatform(ctx context.Context, platform *models.Platform) (*models.Platform, error) {
+	if platform.Exchange == "" {
+		platform.Exchange = models.ExchangeBinanceFutures
 	}
 
 	err := s.platformRepo.CreatePlatform(ctx, platform)
@@ -64,3 +70,24 @@ func (s *platformService) UpdatePlatform(ctx context.Context, id uuid.UUID, upda
 func (s *platformService) DeletePlatform(ctx context.Context, id uuid.UUID) error {
 	return s.platformRepo.DeletePlatform(ctx, id)
 }
+
+func (s *platformService) Client(platform *models.Platform) (exchange.ExchangeClient, error) {
+	return s.exchanges.ForPlatform(platform)
+}
+
+func (s *platformService) Balances(ctx context.Context, platform *models.Platform) ([]exchange.Balance, error) {
+	client, err := s.Client(platform)
+	if err != nil {
+		return nil, err
+	}
+	return client.Balances(ctx)
+}
+
+func (s *platformService) ReplayPrices(ctx context.Context, platform *models.Platform, ticks []exchange.Tick) ([]exchange.Position, error) {
+	if !platform.Paper {
+		return nil, exceptions.ErrNotPaperPlatform
+	}
+	paper := s.exchanges.Paper(platform.ID)
+	paper.Replay(ticks)
+	return paper.Positions(ctx)
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+
+	"github.com/google/uuid"
+)
+
+// PositionService defines position query operations
+type PositionService interface {
+	GetPositionsByUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus, skip, limit int) ([]*models.Position, int64, error)
+	GetPositionByID(ctx context.Context, id uuid.UUID) (*models.Position, error)
+}
+
+type positionService struct {
+	positionRepo repositories.PositionRepository
+}
+
+// NewPositionService creates a new position service instance
+func NewPositionService(positionRepo repositories.PositionRepository) PositionService {
+	return &positionService{
+		positionRepo: positionRepo,
+	}
+}
+
+func (s *positionService) GetPositionsByUser(ctx context.Context, userID uuid.UUID, statuses []models.PositionStatus, skip, limit int) ([]*models.Position, int64, error) {
+	positions, err := s.positionRepo.FindAllByUser(ctx, userID, statuses, skip, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.positionRepo.CountByUser(ctx, userID, statuses)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return positions, total, nil
+}
+
+func (s *positionService) GetPositionByID(ctx context.Context, id uuid.UUID) (*models.Position, error) {
+	return s.positionRepo.FindByIDTyped(ctx, id)
+}
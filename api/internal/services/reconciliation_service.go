@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+)
+
+// reconcileQuantityTolerance absorbs rounding when comparing stored and exchange quantities
+const reconcileQuantityTolerance = 1e-8
+
+// ReconcileReport summarises one reconciliation run
+type ReconcileReport struct {
+	Platforms     int `json:"platforms"`
+	Discrepancies int `json:"discrepancies"`
+	Repaired      int `json:"repaired"`
+	// Failed counts platforms that could not be compared with their exchange
+	Failed int `json:"failed"`
+}
+
+// ReconciliationService compares stored orders and positions with the exchange, repairs what it
+// can and records every discrepancy in the reconciliation audit table
+type ReconciliationService interface {
+	// Reconcile checks every platform holding active positions or working orders
+	Reconcile(ctx context.Context) (*ReconcileReport, error)
+	// ReconcilePlatform checks one platform and returns the discrepancies it found
+	ReconcilePlatform(ctx context.Context, platform *models.Platform) ([]*models.ReconciliationEvent, error)
+	// RunWorker calls Reconcile every interval until ctx is cancelled
+	RunWorker(ctx context.Context, interval time.Duration)
+}
+
+type reconciliationService struct {
+	reconciliationRepo repositories.ReconciliationRepository
+	orderRepo          repositories.OrderRepository
+	positionRepo       repositories.PositionRepository
+	platformRepo       repositories.PlatformRepository
+	platformService    PlatformService
+	executionService   ExecutionService
+}
+
+// NewReconciliationService creates a new reconciliation service instance
+func NewReconciliationService(reconciliationRepo repositories.ReconciliationRepository, orderRepo repositories.OrderRepository, positionRepo repositories.PositionRepository, platformRepo repositories.PlatformRepository, platformService PlatformService, executionService ExecutionService) ReconciliationService {
+	return &reconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		orderRepo:          orderRepo,
+		positionRepo:       positionRepo,
+		platformRepo:       platformRepo,
+		platformService:    platformService,
+		executionService:   executionService,
+	}
+}
+
+func (s *reconciliationService) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	platforms, err := s.platformRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{Platforms: len(platforms)}
+	var errs []error
+	for _, platform := range platforms {
+		events, err := s.ReconcilePlatform(ctx, platform)
+		if err != nil {
+			report.Failed++
+			errs = append(errs, fmt.Errorf("platform %s: %w", platform.ID, err))
+		}
+		report.Discrepancies += len(events)
+		for _, event := range events {
+			if event.Repaired {
+				report.Repaired++
+			}
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+func (s *reconciliationService) ReconcilePlatform(ctx context.Context, platform *models.Platform) ([]*models.ReconciliationEvent, error) {
+	client, err := s.platformService.Client(platform)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &reconcileRun{platform: platform}
+	err = s.reconcileOrders(ctx, client, run)
+	if err == nil {
+		err = s.reconcilePositions(ctx, client, run)
+	}
+
+	for _, event := range run.events {
+		slog.Warn("Reconciliation discrepancy",
+			"platform_id", platform.ID, "kind", event.Kind, "symbol", event.Symbol,
+			"stored", event.Stored, "exchange", event.Exchange, "repaired", event.Repaired, "detail", event.Detail)
+	}
+	if saveErr := s.reconciliationRepo.CreateEvents(ctx, run.events); saveErr != nil {
+		err = errors.Join(err, saveErr)
+	}
+	return run.events, err
+}
+
+// reconcileRun collects the discrepancies of one platform
+type reconcileRun struct {
+	platform *models.Platform
+	events   []*models.ReconciliationEvent
+}
+
+func (r *reconcileRun) record(event *models.ReconciliationEvent) {
+	event.UserID = r.platform.UserID
+	event.PlatformID = r.platform.ID
+	r.events = append(r.events, event)
+}
+
+// reconcileOrders follows stored working orders that changed or disappeared on the exchange, and
+// flags open exchange orders the copier does not track as working
+func (s *reconciliationService) reconcileOrders(ctx context.Context, client exchange.ExchangeClient, run *reconcileRun) error {
+	open, err := client.OpenOrders(ctx, "")
+	if err != nil {
+		return err
+	}
+	openByID := make(map[string]exchange.Order, len(open))
+	for _, order := range open {
+		openByID[order.OrderID] = order
+	}
+
+	working, err := s.orderRepo.FindWorkingByPlatform(ctx, run.platform.ID)
+	if err != nil {
+		return err
+	}
+	tracked := make(map[string]bool, len(working))
+	for _, order := range working {
+		if order.ExchangeOrderID == "" {
+			continue
+		}
+		tracked[order.ExchangeOrderID] = true
+
+		if current, ok := openByID[order.ExchangeOrderID]; ok &&
+			orderStatusFromExchange(current.Status) == order.Status &&
+			math.Abs(current.ExecutedQty-order.FilledQuantity) <= reconcileQuantityTolerance {
+			continue
+		}
+		s.reconcileOrder(ctx, order, run)
+	}
+
+	var untracked []string
+	for id := range openByID {
+		if !tracked[id] {
+			untracked = append(untracked, id)
+		}
+	}
+	stored, err := s.orderRepo.FindByExchangeOrderIDs(ctx, run.platform.ID, untracked)
+	if err != nil {
+		return err
+	}
+	storedByID := make(map[string]*models.Order, len(stored))
+	for _, order := range stored {
+		storedByID[order.ExchangeOrderID] = order
+	}
+
+	for _, id := range untracked {
+		current := openByID[id]
+		order, ok := storedByID[id]
+		if !ok && execution.IsCopierClientOrderID(current.ClientOrderID) {
+			// sent by the copier without a confirmed outcome; the execution worker adopts it
+			continue
+		}
+		event := &models.ReconciliationEvent{
+			Kind:            models.ReconciliationOrphanOrder,
+			Symbol:          current.Symbol,
+			ExchangeOrderID: id,
+			Exchange:        fmt.Sprintf("%s %s %s %v @ %v", current.Status, current.Side, current.Type, current.Quantity, current.Price),
+			Detail:          "open order was not placed by the copier",
+		}
+		if ok {
+			event.Kind = models.ReconciliationOrderReopened
+			event.OrderID = &order.ID
+			event.PositionID = order.PositionID
+			event.Stored = string(order.Status)
+			event.Detail = "order is stored as ended but still rests on the exchange"
+		}
+		run.record(event)
+	}
+
+	return nil
+}
+
+// reconcileOrder brings one stored working order in line with the exchange
+func (s *reconciliationService) reconcileOrder(ctx context.Context, order *models.Order, run *reconcileRun) {
+	event := &models.ReconciliationEvent{
+		Kind:            models.ReconciliationOrderChanged,
+		Symbol:          order.Symbol,
+		OrderID:         &order.ID,
+		PositionID:      order.PositionID,
+		ExchangeOrderID: order.ExchangeOrderID,
+		Stored:          fmt.Sprintf("%s filled %v", order.Status, order.FilledQuantity),
+	}
+
+	status, filled := order.Status, order.FilledQuantity
+	err := s.executionService.SyncOrder(ctx, order)
+	if err == nil && order.Status == status && math.Abs(order.FilledQuantity-filled) <= reconcileQuantityTolerance {
+		// placed after the open orders were listed
+		return
+	}
+	if errors.Is(err, exchange.ErrOrderNotFound) {
+		event.Kind = models.ReconciliationOrderMissing
+		event.Exchange = "not found"
+		err = s.executionService.MarkOrderMissing(ctx, order, "order was not found on the exchange during reconciliation")
+	} else {
+		event.Exchange = fmt.Sprintf("%s filled %v", order.Status, order.FilledQuantity)
+	}
+
+	event.Repaired = err == nil
+	if err != nil {
+		event.Detail = err.Error()
+	}
+	run.record(event)
+}
+
+// reconcilePositions compares the net stored exposure of each symbol with the exchange position.
+// Positions flat on the exchange are closed; other differences are only flagged, because which
+// stored position drifted cannot be told apart. The stored positions are read before the exchange
+// so a fill recorded in between shows as exchange exposure the store lacks, which is only flagged,
+// rather than as a stored position the exchange lacks, which would be closed.
+func (s *reconciliationService) reconcilePositions(ctx context.Context, client exchange.ExchangeClient, run *reconcileRun) error {
+	positions, err := s.positionRepo.FindActiveByPlatform(ctx, run.platform.ID)
+	if err != nil {
+		return err
+	}
+
+	held, err := client.Positions(ctx)
+	if err != nil {
+		return err
+	}
+	actual := make(map[string]float64, len(held))
+	for _, pos := range held {
+		actual[pos.Symbol] = pos.Quantity
+	}
+	bySymbol := make(map[string][]*models.Position)
+	expected := make(map[string]float64)
+	for _, pos := range positions {
+		if pos.Remaining() <= 0 {
+			continue
+		}
+		bySymbol[pos.Symbol] = append(bySymbol[pos.Symbol], pos)
+		if pos.Side == models.SignalSideShort {
+			expected[pos.Symbol] -= pos.Remaining()
+		} else {
+			expected[pos.Symbol] += pos.Remaining()
+		}
+	}
+
+	for symbol, want := range expected {
+		have := actual[symbol]
+		if math.Abs(have-want) <= reconcileQuantityTolerance {
+			continue
+		}
+		if math.Abs(have) <= reconcileQuantityTolerance {
+			s.settleClosed(ctx, client, symbol, bySymbol[symbol], run)
+			continue
+		}
+		run.record(&models.ReconciliationEvent{
+			Kind:     models.ReconciliationPositionDrift,
+			Symbol:   symbol,
+			Stored:   fmt.Sprintf("%v", want),
+			Exchange: fmt.Sprintf("%v", have),
+			Detail:   fmt.Sprintf("%d stored positions hold a different quantity than the exchange", len(bySymbol[symbol])),
+		})
+	}
+
+	for symbol, have := range actual {
+		if _, ok := expected[symbol]; ok || math.Abs(have) <= reconcileQuantityTolerance {
+			continue
+		}
+		run.record(&models.ReconciliationEvent{
+			Kind:     models.ReconciliationUntrackedPosition,
+			Symbol:   symbol,
+			Exchange: fmt.Sprintf("%v", have),
+			Detail:   "exchange position has no stored position",
+		})
+	}
+
+	return nil
+}
+
+// settleClosed closes the stored positions of a symbol that is flat on the exchange at the current
+// price, the best estimate of where they were closed
+func (s *reconciliationService) settleClosed(ctx context.Context, client exchange.ExchangeClient, symbol string, positions []*models.Position, run *reconcileRun) {
+	price, priceErr := client.Price(ctx, symbol)
+
+	for _, pos := range positions {
+		event := &models.ReconciliationEvent{
+			Kind:       models.ReconciliationPositionClosed,
+			Symbol:     symbol,
+			PositionID: &pos.ID,
+			Stored:     fmt.Sprintf("%s %v", pos.Status, pos.Remaining()),
+			Exchange:   "0",
+		}
+
+		exit := price
+		if priceErr != nil {
+			exit = pos.EntryPrice
+			event.Detail = fmt.Sprintf("closed at entry price, current price unavailable: %v", priceErr)
+		}
+		if err := s.executionService.SettleExternalClose(ctx, pos, exit, "position was closed outside the copier"); err != nil {
+			event.Detail = err.Error()
+		} else {
+			event.Repaired = true
+		}
+		run.record(event)
+	}
+}
+
+func (s *reconciliationService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("Reconciliation worker started", "interval", interval)
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Reconciliation worker stopped")
+			return
+		case <-ticker.C:
+		}
+
+		report, err := s.Reconcile(ctx)
+		if err != nil {
+			slog.Error("Failed to reconcile platforms", "error", err)
+		}
+		if report != nil && report.Discrepancies > 0 {
+			slog.Info("Reconciliation finished", "platforms", report.Platforms, "discrepancies", report.Discrepancies, "repaired", report.Repaired)
+		}
+	}
+}
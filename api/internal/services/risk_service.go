@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/execution"
+
+	"github.com/google/uuid"
+)
+
+// RiskService measures a user's positions and realised losses against their risk limits and
+// records the signals it stops from being copied
+type RiskService interface {
+	// Book measures what the user's positions hold and what they realised in the periods holding now
+	Book(ctx context.Context, userID uuid.UUID, now time.Time) (*execution.RiskBook, error)
+	// Budget returns what is left of the user's risk limits
+	Budget(ctx context.Context, userID uuid.UUID) (*execution.RiskBudget, error)
+	// Reject records that violation stopped sig from being copied, on one platform or, with a nil
+	// platformID, on all of them
+	Reject(ctx context.Context, sig *models.Signal, platformID *uuid.UUID, symbol string, violation *execution.RiskViolation)
+	// Rejections lists the user's most recent risk rejections
+	Rejections(ctx context.Context, userID uuid.UUID, limit int) ([]*models.RiskRejection, error)
+}
+
+type riskService struct {
+	riskRepo     repositories.RiskRepository
+	positionRepo repositories.PositionRepository
+	settingsRepo repositories.TradeSettingsRepository
+}
+
+// NewRiskService creates a new risk service instance
+func NewRiskService(riskRepo repositories.RiskRepository, positionRepo repositories.PositionRepository, settingsRepo repositories.TradeSettingsRepository) RiskService {
+	return &riskService{
+		riskRepo:     riskRepo,
+		positionRepo: positionRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+func (s *riskService) Book(ctx context.Context, userID uuid.UUID, now time.Time) (*execution.RiskBook, error) {
+	positions, err := s.positionRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	book := execution.NewRiskBook()
+	for _, pos := range positions {
+		book.Add(pos.Symbol, pos.Side, execution.PositionExposure(pos))
+	}
+
+	day, week := execution.RiskPeriods(now)
+	if book.DailyPnL, err = s.positionRepo.SumRealizedPnL(ctx, userID, day); err != nil {
+		return nil, err
+	}
+	if book.WeeklyPnL, err = s.positionRepo.SumRealizedPnL(ctx, userID, week); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func (s *riskService) Budget(ctx context.Context, userID uuid.UUID) (*execution.RiskBudget, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	book, err := s.Book(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	return execution.BuildRiskBudget(settings.RiskLimits, book, now), nil
+}
+
+func (s *riskService) Reject(ctx context.Context, sig *models.Signal, platformID *uuid.UUID, symbol string, violation *execution.RiskViolation) {
+	rejection := &models.RiskRejection{
+		UserID:     sig.UserID,
+		SignalID:   sig.ID,
+		PlatformID: platformID,
+		Rule:       violation.Rule,
+		Symbol:     symbol,
+		Limit:      violation.Limit,
+		Value:      violation.Value,
+		Detail:     violation.Error(),
+	}
+	if err := s.riskRepo.CreateRejection(ctx, rejection); err != nil {
+		slog.Error("Failed to record risk rejection", "signal_id", sig.ID, "rule", violation.Rule, "error", err)
+		return
+	}
+	slog.Info("Signal rejected by risk guard", "signal_id", sig.ID, "symbol", symbol, "rule", violation.Rule, "limit", violation.Limit, "value", violation.Value)
+}
+
+func (s *riskService) Rejections(ctx context.Context, userID uuid.UUID, limit int) ([]*models.RiskRejection, error) {
+	return s.riskRepo.FindRejectionsByUser(ctx, userID, limit)
+}
@@ -0,0 +1,569 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+	"copier/internal/signal"
+
+	"github.com/google/uuid"
+)
+
+// SignalService defines signal ingestion and query operations
+type SignalService interface {
+	IngestMessage(ctx context.Context, msg signal.Message) ([]*models.Signal, error)
+	IngestForChannel(ctx context.Context, channel *models.Channel, msg signal.Message) (*models.Signal, error)
+	GetSignalsByChannel(ctx context.Context, channelID uuid.UUID, skip, limit int) ([]*models.Signal, int64, error)
+	GetSignalByID(ctx context.Context, id uuid.UUID) (*models.Signal, error)
+	PreviewMessage(ctx context.Context, channel *models.Channel, text string, account execution.Account) (*SignalPreview, error)
+	ImportHistory(ctx context.Context, channel *models.Channel, msgs []signal.Message) (*ImportResult, error)
+}
+
+// SignalPreview is the dry-run result of parsing a message against a channel's parser
+type SignalPreview struct {
+	Signal      *models.Signal           `json:"signal"`
+	MatchedRule string                   `json:"matched_rule"`
+	Warnings    []string                 `json:"warnings"`
+	Orders      []execution.PlannedOrder `json:"orders"`
+}
+
+// ImportResult summarises a channel history import
+type ImportResult struct {
+	Messages   int `json:"messages"`
+	Signals    int `json:"signals"`
+	Suppressed int `json:"suppressed"`
+	Filtered   int `json:"filtered"`
+	FollowUps  int `json:"follow_ups"`
+	Duplicates int `json:"duplicates"`
+	Ignored    int `json:"ignored"`
+}
+
+type signalService struct {
+	signalRepo    repositories.SignalRepository
+	channelRepo   repositories.ChannelRepository
+	settingsRepo  repositories.TradeSettingsRepository
+	symbolService SymbolService
+	dedup         *signal.Deduplicator
+}
+
+// NewSignalService creates a new signal service instance
+func NewSignalService(signalRepo repositories.SignalRepository, channelRepo repositories.ChannelRepository, settingsRepo repositories.TradeSettingsRepository, symbolService SymbolService, dedup *signal.Deduplicator) SignalService {
+	return &signalService{
+		signalRepo:    signalRepo,
+		channelRepo:   channelRepo,
+		settingsRepo:  settingsRepo,
+		symbolService: symbolService,
+		dedup:         dedup,
+	}
+}
+
+// IngestMessage parses a source message once for every user channel following that source,
+// or only for msg.TargetID when the source is bound to a single channel
+func (s *signalService) IngestMessage(ctx context.Context, msg signal.Message) ([]*models.Signal, error) {
+	var channels []*models.Channel
+	if msg.TargetID != uuid.Nil {
+		channel, err := s.channelRepo.FindByIDTyped(ctx, msg.TargetID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", exceptions.ErrChannelNotFound, msg.TargetID)
+		}
+		channels = append(channels, channel)
+	} else {
+		found, err := s.channelRepo.FindAllByChannelID(ctx, msg.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%w: %s", exceptions.ErrChannelNotFound, msg.ChannelID)
+		}
+		channels = found
+	}
+
+	var signals []*models.Signal
+	for _, channel := range channels {
+		sig, err := s.IngestForChannel(ctx, channel, msg)
+		if err != nil {
+			if IsParseError(err) || errors.Is(err, exceptions.ErrDuplicateMessage) {
+				slog.Debug("Message ignored", "channel_id", channel.ID, "message_id", msg.MessageID, "reason", err)
+				continue
+			}
+			return signals, err
+		}
+		signals = append(signals, sig)
+	}
+
+	return signals, nil
+}
+
+// IngestForChannel parses a message and stores the resulting signal next to its channel.
+// Follow-up messages are linked to an open signal by reply ID or symbol and recorded as actions.
+// Redeliveries of a processed message are rejected and repeated signals are stored as suppressed.
+func (s *signalService) IngestForChannel(ctx context.Context, channel *models.Channel, msg signal.Message) (*models.Signal, error) {
+	if msg.PostedAt.IsZero() {
+		msg.PostedAt = time.Now()
+	}
+
+	switch msg.Kind {
+	case signal.MessageKindEdit:
+		// An edit of a message that never produced a signal is handled like a new post
+		if original, err := s.signalRepo.FindByChannelAndMessage(ctx, channel.ID, msg.MessageID); err == nil {
+			return s.applyEdit(ctx, channel, original, msg)
+		}
+	case signal.MessageKindDelete:
+		original, err := s.signalRepo.FindByChannelAndMessage(ctx, channel.ID, msg.MessageID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: message %s", exceptions.ErrSignalNotLinked, msg.MessageID)
+		}
+		return s.applyDelete(ctx, channel, original, msg)
+	}
+
+	if err := s.claimMessage(ctx, channel, msg); err != nil {
+		return nil, err
+	}
+
+	sig, err := s.ingestNew(ctx, channel, msg)
+	if err != nil {
+		// Let a redelivery retry a message that failed, and a later edit of a post that was not
+		// a signal be handled like a new post; a redelivered non-signal simply fails to parse again
+		if releaseErr := s.dedup.ReleaseMessage(ctx, channel.ID, msg.MessageID); releaseErr != nil {
+			slog.Warn("Failed to release message claim", "channel_id", channel.ID, "message_id", msg.MessageID, "error", releaseErr)
+		}
+	}
+	return sig, err
+}
+
+// ingestNew handles a first delivery: a follow-up command for an open signal or a new signal
+func (s *signalService) ingestNew(ctx context.Context, channel *models.Channel, msg signal.Message) (*models.Signal, error) {
+	if msg.ReplyToID != "" {
+		if original, err := s.signalRepo.FindByChannelAndMessage(ctx, channel.ID, msg.ReplyToID); err == nil {
+			if cmd, err := signal.ParseCommand(msg.Text); err == nil {
+				return s.recordCommand(ctx, original, cmd, msg)
+			}
+		}
+	}
+
+	parser, err := s.channelParser(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := parser.Parse(msg.Text)
+	if err != nil {
+		if !IsParseError(err) {
+			return nil, err
+		}
+		cmd, cmdErr := signal.ParseCommand(msg.Text)
+		if cmdErr != nil {
+			return nil, err
+		}
+		table, tableErr := s.symbolService.Table(ctx)
+		if tableErr != nil {
+			return nil, tableErr
+		}
+		if cmd.Symbol != "" {
+			cmd.Symbol, _ = table.Canonical(cmd.Symbol)
+		} else {
+			cmd.Symbol, _ = table.SymbolIn(msg.Text)
+		}
+		if cmd.Symbol == "" {
+			return nil, exceptions.ErrSignalNotLinked
+		}
+		original, findErr := s.signalRepo.FindLatestOpenBySymbol(ctx, channel.ID, cmd.Symbol, msg.Historical)
+		if findErr != nil {
+			return nil, fmt.Errorf("%w: %s", exceptions.ErrSignalNotLinked, cmd.Symbol)
+		}
+		return s.recordCommand(ctx, original, cmd, msg)
+	}
+
+	sig.UserID = channel.UserID
+	sig.ChannelID = channel.ID
+	sig.MessageID = msg.MessageID
+	sig.Status = models.SignalStatusNew
+	sig.Historical = msg.Historical
+	sig.PostedAt = msg.PostedAt
+	sig.Fingerprint = signal.Fingerprint(channel.ID, sig)
+
+	reason, err := s.duplicateReason(ctx, channel, sig, msg)
+	if err != nil {
+		s.releaseContent(ctx, channel, sig, msg)
+		return nil, err
+	}
+	if reason != "" {
+		sig.Status = models.SignalStatusSuppressed
+		sig.StatusReason = reason
+	} else if reason := signal.ApplyFilter(channel.Filter, sig); reason != "" {
+		sig.Status = models.SignalStatusFiltered
+		sig.StatusReason = reason
+	} else {
+		sig.ExecuteAfter = signal.CopyAfter(channel.Filter, sig.PostedAt)
+	}
+
+	if err := s.signalRepo.CreateSignal(ctx, sig); err != nil {
+		s.releaseContent(ctx, channel, sig, msg)
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// releaseContent lets a redelivery of msg claim the fingerprint of a signal that was not stored
+func (s *signalService) releaseContent(ctx context.Context, channel *models.Channel, sig *models.Signal, msg signal.Message) {
+	if msg.Historical {
+		return
+	}
+	if err := s.dedup.ReleaseContent(ctx, sig.Fingerprint, msg.MessageID); err != nil {
+		slog.Warn("Failed to release signal fingerprint claim", "channel_id", channel.ID, "message_id", msg.MessageID, "error", err)
+	}
+}
+
+// channelParser builds the channel's parser chain with symbols resolved through the registry
+func (s *signalService) channelParser(ctx context.Context, channel *models.Channel) (signal.Parser, error) {
+	parser, err := signal.NewChainParser(channel.ParserRules)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := s.symbolService.Table(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return signal.WithSymbols(parser, table), nil
+}
+
+// claimMessage rejects redeliveries of a message the channel has already processed. The shared
+// cache makes the claim atomic across API instances; the database covers claims that have expired.
+func (s *signalService) claimMessage(ctx context.Context, channel *models.Channel, msg signal.Message) error {
+	duplicate := fmt.Errorf("%w: message %s", exceptions.ErrDuplicateMessage, msg.MessageID)
+
+	if !msg.Historical {
+		first, err := s.dedup.ClaimMessage(ctx, channel.ID, msg.MessageID)
+		if err != nil {
+			slog.Warn("Message claim failed, falling back to database", "channel_id", channel.ID, "error", err)
+		} else if !first {
+			return duplicate
+		}
+	}
+
+	seen, err := s.signalRepo.HasMessage(ctx, channel.ID, msg.MessageID)
+	if err != nil {
+		return err
+	}
+	if seen {
+		return duplicate
+	}
+	return nil
+}
+
+// duplicateReason explains why sig repeats a signal already received in the dedup window, or returns ""
+func (s *signalService) duplicateReason(ctx context.Context, channel *models.Channel, sig *models.Signal, msg signal.Message) (string, error) {
+	window := s.dedup.Window()
+
+	if !msg.Historical {
+		prior, first, err := s.dedup.ClaimContent(ctx, sig.Fingerprint, msg.MessageID)
+		if err != nil {
+			slog.Warn("Signal fingerprint claim failed, falling back to database", "channel_id", channel.ID, "error", err)
+		} else if !first {
+			return fmt.Sprintf("duplicate of message %s received within %s", prior, window), nil
+		}
+	}
+
+	prior, err := s.signalRepo.FindRecentByFingerprint(ctx, channel.ID, sig.Fingerprint, msg.PostedAt.Add(-window), msg.PostedAt)
+	if err != nil {
+		return "", err
+	}
+	if prior == nil {
+		return "", nil
+	}
+	return fmt.Sprintf("duplicate of message %s posted at %s", prior.MessageID, prior.PostedAt.Format(time.RFC3339)), nil
+}
+
+// recordCommand turns a follow-up command into actions on the signal's history and updates the signal.
+// Status changes are conditional on the signal's current status, since the execution worker may claim
+// it in the meantime.
+func (s *signalService) recordCommand(ctx context.Context, sig *models.Signal, cmd *signal.Command, msg signal.Message) (*models.Signal, error) {
+	update := &models.Signal{}
+	changed := false
+	var (
+		status models.SignalStatus
+		from   []models.SignalStatus
+	)
+
+	for _, a := range cmd.Actions {
+		action := &models.SignalAction{
+			SignalID:        sig.ID,
+			Type:            a.Type,
+			SourceMessageID: msg.MessageID,
+			Text:            msg.Text,
+			Status:          models.SignalActionStatusPending,
+			Note:            a.Note,
+		}
+		if !isOpen(sig) {
+			action.Status = models.SignalActionStatusSkipped
+			action.Note = fmt.Sprintf("signal is already %s", sig.Status)
+			if err := s.signalRepo.CreateAction(ctx, action); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch a.Type {
+		case models.SignalActionMoveStop:
+			switch {
+			case a.Stop.Price != nil:
+				action.StopPrice = a.Stop.Price
+			case a.Stop.BreakEven:
+				entry := sig.EntryPrice()
+				action.StopPrice = &entry
+				action.BreakEven = true
+			case a.Stop.Target > 0 && a.Stop.Target <= len(sig.Targets):
+				target := sig.Targets[a.Stop.Target-1]
+				action.StopPrice = &target
+			default:
+				action.Status = models.SignalActionStatusSkipped
+				action.Note = fmt.Sprintf("signal has no TP%d to move the stop to", a.Stop.Target)
+			}
+			if action.StopPrice != nil {
+				update.StopLoss = action.StopPrice
+				changed = true
+			}
+		case models.SignalActionClosePartial:
+			pct := a.Percent
+			action.Percent = &pct
+		case models.SignalActionCancelPending:
+			// Only a signal not yet copied is cancelled; the action cancels the entries of one that was
+			status, from = models.SignalStatusCancelled, []models.SignalStatus{models.SignalStatusNew}
+		case models.SignalActionSourceDeleted:
+			now := time.Now()
+			action.Status = models.SignalActionStatusApplied
+			action.AppliedAt = &now
+		case models.SignalActionCloseAll:
+			status, from = models.SignalStatusClosed, models.OpenSignalStatuses
+		case models.SignalActionTargetHit:
+			target := a.Target
+			now := time.Now()
+			action.Target = &target
+			action.Status = models.SignalActionStatusApplied
+			action.AppliedAt = &now
+			action.Note = "informational"
+		}
+
+		if msg.Historical && action.Status == models.SignalActionStatusPending {
+			action.Status = models.SignalActionStatusHistorical
+		}
+
+		if err := s.signalRepo.CreateAction(ctx, action); err != nil {
+			return nil, err
+		}
+	}
+
+	if changed {
+		if err := s.signalRepo.UpdateSignal(ctx, sig.ID, update); err != nil {
+			return nil, err
+		}
+	}
+	if status != "" {
+		if _, err := s.signalRepo.TransitionStatus(ctx, sig.ID, from, status, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.signalRepo.FindByIDTyped(ctx, sig.ID)
+}
+
+// applyEdit re-parses an edited source message, diffs it against the stored signal and records an
+// amendment. Only signals that have not been copied yet are amended, through the channel filter
+// again; orders already placed for a signal are not changed by an edit.
+func (s *signalService) applyEdit(ctx context.Context, channel *models.Channel, sig *models.Signal, msg signal.Message) (*models.Signal, error) {
+	parser, err := s.channelParser(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &models.SignalAction{
+		SignalID:        sig.ID,
+		Type:            models.SignalActionAmend,
+		SourceMessageID: msg.MessageID,
+		Text:            msg.Text,
+		Status:          models.SignalActionStatusPending,
+	}
+
+	parsed, err := parser.Parse(msg.Text)
+	switch {
+	case err != nil && !IsParseError(err):
+		return nil, err
+	case err != nil:
+		action.Status = models.SignalActionStatusSkipped
+		action.Note = fmt.Sprintf("edited message no longer parses: %v", err)
+	default:
+		action.Changes = signal.Diff(sig, parsed)
+		if len(action.Changes) == 0 {
+			return sig, nil
+		}
+		if sig.Status != models.SignalStatusNew {
+			action.Status = models.SignalActionStatusSkipped
+			action.Note = fmt.Sprintf("signal is already %s", sig.Status)
+			break
+		}
+
+		parsed.RawText = msg.Text
+		parsed.Fingerprint = signal.Fingerprint(channel.ID, parsed)
+		parsed.Status = models.SignalStatusNew
+		parsed.ExecuteAfter = signal.CopyAfter(channel.Filter, sig.PostedAt)
+		if reason := signal.ApplyFilter(channel.Filter, parsed); reason != "" {
+			parsed.Status = models.SignalStatusFiltered
+			parsed.StatusReason = reason
+			parsed.ExecuteAfter = nil
+		}
+		// the execution worker may claim the signal at any time, so the edit only lands while it is new
+		amended, err := s.signalRepo.AmendSignal(ctx, sig.ID, []models.SignalStatus{models.SignalStatusNew}, parsed,
+			"symbol", "side", "entry_low", "entry_high", "targets", "stop_loss", "leverage", "raw_text", "matched_rule",
+			"fingerprint", "status", "status_reason", "execute_after")
+		if err != nil {
+			return nil, err
+		}
+		if !amended {
+			action.Status = models.SignalActionStatusSkipped
+			action.Note = "signal was copied before the edit arrived"
+			break
+		}
+		now := time.Now()
+		action.Status = models.SignalActionStatusApplied
+		action.AppliedAt = &now
+		if parsed.Status == models.SignalStatusFiltered {
+			action.Note = fmt.Sprintf("edited signal filtered: %s", parsed.StatusReason)
+		}
+	}
+
+	if err := s.signalRepo.CreateAction(ctx, action); err != nil {
+		return nil, err
+	}
+
+	return s.signalRepo.FindByIDTyped(ctx, sig.ID)
+}
+
+// applyDelete applies the channel's delete policy to the signal of a deleted source message
+func (s *signalService) applyDelete(ctx context.Context, channel *models.Channel, sig *models.Signal, msg signal.Message) (*models.Signal, error) {
+	const note = "source message deleted"
+
+	var action signal.Action
+	switch channel.DeletePolicy {
+	case models.DeletePolicyCancelPending:
+		action = signal.Action{Type: models.SignalActionCancelPending, Note: note}
+	case models.DeletePolicyClosePosition:
+		action = signal.Action{Type: models.SignalActionCloseAll, Note: note}
+	default:
+		action = signal.Action{Type: models.SignalActionSourceDeleted, Note: note + "; ignored per channel policy"}
+	}
+
+	return s.recordCommand(ctx, sig, &signal.Command{Actions: []signal.Action{action}}, msg)
+}
+
+// GetSignalsByChannel retrieves a channel's signals with pagination and the total count
+func (s *signalService) GetSignalsByChannel(ctx context.Context, channelID uuid.UUID, skip, limit int) ([]*models.Signal, int64, error) {
+	signals, err := s.signalRepo.FindAllByChannel(ctx, channelID, skip, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.signalRepo.CountSignalsByChannel(ctx, channelID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return signals, total, nil
+}
+
+// GetSignalByID retrieves a single signal record
+func (s *signalService) GetSignalByID(ctx context.Context, id uuid.UUID) (*models.Signal, error) {
+	return s.signalRepo.FindByIDTyped(ctx, id)
+}
+
+// PreviewMessage parses text and derives orders from the channel owner's trade settings without persisting anything.
+// account supplies the balance that balance-based sizing and leverage caps need.
+func (s *signalService) PreviewMessage(ctx context.Context, channel *models.Channel, text string, account execution.Account) (*SignalPreview, error) {
+	parser, err := s.channelParser(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := parser.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sig.UserID = channel.UserID
+	sig.ChannelID = channel.ID
+
+	preview := &SignalPreview{
+		Signal:      sig,
+		MatchedRule: sig.MatchedRule,
+		Warnings:    []string{},
+		Orders:      []execution.PlannedOrder{},
+	}
+	if reason := signal.ApplyFilter(channel.Filter, sig); reason != "" {
+		preview.Warnings = append(preview.Warnings, "signal would be filtered: "+reason)
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, channel.UserID)
+	if err != nil {
+		preview.Warnings = append(preview.Warnings, "no trade settings configured; orders not derived")
+		preview.Warnings = append(preview.Warnings, execution.ValidateSignal(sig)...)
+		return preview, nil
+	}
+
+	plan := execution.PlanOrders(sig, settings, account)
+	preview.Warnings = append(preview.Warnings, plan.Warnings...)
+	preview.Orders = append(preview.Orders, plan.Orders...)
+
+	return preview, nil
+}
+
+// ImportHistory replays past channel messages oldest first, storing historical signals that are never executed.
+// Messages already seen in the channel are skipped so an export can be imported more than once.
+func (s *signalService) ImportHistory(ctx context.Context, channel *models.Channel, msgs []signal.Message) (*ImportResult, error) {
+	result := &ImportResult{Messages: len(msgs)}
+
+	for _, msg := range msgs {
+		msg.Historical = true
+
+		sig, err := s.IngestForChannel(ctx, channel, msg)
+		switch {
+		case err == nil && sig.Status == models.SignalStatusSuppressed && sig.MessageID == msg.MessageID:
+			result.Suppressed++
+		case err == nil && sig.Status == models.SignalStatusFiltered && sig.MessageID == msg.MessageID:
+			result.Filtered++
+		case err == nil && sig.MessageID == msg.MessageID:
+			result.Signals++
+		case err == nil:
+			result.FollowUps++
+		case errors.Is(err, exceptions.ErrDuplicateMessage):
+			result.Duplicates++
+		case IsParseError(err):
+			result.Ignored++
+		default:
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// isOpen reports whether a signal can still be managed by follow-up messages
+func isOpen(sig *models.Signal) bool {
+	return slices.Contains(models.OpenSignalStatuses, sig.Status)
+}
+
+// IsParseError reports whether err means the message simply did not contain a usable signal
+func IsParseError(err error) bool {
+	return errors.Is(err, exceptions.ErrNotASignal) ||
+		errors.Is(err, exceptions.ErrSignalMissingSide) ||
+		errors.Is(err, exceptions.ErrSignalMissingEntry) ||
+		errors.Is(err, exceptions.ErrNotACommand) ||
+		errors.Is(err, exceptions.ErrSignalNotLinked)
+}
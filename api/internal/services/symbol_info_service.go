@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"copier/internal/exchange"
+	"copier/pkg/cache"
+)
+
+const (
+	// DefaultSymbolInfoTTL is how long trading rules are cached when no TTL is configured
+	DefaultSymbolInfoTTL = time.Hour
+
+	symbolInfoKeyPrefix = "copier:symbol-info:"
+)
+
+// SymbolInfoService serves the trading rules of exchange symbols, such as tick size, lot step and
+// minimum notional, from a shared cache
+type SymbolInfoService interface {
+	// Info returns the trading rules of symbol on the client's exchange, loading them through the
+	// client when they are not cached
+	Info(ctx context.Context, client exchange.ExchangeClient, symbol string) (*exchange.SymbolInfo, error)
+	// Invalidate drops the cached rules of symbol, e.g. after the exchange rejected an order for them
+	Invalidate(ctx context.Context, client exchange.ExchangeClient, symbol string)
+}
+
+type symbolInfoService struct {
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewSymbolInfoService creates a new symbol info service instance. With a nil cache the rules are
+// loaded from the exchange on every call.
+func NewSymbolInfoService(c cache.Cache, ttl time.Duration) SymbolInfoService {
+	if ttl <= 0 {
+		ttl = DefaultSymbolInfoTTL
+	}
+	return &symbolInfoService{
+		cache: c,
+		ttl:   ttl,
+	}
+}
+
+func (s *symbolInfoService) Info(ctx context.Context, client exchange.ExchangeClient, symbol string) (*exchange.SymbolInfo, error) {
+	key := symbolInfoKey(client, symbol)
+	if s.cache != nil {
+		if raw, err := s.cache.Get(ctx, key); err == nil {
+			var info exchange.SymbolInfo
+			if err := json.Unmarshal([]byte(raw), &info); err == nil {
+				return &info, nil
+			}
+		}
+	}
+
+	info, err := client.SymbolInfo(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		raw, _ := json.Marshal(info)
+		if err := s.cache.Set(ctx, key, string(raw), s.ttl); err != nil {
+			slog.Warn("Failed to cache symbol info", "symbol", symbol, "error", err)
+		}
+	}
+	return info, nil
+}
+
+func (s *symbolInfoService) Invalidate(ctx context.Context, client exchange.ExchangeClient, symbol string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Delete(ctx, symbolInfoKey(client, symbol)); err != nil {
+		slog.Warn("Failed to invalidate symbol info", "symbol", symbol, "error", err)
+	}
+}
+
+func symbolInfoKey(client exchange.ExchangeClient, symbol string) string {
+	return symbolInfoKeyPrefix + client.Name() + ":" + strings.ToUpper(symbol)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+	"copier/internal/signal"
+
+	"github.com/google/uuid"
+)
+
+// symbolTableTTL bounds how long an instance serves aliases changed through another instance
+const symbolTableTTL = time.Minute
+
+// SymbolService defines the symbol alias registry used by parsing and execution
+type SymbolService interface {
+	Table(ctx context.Context) (*signal.SymbolTable, error)
+	Resolve(ctx context.Context, exchange models.Exchange, raw string) (signal.ResolvedSymbol, error)
+	ListAliases(ctx context.Context) ([]*models.SymbolAlias, error)
+	CreateAlias(ctx context.Context, alias *models.SymbolAlias) (*models.SymbolAlias, error)
+	UpdateAlias(ctx context.Context, id uuid.UUID, update *models.SymbolAlias) (*models.SymbolAlias, error)
+	DeleteAlias(ctx context.Context, id uuid.UUID) error
+}
+
+type symbolService struct {
+	aliasRepo repositories.SymbolAliasRepository
+
+	mu       sync.RWMutex
+	table    *signal.SymbolTable
+	loadedAt time.Time
+}
+
+// NewSymbolService creates a new symbol registry service instance
+func NewSymbolService(aliasRepo repositories.SymbolAliasRepository) SymbolService {
+	return &symbolService{
+		aliasRepo: aliasRepo,
+	}
+}
+
+// Table returns the alias table, reloading it from the database once it is stale
+func (s *symbolService) Table(ctx context.Context) (*signal.SymbolTable, error) {
+	s.mu.RLock()
+	table, loadedAt := s.table, s.loadedAt
+	s.mu.RUnlock()
+	if table != nil && time.Since(loadedAt) < symbolTableTTL {
+		return table, nil
+	}
+
+	aliases, err := s.aliasRepo.FindAll(ctx)
+	if err != nil {
+		if table != nil {
+			slog.Warn("Failed to reload symbol aliases, using cached table", "error", err)
+			return table, nil
+		}
+		return nil, err
+	}
+
+	table = signal.NewSymbolTable(aliases)
+	s.mu.Lock()
+	s.table, s.loadedAt = table, time.Now()
+	s.mu.Unlock()
+
+	return table, nil
+}
+
+// Resolve returns the contract traded on exchange for a symbol or alias
+func (s *symbolService) Resolve(ctx context.Context, exchange models.Exchange, raw string) (signal.ResolvedSymbol, error) {
+	table, err := s.Table(ctx)
+	if err != nil {
+		return signal.ResolvedSymbol{}, err
+	}
+	return table.ForExchange(exchange, raw), nil
+}
+
+func (s *symbolService) ListAliases(ctx context.Context) ([]*models.SymbolAlias, error) {
+	return s.aliasRepo.FindAll(ctx)
+}
+
+func (s *symbolService) CreateAlias(ctx context.Context, alias *models.SymbolAlias) (*models.SymbolAlias, error) {
+	alias.Alias = signal.NormalizeAlias(alias.Alias)
+	alias.Symbol = signal.NormalizeAlias(alias.Symbol)
+	if alias.Multiplier == 0 {
+		alias.Multiplier = 1
+	}
+
+	if _, err := s.aliasRepo.FindByExchangeAndAlias(ctx, alias.Exchange, alias.Alias); err == nil {
+		return nil, fmt.Errorf("%w: %s", exceptions.ErrSymbolAliasExists, alias.Alias)
+	}
+
+	if err := s.aliasRepo.CreateAlias(ctx, alias); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return alias, nil
+}
+
+func (s *symbolService) UpdateAlias(ctx context.Context, id uuid.UUID, update *models.SymbolAlias) (*models.SymbolAlias, error) {
+	if update.Alias != "" {
+		update.Alias = signal.NormalizeAlias(update.Alias)
+	}
+	if update.Symbol != "" {
+		update.Symbol = signal.NormalizeAlias(update.Symbol)
+	}
+
+	if err := s.aliasRepo.UpdateAlias(ctx, id, update); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return s.aliasRepo.FindByIDTyped(ctx, id)
+}
+
+func (s *symbolService) DeleteAlias(ctx context.Context, id uuid.UUID) error {
+	if err := s.aliasRepo.DeleteAlias(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	return nil
+}
+
+// invalidate forces the next Table call to reload the aliases
+func (s *symbolService) invalidate() {
+	s.mu.Lock()
+	s.table = nil
+	s.mu.Unlock()
+}
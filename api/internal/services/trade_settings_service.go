@@ -5,6 +5,7 @@ import (
 
 	"copier/database/repositories"
 	"copier/internal/database/models"
+	"copier/internal/execution"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +16,9 @@ type TradeSettingsService interface {
 	UpsertTradeSettings(ctx context.Context, userID uuid.UUID, settings *models.TradeSettings) (*models.TradeSettings, error)
 	UpdateStopLoss(ctx context.Context, userID uuid.UUID, percentage int, status bool) error
 	UpdateTakeProfit(ctx context.Context, userID uuid.UUID, status bool, step int, percentages []float64) error
+	UpdateStopRules(ctx context.Context, userID uuid.UUID, rules models.StopRules) error
+	UpdateExpiryRules(ctx context.Context, userID uuid.UUID, rules models.ExpiryRules) error
+	UpdateRiskLimits(ctx context.Context, userID uuid.UUID, limits models.RiskLimits) error
 }
 
 type tradeSettingsService struct {
@@ -33,6 +37,35 @@ func (s *tradeSettingsService) GetTradeSettingsByUser(ctx context.Context, userI
 }
 
 func (s *tradeSettingsService) UpsertTradeSettings(ctx context.Context, userID uuid.UUID, settings *models.TradeSettings) (*models.TradeSettings, error) {
+	if settings.TakeProfitStatus {
+		if err := execution.ValidateTakeProfitLadder(settings.TakeProfitStep, settings.TPPercentage); err != nil {
+			return nil, err
+		}
+	}
+	if err := execution.ValidateStopRules(settings.StopRules); err != nil {
+		return nil, err
+	}
+	if err := execution.ValidateSizing(settings); err != nil {
+		return nil, err
+	}
+	if err := execution.ValidateEntryStrategy(settings); err != nil {
+		return nil, err
+	}
+	if settings.SizingMode == "" {
+		settings.SizingMode = models.SizingModeFixedAmount
+	}
+	if settings.LeverageSource == "" {
+		settings.LeverageSource = models.LeverageSourceSignal
+	}
+	if settings.MarginMode == "" {
+		settings.MarginMode = models.MarginModeCross
+	}
+	if settings.EntryStrategy == "" {
+		settings.EntryStrategy = models.EntryStrategyFirstPrice
+	}
+	// pausing is not a setting; a zero value leaves the stored pause untouched
+	settings.TradingPaused = false
+
 	_, err := s.settingsRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		// New settings
@@ -57,5 +90,25 @@ func (s *tradeSettingsService) UpdateStopLoss(ctx context.Context, userID uuid.U
 }
 
 func (s *tradeSettingsService) UpdateTakeProfit(ctx context.Context, userID uuid.UUID, status bool, step int, percentages []float64) error {
+	if status {
+		if err := execution.ValidateTakeProfitLadder(step, percentages); err != nil {
+			return err
+		}
+	}
 	return s.settingsRepo.UpdateTakeProfitSettings(ctx, userID, status, step, percentages)
 }
+
+func (s *tradeSettingsService) UpdateStopRules(ctx context.Context, userID uuid.UUID, rules models.StopRules) error {
+	if err := execution.ValidateStopRules(rules); err != nil {
+		return err
+	}
+	return s.settingsRepo.UpdateStopRules(ctx, userID, rules)
+}
+
+func (s *tradeSettingsService) UpdateExpiryRules(ctx context.Context, userID uuid.UUID, rules models.ExpiryRules) error {
+	return s.settingsRepo.UpdateExpiryRules(ctx, userID, rules)
+}
+
+func (s *tradeSettingsService) UpdateRiskLimits(ctx context.Context, userID uuid.UUID, limits models.RiskLimits) error {
+	return s.settingsRepo.UpdateRiskLimits(ctx, userID, limits)
+}
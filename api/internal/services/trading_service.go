@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+
+	"github.com/google/uuid"
+)
+
+// closeAllReason is recorded on positions and orders closed by an emergency close-all
+const closeAllReason = "emergency close-all"
+
+// TradingStatus says whether a user's signals are being executed
+type TradingStatus struct {
+	// Paused is the user's own pause
+	Paused bool `json:"paused"`
+	// Halt is the global halt, if one is active
+	Halt *TradingHalt `json:"halt,omitempty"`
+}
+
+// PlatformCloseResult is the outcome of an emergency close-all on one platform
+type PlatformCloseResult struct {
+	PlatformID uuid.UUID `json:"platform_id"`
+	Name       string    `json:"name"`
+	Positions  int       `json:"positions"`
+	Closed     int       `json:"closed"`
+	// Pending counts positions whose close was sent without a confirmed outcome yet
+	Pending int      `json:"pending"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// CloseAllReport summarises an emergency close-all per platform
+type CloseAllReport struct {
+	Platforms []PlatformCloseResult `json:"platforms"`
+}
+
+// TradingService pauses and resumes the execution of signals, per user and globally, and closes
+// everything a user holds in an emergency
+type TradingService interface {
+	// Status returns the user's pause and the global halt
+	Status(ctx context.Context, userID uuid.UUID) (*TradingStatus, error)
+	// Pause stops the user's new signals from being executed; open positions are still managed
+	Pause(ctx context.Context, userID uuid.UUID) error
+	// Resume lifts the user's pause
+	Resume(ctx context.Context, userID uuid.UUID) error
+	// CloseAll pauses the user, cancels their waiting entries and closes every position at market
+	// on each of their platforms
+	CloseAll(ctx context.Context, userID uuid.UUID) (*CloseAllReport, error)
+	// Halt stops all new executions for every user on every instance
+	Halt(ctx context.Context, adminID uuid.UUID, reason string) (*TradingHalt, error)
+	// ResumeAll lifts the global halt
+	ResumeAll(ctx context.Context) error
+}
+
+type tradingService struct {
+	settingsRepo     repositories.TradeSettingsRepository
+	platformRepo     repositories.PlatformRepository
+	positionRepo     repositories.PositionRepository
+	executionService ExecutionService
+	killSwitch       KillSwitch
+}
+
+// NewTradingService creates a new trading service instance
+func NewTradingService(settingsRepo repositories.TradeSettingsRepository, platformRepo repositories.PlatformRepository, positionRepo repositories.PositionRepository, executionService ExecutionService, killSwitch KillSwitch) TradingService {
+	return &tradingService{
+		settingsRepo:     settingsRepo,
+		platformRepo:     platformRepo,
+		positionRepo:     positionRepo,
+		executionService: executionService,
+		killSwitch:       killSwitch,
+	}
+}
+
+func (s *tradingService) Status(ctx context.Context, userID uuid.UUID) (*TradingStatus, error) {
+	halt, err := s.killSwitch.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TradingStatus{Halt: halt}
+	if settings, err := s.settingsRepo.FindByUserID(ctx, userID); err == nil {
+		status.Paused = settings.TradingPaused
+	}
+	return status, nil
+}
+
+func (s *tradingService) Pause(ctx context.Context, userID uuid.UUID) error {
+	return s.setPaused(ctx, userID, true)
+}
+
+func (s *tradingService) Resume(ctx context.Context, userID uuid.UUID) error {
+	return s.setPaused(ctx, userID, false)
+}
+
+func (s *tradingService) setPaused(ctx context.Context, userID uuid.UUID, paused bool) error {
+	if _, err := s.settingsRepo.FindByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("%w: %v", exceptions.ErrTradeSettingsNotFound, err)
+	}
+	return s.settingsRepo.UpdateTradingPaused(ctx, userID, paused)
+}
+
+func (s *tradingService) CloseAll(ctx context.Context, userID uuid.UUID) (*CloseAllReport, error) {
+	// users without settings have no signals executed and nothing to pause
+	if err := s.Pause(ctx, userID); err != nil {
+		slog.Warn("Close-all could not pause trading", "user_id", userID, "error", err)
+	}
+
+	platforms, err := s.platformRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CloseAllReport{Platforms: make([]PlatformCloseResult, 0, len(platforms))}
+	for _, platform := range platforms {
+		result := PlatformCloseResult{PlatformID: platform.ID, Name: platform.Name}
+		positions, err := s.positionRepo.FindActiveByPlatform(ctx, platform.ID)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			report.Platforms = append(report.Platforms, result)
+			continue
+		}
+
+		result.Positions = len(positions)
+		for _, pos := range positions {
+			err := s.executionService.ClosePosition(ctx, pos, closeAllReason)
+			switch {
+			case err != nil:
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("%s %s: %v", pos.Symbol, pos.ID, err))
+			case execution.IsTerminal(pos.Status):
+				result.Closed++
+			default:
+				result.Pending++
+			}
+		}
+		slog.Info("Close-all finished on platform", "user_id", userID, "platform_id", platform.ID,
+			"positions", result.Positions, "closed", result.Closed, "pending", result.Pending, "failed", result.Failed)
+		report.Platforms = append(report.Platforms, result)
+	}
+
+	return report, nil
+}
+
+func (s *tradingService) Halt(ctx context.Context, adminID uuid.UUID, reason string) (*TradingHalt, error) {
+	halt := &TradingHalt{Reason: reason, HaltedBy: adminID, HaltedAt: time.Now()}
+	if err := s.killSwitch.Halt(ctx, *halt); err != nil {
+		return nil, err
+	}
+
+	slog.Warn("Trading halted", "admin_id", adminID, "reason", reason)
+	return halt, nil
+}
+
+func (s *tradingService) ResumeAll(ctx context.Context) error {
+	if err := s.killSwitch.Resume(ctx); err != nil {
+		return err
+	}
+
+	slog.Warn("Trading halt lifted")
+	return nil
+}
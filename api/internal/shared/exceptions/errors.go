@@ -27,6 +27,28 @@ var (
 	ErrInfrastructureError   = errors.New("infrastructure error")
 	ErrExternalServiceError  = errors.New("external service error")
 
+	ErrNotASignal         = errors.New("message is not a trade signal")
+	ErrSignalMissingSide  = errors.New("signal side (long/short) not found")
+	ErrSignalMissingEntry = errors.New("signal entry price not found")
+	ErrChannelNotFound    = errors.New("channel not found")
+	ErrInvalidParserRule  = errors.New("invalid parser rule")
+	ErrNotACommand        = errors.New("message is not a signal management command")
+	ErrSignalNotLinked    = errors.New("no open signal found for management command")
+	ErrDuplicateMessage   = errors.New("message was already processed")
+	ErrSymbolAliasExists  = errors.New("symbol alias already exists")
+	ErrNotPaperPlatform   = errors.New("platform is not a paper trading platform")
+	ErrInvalidTransition  = errors.New("invalid position status transition")
+	ErrInvalidTakeProfit  = errors.New("invalid take profit ladder")
+	ErrInvalidStopRules   = errors.New("invalid stop rules")
+	ErrInvalidSizing      = errors.New("invalid position sizing")
+	ErrInvalidEntry       = errors.New("invalid entry strategy")
+	ErrBelowMinimum       = errors.New("order is below the exchange minimum")
+
+	ErrKillSwitchUnavailable = errors.New("kill switch needs a shared cache")
+	ErrTradeSettingsNotFound = errors.New("trade settings not found")
+	ErrRiskLimit             = errors.New("risk limit reached")
+	ErrInvalidRoute          = errors.New("invalid channel route")
+
 	ErrInvalidDummyName   = errors.New("invalid dummy name")
 	ErrInvalidDummyStatus = errors.New("invalid dummy status")
 	ErrDummyNotFound      = errors.New("dummy not found")
@@ -0,0 +1,118 @@
+package signal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// StopTarget says where a move-stop command wants the stop: an explicit price, the entry, or a target
+type StopTarget struct {
+	Price     *float64
+	BreakEven bool
+	Target    int
+}
+
+// Action is a single management instruction extracted from a follow-up message
+type Action struct {
+	Type    models.SignalActionType
+	Stop    StopTarget
+	Percent float64
+	Target  int
+	Note    string
+}
+
+// Command is the set of actions found in a follow-up message, with the symbol it names if any
+type Command struct {
+	Symbol  string
+	Actions []Action
+}
+
+const (
+	stopLevel = `(entry|break[\s-]?even|be|cost|tp\s*\d|target\s*\d|\d+(?:\.\d+)?)`
+
+	// commandSymbol is a ticker named by a command: a hashtag, or an upper-case word or pair so
+	// that ordinary words such as "to" are not taken for one
+	commandSymbol = `(?:[#$][A-Za-z0-9]{2,15}|(?-i:[A-Z][A-Z0-9]{1,14})(?:\s?/\s?(?-i:USDT|USDC|BUSD|USD))?)`
+)
+
+var (
+	moveStopVerbRe  = regexp.MustCompile(`(?i)\b(?:move|set|shift|adjust|update|change|trail)\w*\s+(?:the\s+|your\s+)?(?:sl|stop[\s-]?loss|stop)\b\s*(?:to|@|at|=)?\s*(?:the\s+)?` + stopLevel + `\b`)
+	moveStopToRe    = regexp.MustCompile(`(?i)\b(?:sl|stop[\s-]?loss|stop)\b\s*(?:has\s+been\s+|is\s+|was\s+)?(?:moved|move|set|shifted|adjusted|updated|changed)?\s*(?:to|@)\s+(?:the\s+)?` + stopLevel + `\b`)
+	closePartialRe  = regexp.MustCompile(`(?i)\b(?:close|take|book|secure)\w*\s+(?:partial\s+|some\s+)?(?:profits?\s+)?(\d{1,3}(?:\.\d+)?)\s*%`)
+	closeHalfRe     = regexp.MustCompile(`(?i)\b(?:close|take|book|secure)\w*\s+(?:profits?\s+on\s+)?half\b`)
+	targetHitRe     = regexp.MustCompile(`(?i)\b(?:tp|target)\s*(\d)\s*(?:is\s+|was\s+|has\s+been\s+)?(?:hit|reached|done|achieved|smashed|filled)\b`)
+	targetIndexRe   = regexp.MustCompile(`\d`)
+	breakEvenWordRe = regexp.MustCompile(`(?i)^(?:entry|break[\s-]?even|be|cost)$`)
+)
+
+// Closing and cancelling need imperative phrasing or a statement about the trade itself, since
+// words like "close" and "invalid" are common in ordinary chatter
+var (
+	closeAllRe = regexp.MustCompile(`(?i)\b(?:close|exit)\s+(?:all|now|everything|(?:the\s+|this\s+|all\s+)?(?:trades?|positions?)|` + commandSymbol + `)\b` +
+		`|\b(?:trade|position)s?\s+(?:is\s+|was\s+|has\s+been\s+|were\s+)?(?:closed|exited)\b`)
+	cancelRe = regexp.MustCompile(`(?i)\b(?:cancel|void)\s+(?:the\s+|this\s+)?(?:` + commandSymbol + `\s+)?(?:signal|trade|setup|entry|entries|orders?)\b` +
+		`|\bcancel\s+` + commandSymbol + `\b` +
+		`|\b(?:signal|trade|setup|entry)\s+(?:is\s+|was\s+|has\s+been\s+)?(?:cancelled|canceled|invalidated)\b`)
+)
+
+// ParseCommand extracts management actions such as "TP1 hit, move SL to entry" or "close 50% now"
+func ParseCommand(text string) (*Command, error) {
+	clean := Normalize(text)
+	cmd := &Command{Symbol: ExtractSymbol(clean)}
+
+	if m := targetHitRe.FindStringSubmatch(clean); m != nil {
+		target, _ := strconv.Atoi(m[1])
+		cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionTargetHit, Target: target})
+	}
+
+	if m := moveStopVerbRe.FindStringSubmatch(clean); m != nil {
+		cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionMoveStop, Stop: parseStopLevel(m[1])})
+	} else if m := moveStopToRe.FindStringSubmatch(clean); m != nil {
+		cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionMoveStop, Stop: parseStopLevel(m[1])})
+	}
+
+	switch {
+	case cancelRe.MatchString(clean):
+		cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionCancelPending})
+	case closePartialRe.MatchString(clean):
+		pct, _ := strconv.ParseFloat(closePartialRe.FindStringSubmatch(clean)[1], 64)
+		if pct >= 100 {
+			cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionCloseAll})
+		} else if pct > 0 {
+			cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionClosePartial, Percent: pct})
+		}
+	case closeHalfRe.MatchString(clean):
+		cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionClosePartial, Percent: 50})
+	case closeAllRe.MatchString(clean):
+		cmd.Actions = append(cmd.Actions, Action{Type: models.SignalActionCloseAll})
+	}
+
+	if len(cmd.Actions) == 0 {
+		return nil, exceptions.ErrNotACommand
+	}
+	return cmd, nil
+}
+
+// parseStopLevel interprets the destination of a move-stop instruction
+func parseStopLevel(level string) StopTarget {
+	level = strings.TrimSpace(level)
+	lower := strings.ToLower(level)
+
+	switch {
+	case breakEvenWordRe.MatchString(lower):
+		return StopTarget{BreakEven: true}
+	case strings.HasPrefix(lower, "tp") || strings.HasPrefix(lower, "target"):
+		target, _ := strconv.Atoi(targetIndexRe.FindString(lower))
+		return StopTarget{Target: target}
+	default:
+		price, err := strconv.ParseFloat(level, 64)
+		if err != nil {
+			return StopTarget{BreakEven: true}
+		}
+		return StopTarget{Price: &price}
+	}
+}
@@ -0,0 +1,125 @@
+package signal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"copier/internal/database/models"
+	"copier/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultDedupWindow is how long an identical signal in the same channel counts as a duplicate
+	DefaultDedupWindow = 15 * time.Minute
+
+	// DefaultReplayWindow is how long a delivered source message ID is remembered
+	DefaultReplayWindow = 72 * time.Hour
+
+	dedupKeyPrefix = "copier:dedup:"
+)
+
+// Fingerprint identifies a signal by its normalised trading content within a user channel,
+// so reposts and forwards with different wording or emojis produce the same value
+func Fingerprint(channelID uuid.UUID, sig *models.Signal) string {
+	parts := []string{
+		channelID.String(),
+		strings.ToUpper(sig.Symbol),
+		string(sig.Side),
+		formatPrice(sig.EntryLow),
+		formatPrice(sig.EntryHigh),
+	}
+	for _, target := range sig.Targets {
+		parts = append(parts, formatPrice(target))
+	}
+	if sig.StopLoss != nil {
+		parts = append(parts, "sl="+formatPrice(*sig.StopLoss))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:16])
+}
+
+func formatPrice(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Deduplicator claims message IDs and signal fingerprints in a shared cache, so that only one
+// API instance processes each delivery. With a nil cache every claim succeeds and callers rely
+// on their own storage checks.
+type Deduplicator struct {
+	cache        cache.Cache
+	window       time.Duration
+	replayWindow time.Duration
+}
+
+// NewDeduplicator creates a deduplicator; zero windows fall back to the defaults
+func NewDeduplicator(c cache.Cache, window, replayWindow time.Duration) *Deduplicator {
+	if window <= 0 {
+		window = DefaultDedupWindow
+	}
+	if replayWindow <= 0 {
+		replayWindow = DefaultReplayWindow
+	}
+	return &Deduplicator{
+		cache:        c,
+		window:       window,
+		replayWindow: replayWindow,
+	}
+}
+
+// Window returns the period within which identical signals are duplicates
+func (d *Deduplicator) Window() time.Duration {
+	return d.window
+}
+
+// ClaimMessage reports whether this is the first delivery of a source message to a channel
+func (d *Deduplicator) ClaimMessage(ctx context.Context, channelID uuid.UUID, messageID string) (bool, error) {
+	if d.cache == nil || messageID == "" {
+		return true, nil
+	}
+	key := dedupKeyPrefix + "msg:" + channelID.String() + ":" + messageID
+	return d.cache.SetNX(ctx, key, "1", d.replayWindow)
+}
+
+// ReleaseMessage forgets a claimed message so a redelivery can retry it after a failure
+func (d *Deduplicator) ReleaseMessage(ctx context.Context, channelID uuid.UUID, messageID string) error {
+	if d.cache == nil || messageID == "" {
+		return nil
+	}
+	return d.cache.Delete(ctx, dedupKeyPrefix+"msg:"+channelID.String()+":"+messageID)
+}
+
+// ClaimContent reports whether fingerprint is new within the window; when it is not,
+// prior is the source message that claimed it first
+func (d *Deduplicator) ClaimContent(ctx context.Context, fingerprint, messageID string) (prior string, first bool, err error) {
+	if d.cache == nil {
+		return "", true, nil
+	}
+	key := dedupKeyPrefix + "sig:" + fingerprint
+	first, err = d.cache.SetNX(ctx, key, messageID, d.window)
+	if err != nil || first {
+		return "", first, err
+	}
+	prior, _ = d.cache.Get(ctx, key)
+	return prior, false, nil
+}
+
+// ReleaseContent forgets a fingerprint claimed by messageID so a redelivery of that message can
+// retry after a failure; a claim held by another message is kept
+func (d *Deduplicator) ReleaseContent(ctx context.Context, fingerprint, messageID string) error {
+	if d.cache == nil {
+		return nil
+	}
+	key := dedupKeyPrefix + "sig:" + fingerprint
+	holder, err := d.cache.Get(ctx, key)
+	if err != nil || holder != messageID {
+		return nil
+	}
+	return d.cache.Delete(ctx, key)
+}
@@ -0,0 +1,50 @@
+package signal
+
+import (
+	"slices"
+
+	"copier/internal/database/models"
+)
+
+// Diff lists the trading fields that differ between a stored signal and a re-parsed edit of it
+func Diff(old, updated *models.Signal) []models.FieldChange {
+	var changes []models.FieldChange
+
+	if old.Symbol != updated.Symbol {
+		changes = append(changes, models.FieldChange{Field: "symbol", Old: old.Symbol, New: updated.Symbol})
+	}
+	if old.Side != updated.Side {
+		changes = append(changes, models.FieldChange{Field: "side", Old: old.Side, New: updated.Side})
+	}
+	if old.EntryLow != updated.EntryLow {
+		changes = append(changes, models.FieldChange{Field: "entry_low", Old: old.EntryLow, New: updated.EntryLow})
+	}
+	if old.EntryHigh != updated.EntryHigh {
+		changes = append(changes, models.FieldChange{Field: "entry_high", Old: old.EntryHigh, New: updated.EntryHigh})
+	}
+	if !slices.Equal(old.Targets, updated.Targets) {
+		changes = append(changes, models.FieldChange{Field: "targets", Old: old.Targets, New: updated.Targets})
+	}
+	if !equalFloatPtr(old.StopLoss, updated.StopLoss) {
+		changes = append(changes, models.FieldChange{Field: "stop_loss", Old: old.StopLoss, New: updated.StopLoss})
+	}
+	if !equalIntPtr(old.Leverage, updated.Leverage) {
+		changes = append(changes, models.FieldChange{Field: "leverage", Old: old.Leverage, New: updated.Leverage})
+	}
+
+	return changes
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
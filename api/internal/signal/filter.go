@@ -0,0 +1,102 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"copier/internal/database/models"
+)
+
+// RiskReward returns the reward to risk ratio of the first target against the stop loss,
+// measured from the entry midpoint. ok is false when the signal lacks a stop or a target.
+func RiskReward(sig *models.Signal) (ratio float64, ok bool) {
+	if sig.StopLoss == nil || len(sig.Targets) == 0 {
+		return 0, false
+	}
+
+	entry := sig.EntryPrice()
+	risk := entry - *sig.StopLoss
+	reward := sig.Targets[0] - entry
+	if sig.Side == models.SignalSideShort {
+		risk, reward = -risk, -reward
+	}
+	if risk <= 0 || reward <= 0 {
+		return 0, true
+	}
+	return reward / risk, true
+}
+
+// ApplyFilter checks sig against a channel filter and returns why it is rejected, or "" to copy it
+func ApplyFilter(f models.ChannelFilter, sig *models.Signal) string {
+	symbol := strings.ToUpper(sig.Symbol)
+
+	if matchesSymbol(f.DenySymbols, symbol) {
+		return fmt.Sprintf("symbol %s is on the deny list", symbol)
+	}
+	if len(f.AllowSymbols) > 0 && !matchesSymbol(f.AllowSymbols, symbol) {
+		return fmt.Sprintf("symbol %s is not on the allow list", symbol)
+	}
+	if len(f.QuoteAssets) > 0 && !slices.ContainsFunc(f.QuoteAssets, func(quote string) bool {
+		return strings.HasSuffix(symbol, strings.ToUpper(quote))
+	}) {
+		return fmt.Sprintf("symbol %s is not quoted in %s", symbol, strings.Join(f.QuoteAssets, "/"))
+	}
+
+	if f.Side != "" && sig.Side != f.Side {
+		return fmt.Sprintf("channel copies %s signals only", f.Side)
+	}
+
+	if f.MaxLeverage > 0 && sig.Leverage != nil && *sig.Leverage > f.MaxLeverage {
+		return fmt.Sprintf("leverage %dx exceeds maximum %dx", *sig.Leverage, f.MaxLeverage)
+	}
+
+	if f.MinRiskReward > 0 {
+		ratio, ok := RiskReward(sig)
+		if !ok {
+			return "risk/reward cannot be computed without a stop loss and a target"
+		}
+		if ratio < f.MinRiskReward {
+			return fmt.Sprintf("risk/reward %.2f is below minimum %.2f", math.Floor(ratio*100)/100, f.MinRiskReward)
+		}
+	}
+
+	return ""
+}
+
+// CopyAfter returns when a signal posted at postedAt may be copied, or nil without a delay
+func CopyAfter(f models.ChannelFilter, postedAt time.Time) *time.Time {
+	if f.CopyDelaySeconds <= 0 {
+		return nil
+	}
+	at := postedAt.Add(time.Duration(f.CopyDelaySeconds) * time.Second)
+	return &at
+}
+
+// knownQuotes are the quote assets stripped to find a symbol's base asset
+var knownQuotes = []string{"FDUSD", "USDT", "USDC", "BUSD"}
+
+// BaseAsset returns symbol without its quote asset, e.g. "BTC" for "BTCUSDT"
+func BaseAsset(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	for _, quote := range knownQuotes {
+		if base, ok := strings.CutSuffix(symbol, quote); ok && base != "" {
+			return base
+		}
+	}
+	return symbol
+}
+
+// matchesSymbol reports whether symbol equals an entry, or its base asset does
+func matchesSymbol(list []string, symbol string) bool {
+	base := BaseAsset(symbol)
+	for _, entry := range list {
+		entry = strings.ToUpper(strings.TrimSpace(entry))
+		if entry == symbol || entry == base {
+			return true
+		}
+	}
+	return false
+}
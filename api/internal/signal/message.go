@@ -0,0 +1,32 @@
+package signal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageKind string
+
+const (
+	MessageKindNew    MessageKind = "new"
+	MessageKindEdit   MessageKind = "edit"
+	MessageKindDelete MessageKind = "delete"
+)
+
+// Message is a raw post received from a signal channel
+type Message struct {
+	// ChannelID is the source identifier stored in models.Channel.ChannelID
+	ChannelID string `json:"channel_id"`
+	// TargetID restricts delivery to one user channel (models.Channel.ID); zero reaches every follower of ChannelID
+	TargetID  uuid.UUID `json:"-"`
+	MessageID string    `json:"message_id"`
+	// ReplyToID is the source message this one replies to, if any
+	ReplyToID string    `json:"reply_to_id,omitempty"`
+	Text      string    `json:"text"`
+	PostedAt  time.Time `json:"posted_at"`
+	// Kind marks edits and deletions of an earlier message; empty means a new post
+	Kind MessageKind `json:"kind,omitempty"`
+	// Historical marks replayed channel history: signals are stored for analysis but never executed
+	Historical bool `json:"-"`
+}
@@ -0,0 +1,279 @@
+package signal
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+const (
+	// DefaultQuote is appended to symbols posted without a quote asset (e.g. "#BTC")
+	DefaultQuote = "USDT"
+
+	// GenericRuleName is recorded on signals extracted by the built-in parser
+	GenericRuleName = "generic"
+)
+
+// Parser extracts a structured trade signal from a free-text channel message
+type Parser interface {
+	Parse(text string) (*models.Signal, error)
+}
+
+var (
+	thousandsRe = regexp.MustCompile(`(\d),(\d{3})\b`)
+	numberRe    = regexp.MustCompile(`\b(\d+(?:\.\d+)?)\b(\s*%)?`)
+	listIndexRe = regexp.MustCompile(`^\s*\d{1,2}\s*[).:\-]\s+`)
+	// inlineIndexRe finds list indices such as "1)" or "2." inside a line, e.g. "Targets: 1) 150 2) 155"
+	inlineIndexRe = regexp.MustCompile(`(?:^|\s)\d{1,2}(?:\)\s*|\.\s+)`)
+
+	pairRe    = regexp.MustCompile(`(?i)\b([A-Z0-9]{2,15})\s?[/_:\-]\s?(USDT|USDC|BUSD|FDUSD|USD|PERP)\b`)
+	concatRe  = regexp.MustCompile(`(?i)\b([A-Z0-9]{2,15}?)(USDT|USDC|BUSD|FDUSD)(?:\.P|PERP)?\b`)
+	hashtagRe = regexp.MustCompile(`[#$]([A-Za-z0-9]{2,15})\b`)
+	bareRe    = regexp.MustCompile(`\b([A-Z0-9]{2,10})\s+(?:LONG|SHORT|BUY|SELL)\b|\b(?:LONG|SHORT|BUY|SELL)\s+([A-Z0-9]{2,10})\b`)
+
+	sideRe   = regexp.MustCompile(`(?i)\b(long|buy|short|sell)\b`)
+	stopRe   = regexp.MustCompile(`(?i)\b(?:sl|stop[\s-]?loss|stoploss|stop)\b`)
+	targetRe = regexp.MustCompile(`(?i)\b(?:tps?|targets?|take[\s-]?profits?)(?:\d{1,2}\b|\b(?:\s*\d{1,2}\s*[:)=\-])?)`)
+	entryRe  = regexp.MustCompile(`(?i)\b(?:entry\s+zone|entry\s+price|entries|entry|buy\s+zone|sell\s+zone|enter|buy|sell)\b`)
+
+	leverageRe       = regexp.MustCompile(`(?i)\b(?:leverage|lev)\b\s*[:=\-]?\s*(?:cross|isolated)?\s*\(?\s*(\d{1,3})(?:\s*[-–]\s*(\d{1,3}))?`)
+	marginLeverageRe = regexp.MustCompile(`(?i)\b(?:cross|isolated)\s*\(?\s*(\d{1,3})\s*x\b`)
+	bareLeverageRe   = regexp.MustCompile(`(?i)\b(\d{1,3})\s?x\b`)
+)
+
+// symbolStopWords are hashtags and capitalised words that are never tickers
+var symbolStopWords = map[string]bool{
+	"LONG": true, "SHORT": true, "BUY": true, "SELL": true, "SIGNAL": true, "SIGNALS": true,
+	"VIP": true, "SPOT": true, "FUTURES": true, "FUTURE": true, "SCALP": true, "SWING": true,
+	"CRYPTO": true, "BINANCE": true, "BYBIT": true, "TRADE": true, "SETUP": true, "UPDATE": true,
+	"NEW": true, "ENTRY": true, "TP": true, "SL": true, "LEVERAGE": true, "CROSS": true,
+	"ISOLATED": true, "TARGET": true, "TARGETS": true, "STOP": true, "PAIR": true, "COIN": true,
+}
+
+// genericParser understands the common free-text formats used by Telegram signal channels
+type genericParser struct{}
+
+// NewGenericParser creates the built-in parser used when a channel has no template
+func NewGenericParser() Parser {
+	return &genericParser{}
+}
+
+// Parse extracts symbol, side, entry range, ordered targets, stop loss and leverage from text
+func (p *genericParser) Parse(text string) (*models.Signal, error) {
+	clean := Normalize(text)
+
+	symbol := ExtractSymbol(clean)
+	if symbol == "" {
+		return nil, exceptions.ErrNotASignal
+	}
+
+	side := ExtractSide(clean)
+	if side == "" {
+		return nil, exceptions.ErrSignalMissingSide
+	}
+
+	sig := &models.Signal{
+		Symbol:      symbol,
+		Side:        side,
+		RawText:     text,
+		MatchedRule: GenericRuleName,
+	}
+
+	levels := extractLevels(clean)
+	if len(levels.entries) == 0 {
+		return nil, exceptions.ErrSignalMissingEntry
+	}
+	sig.EntryLow = slices.Min(levels.entries)
+	sig.EntryHigh = slices.Max(levels.entries)
+	sig.Targets = levels.targets
+	sig.StopLoss = levels.stopLoss
+
+	if leverage := ExtractLeverage(clean); leverage > 0 {
+		sig.Leverage = &leverage
+	}
+
+	return sig, nil
+}
+
+// priceLevels are the entry, target and stop loss prices found in a message
+type priceLevels struct {
+	entries  []float64
+	targets  []float64
+	stopLoss *float64
+}
+
+// extractLevels reads the labelled entry, target and stop loss lines of normalised text,
+// including the bare numbers continuing an "Entry:" or "Targets:" block
+func extractLevels(clean string) priceLevels {
+	const (
+		sectionNone = iota
+		sectionEntry
+		sectionTarget
+	)
+	section := sectionNone
+	var levels priceLevels
+
+	for _, line := range strings.Split(clean, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if loc := stopRe.FindStringIndex(line); loc != nil {
+			section = sectionNone
+			if nums := extractNumbers(line[loc[1]:]); len(nums) > 0 && levels.stopLoss == nil {
+				levels.stopLoss = &nums[0]
+			}
+			continue
+		}
+
+		if loc := targetRe.FindStringIndex(line); loc != nil {
+			section = sectionTarget
+			levels.targets = append(levels.targets, extractNumbers(inlineIndexRe.ReplaceAllString(line[loc[1]:], " "))...)
+			continue
+		}
+
+		if loc := entryRe.FindStringIndex(line); loc != nil {
+			section = sectionNone
+			if nums := extractNumbers(line[loc[1]:]); len(nums) > 0 {
+				levels.entries = append(levels.entries, nums...)
+			} else {
+				section = sectionEntry
+			}
+			continue
+		}
+
+		if leverageRe.MatchString(line) || bareLeverageRe.MatchString(line) {
+			section = sectionNone
+			continue
+		}
+
+		// Continuation lines of a "Targets:" or "Entry:" block carry bare numbers
+		switch section {
+		case sectionTarget:
+			levels.targets = append(levels.targets, extractNumbers(listIndexRe.ReplaceAllString(line, ""))...)
+		case sectionEntry:
+			levels.entries = append(levels.entries, extractNumbers(listIndexRe.ReplaceAllString(line, ""))...)
+		}
+	}
+
+	return levels
+}
+
+// Normalize strips emojis and formatting characters and removes thousands separators
+func Normalize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		switch {
+		case r == '\n' || r == '\t':
+			b.WriteRune(r)
+		case r == '*' || r == '`' || r == '|' || r == '\u200d' || r == '\ufe0f':
+			b.WriteRune(' ')
+		case unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r) || unicode.Is(unicode.Cs, r) || unicode.IsControl(r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	out := b.String()
+	for {
+		next := thousandsRe.ReplaceAllString(out, "$1$2")
+		if next == out {
+			return out
+		}
+		out = next
+	}
+}
+
+// ExtractSymbol returns the first ticker found in text as BASE+QUOTE, e.g. "BTCUSDT"
+func ExtractSymbol(text string) string {
+	if m := pairRe.FindStringSubmatch(text); m != nil && hasLetter(m[1]) {
+		return joinSymbol(m[1], m[2])
+	}
+	if m := concatRe.FindStringSubmatch(text); m != nil && hasLetter(m[1]) {
+		return joinSymbol(m[1], m[2])
+	}
+	for _, m := range hashtagRe.FindAllStringSubmatch(text, -1) {
+		if base := strings.ToUpper(m[1]); hasLetter(base) && !symbolStopWords[base] {
+			return joinSymbol(base, "")
+		}
+	}
+	for _, m := range bareRe.FindAllStringSubmatch(text, -1) {
+		base := m[1]
+		if base == "" {
+			base = m[2]
+		}
+		if hasLetter(base) && !symbolStopWords[base] {
+			return joinSymbol(base, "")
+		}
+	}
+	return ""
+}
+
+// ExtractSide returns the direction named first in text
+func ExtractSide(text string) models.SignalSide {
+	m := sideRe.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	switch strings.ToLower(m[1]) {
+	case "long", "buy":
+		return models.SignalSideLong
+	default:
+		return models.SignalSideShort
+	}
+}
+
+// ExtractLeverage returns the leverage mentioned in text, taking the upper bound of a range
+func ExtractLeverage(text string) int {
+	if m := leverageRe.FindStringSubmatch(text); m != nil {
+		low, _ := strconv.Atoi(m[1])
+		high, _ := strconv.Atoi(m[2])
+		return max(low, high)
+	}
+	if m := marginLeverageRe.FindStringSubmatch(text); m != nil {
+		leverage, _ := strconv.Atoi(m[1])
+		return leverage
+	}
+	if m := bareLeverageRe.FindStringSubmatch(text); m != nil {
+		leverage, _ := strconv.Atoi(m[1])
+		return leverage
+	}
+	return 0
+}
+
+// extractNumbers returns every price in s, skipping percentages
+func extractNumbers(s string) []float64 {
+	var nums []float64
+	for _, m := range numberRe.FindAllStringSubmatch(s, -1) {
+		if m[2] != "" {
+			continue
+		}
+		if v, err := strconv.ParseFloat(m[1], 64); err == nil && v > 0 {
+			nums = append(nums, v)
+		}
+	}
+	return nums
+}
+
+func joinSymbol(base, quote string) string {
+	base = strings.ToUpper(base)
+	quote = strings.ToUpper(quote)
+	if quote == "" || quote == "USD" || quote == "PERP" {
+		quote = DefaultQuote
+	}
+	if strings.HasSuffix(base, quote) {
+		return base
+	}
+	return base + quote
+}
+
+func hasLetter(s string) bool {
+	return strings.ContainsFunc(s, unicode.IsLetter)
+}
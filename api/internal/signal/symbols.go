@@ -0,0 +1,152 @@
+package signal
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// ResolvedSymbol is the contract to trade on an exchange for a signal symbol
+type ResolvedSymbol struct {
+	Symbol string `json:"symbol"`
+	// Multiplier converts signal prices to contract prices; quantities divide by it
+	Multiplier float64 `json:"multiplier"`
+}
+
+// exchangeQuotes is the quote asset appended to bare tickers per exchange
+var exchangeQuotes = map[models.Exchange]string{
+	models.ExchangeBinanceFutures: "USDT",
+}
+
+var (
+	aliasSeparatorRe = regexp.MustCompile(`[\s/_:\-.]+`)
+	aliasWordRe      = regexp.MustCompile(`[#$]?[A-Za-z0-9]{2,}`)
+)
+
+// NormalizeAlias reduces the ways channels write a market ("#btc", "BTC/USDT", "BTC-PERP",
+// "BTCUSDT.P") to a single upper-case key
+func NormalizeAlias(raw string) string {
+	key := strings.ToUpper(strings.TrimSpace(raw))
+	key = strings.TrimLeft(key, "#$")
+	key = strings.TrimSuffix(key, ".P")
+	key = aliasSeparatorRe.ReplaceAllString(key, "")
+	if base, ok := strings.CutSuffix(key, "PERP"); ok && base != "" {
+		key = base
+	}
+	return key
+}
+
+// SymbolTable resolves aliases to canonical and exchange symbols
+type SymbolTable struct {
+	// aliases is keyed by exchange ("" for exchange-independent aliases), then normalised alias
+	aliases map[models.Exchange]map[string]models.SymbolAlias
+}
+
+// NewSymbolTable indexes stored aliases
+func NewSymbolTable(aliases []*models.SymbolAlias) *SymbolTable {
+	t := &SymbolTable{aliases: map[models.Exchange]map[string]models.SymbolAlias{}}
+	for _, a := range aliases {
+		if t.aliases[a.Exchange] == nil {
+			t.aliases[a.Exchange] = map[string]models.SymbolAlias{}
+		}
+		t.aliases[a.Exchange][NormalizeAlias(a.Alias)] = *a
+	}
+	return t
+}
+
+// Canonical returns the exchange-independent symbol for raw, appending DefaultQuote to bare
+// tickers; known reports whether an alias matched
+func (t *SymbolTable) Canonical(raw string) (symbol string, known bool) {
+	return t.canonical(raw, DefaultQuote)
+}
+
+func (t *SymbolTable) canonical(raw, quote string) (string, bool) {
+	key := NormalizeAlias(raw)
+	if key == "" {
+		return "", false
+	}
+	if a, ok := t.aliases[""][key]; ok {
+		return NormalizeAlias(a.Symbol), true
+	}
+	if BaseAsset(key) == key {
+		return key + quote, false
+	}
+	return key, false
+}
+
+// ForExchange returns the contract traded on exchange for raw
+func (t *SymbolTable) ForExchange(exchange models.Exchange, raw string) ResolvedSymbol {
+	quote, ok := exchangeQuotes[exchange]
+	if !ok {
+		quote = DefaultQuote
+	}
+
+	symbol, _ := t.canonical(raw, quote)
+	for _, key := range []string{symbol, NormalizeAlias(raw)} {
+		if a, ok := t.aliases[exchange][key]; ok {
+			multiplier := a.Multiplier
+			if multiplier <= 0 {
+				multiplier = 1
+			}
+			return ResolvedSymbol{Symbol: NormalizeAlias(a.Symbol), Multiplier: multiplier}
+		}
+	}
+	return ResolvedSymbol{Symbol: symbol, Multiplier: 1}
+}
+
+// SymbolIn returns the symbol of the first word in text that is a known alias, e.g. "Bitcoin"
+func (t *SymbolTable) SymbolIn(text string) (string, bool) {
+	symbol, _, ok := t.findAliasWord(text)
+	return symbol, ok
+}
+
+// replaceAliasWord swaps the first word that is a known alias for its symbol
+func (t *SymbolTable) replaceAliasWord(text string) (string, bool) {
+	symbol, loc, ok := t.findAliasWord(text)
+	if !ok {
+		return text, false
+	}
+	return text[:loc[0]] + symbol + text[loc[1]:], true
+}
+
+func (t *SymbolTable) findAliasWord(text string) (string, []int, bool) {
+	for _, loc := range aliasWordRe.FindAllStringIndex(text, -1) {
+		if a, ok := t.aliases[""][NormalizeAlias(text[loc[0]:loc[1]])]; ok {
+			return NormalizeAlias(a.Symbol), loc, true
+		}
+	}
+	return "", nil, false
+}
+
+// symbolParser canonicalises the symbols extracted by another parser
+type symbolParser struct {
+	parser Parser
+	table  *SymbolTable
+}
+
+// WithSymbols wraps p so extracted symbols resolve through table and alias words such as
+// "Bitcoin" are recognised in messages without a ticker
+func WithSymbols(p Parser, table *SymbolTable) Parser {
+	return &symbolParser{parser: p, table: table}
+}
+
+func (p *symbolParser) Parse(text string) (*models.Signal, error) {
+	sig, err := p.parser.Parse(text)
+	if errors.Is(err, exceptions.ErrNotASignal) {
+		if replaced, ok := p.table.replaceAliasWord(text); ok {
+			sig, err = p.parser.Parse(replaced)
+			if err == nil {
+				sig.RawText = text
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sig.Symbol, _ = p.table.Canonical(sig.Symbol)
+	return sig, nil
+}
@@ -0,0 +1,267 @@
+package signal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+)
+
+// Capture group names understood by channel parser rules
+const (
+	GroupSymbol    = "symbol"
+	GroupSide      = "side"
+	GroupEntry     = "entry"
+	GroupEntryLow  = "entry_low"
+	GroupEntryHigh = "entry_high"
+	GroupTargets   = "targets"
+	GroupStop      = "stop"
+	GroupLeverage  = "leverage"
+)
+
+// tpGroupRe matches numbered target groups such as tp1, tp2 ... tp9
+var tpGroupRe = regexp.MustCompile(`^tp([1-9])$`)
+
+// templatePlaceholders maps template DSL placeholders to their capture expressions
+var templatePlaceholders = map[string]string{
+	GroupSymbol:    `(?P<symbol>[#$]?[A-Za-z0-9]{2,15}(?:\s?[/_:\-]\s?[A-Za-z]{3,5})?)`,
+	GroupSide:      `(?P<side>long|short|buy|sell)`,
+	GroupEntry:     `(?P<entry>\d+(?:\.\d+)?(?:\s*(?:-|–|to|/|~)\s*\d+(?:\.\d+)?)?)`,
+	GroupEntryLow:  `(?P<entry_low>\d+(?:\.\d+)?)`,
+	GroupEntryHigh: `(?P<entry_high>\d+(?:\.\d+)?)`,
+	GroupTargets:   `(?P<targets>\d+(?:\.\d+)?(?:[\s,/|\-–]+\d+(?:\.\d+)?)*)`,
+	GroupStop:      `(?P<stop>\d+(?:\.\d+)?)`,
+	GroupLeverage:  `(?P<leverage>\d{1,3})`,
+	"*":            `[\s\S]*?`,
+}
+
+var (
+	placeholderRe = regexp.MustCompile(`\{([a-z_0-9*]+)\}`)
+	whitespaceRe  = regexp.MustCompile(`\s+`)
+)
+
+// ruleParser extracts a signal using a single channel-defined regular expression
+type ruleParser struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// NewRuleParser compiles a channel parser rule into a Parser
+func NewRuleParser(rule models.ParserRule) (Parser, error) {
+	pattern := rule.Pattern
+	if rule.Template != "" {
+		if rule.Pattern != "" {
+			return nil, fmt.Errorf("%w %q: set either pattern or template, not both", exceptions.ErrInvalidParserRule, rule.Name)
+		}
+		compiled, err := CompileTemplate(rule.Template)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", exceptions.ErrInvalidParserRule, rule.Name, err)
+		}
+		pattern = compiled
+	}
+	if pattern == "" {
+		return nil, fmt.Errorf("%w %q: pattern or template is required", exceptions.ErrInvalidParserRule, rule.Name)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", exceptions.ErrInvalidParserRule, rule.Name, err)
+	}
+
+	known := 0
+	for _, group := range re.SubexpNames()[1:] {
+		if group == "" {
+			continue
+		}
+		if _, ok := templatePlaceholders[group]; !ok && !tpGroupRe.MatchString(group) {
+			return nil, fmt.Errorf("%w %q: unknown capture group %q", exceptions.ErrInvalidParserRule, rule.Name, group)
+		}
+		known++
+	}
+	if known == 0 {
+		return nil, fmt.Errorf("%w %q: no named capture groups", exceptions.ErrInvalidParserRule, rule.Name)
+	}
+
+	return &ruleParser{name: rule.Name, re: re}, nil
+}
+
+// CompileTemplate turns a template such as "{symbol} {side}\nEntry: {entry}\nSL: {stop}"
+// into a case-insensitive regular expression with named captures
+func CompileTemplate(template string) (string, error) {
+	var b strings.Builder
+	b.WriteString("(?i)")
+
+	seen := map[string]bool{}
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringSubmatchIndex(template, -1) {
+		b.WriteString(literalPattern(template[last:loc[0]]))
+
+		name := template[loc[2]:loc[3]]
+		expr, ok := templatePlaceholders[name]
+		if !ok {
+			if !tpGroupRe.MatchString(name) {
+				return "", fmt.Errorf("unknown placeholder {%s}", name)
+			}
+			expr = fmt.Sprintf(`(?P<%s>\d+(?:\.\d+)?)`, name)
+		}
+		if name != "*" {
+			if seen[name] {
+				return "", fmt.Errorf("placeholder {%s} used more than once", name)
+			}
+			seen[name] = true
+		}
+		b.WriteString(expr)
+		last = loc[1]
+	}
+	b.WriteString(literalPattern(template[last:]))
+
+	if len(seen) == 0 {
+		return "", errors.New("template has no placeholders")
+	}
+	return b.String(), nil
+}
+
+// literalPattern quotes template text, letting any run of whitespace match any other
+func literalPattern(s string) string {
+	parts := whitespaceRe.Split(s, -1)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return strings.Join(parts, `\s+`)
+}
+
+// Parse applies the rule, falling back to generic extraction for fields it does not capture
+func (p *ruleParser) Parse(text string) (*models.Signal, error) {
+	clean := Normalize(text)
+
+	m := p.re.FindStringSubmatch(clean)
+	if m == nil {
+		return nil, exceptions.ErrNotASignal
+	}
+
+	groups := make(map[string]string, len(m))
+	for i, name := range p.re.SubexpNames() {
+		if name != "" && m[i] != "" {
+			groups[name] = m[i]
+		}
+	}
+
+	sig := &models.Signal{
+		RawText:     text,
+		MatchedRule: p.name,
+	}
+
+	if raw, ok := groups[GroupSymbol]; ok {
+		sig.Symbol = symbolFromCapture(raw)
+	} else {
+		sig.Symbol = ExtractSymbol(clean)
+	}
+	if sig.Symbol == "" {
+		return nil, exceptions.ErrNotASignal
+	}
+
+	if raw, ok := groups[GroupSide]; ok {
+		sig.Side = ExtractSide(raw)
+	} else {
+		sig.Side = ExtractSide(clean)
+	}
+	if sig.Side == "" {
+		return nil, exceptions.ErrSignalMissingSide
+	}
+
+	levels := extractLevels(clean)
+
+	entries := extractNumbers(groups[GroupEntry] + " " + groups[GroupEntryLow] + " " + groups[GroupEntryHigh])
+	if len(entries) == 0 {
+		entries = levels.entries
+	}
+	if len(entries) == 0 {
+		return nil, exceptions.ErrSignalMissingEntry
+	}
+	sig.EntryLow = slices.Min(entries)
+	sig.EntryHigh = slices.Max(entries)
+
+	sig.Targets = extractNumbers(groups[GroupTargets])
+	for i := 1; i <= 9; i++ {
+		sig.Targets = append(sig.Targets, extractNumbers(groups[fmt.Sprintf("tp%d", i)])...)
+	}
+	if len(sig.Targets) == 0 {
+		sig.Targets = levels.targets
+	}
+
+	if nums := extractNumbers(groups[GroupStop]); len(nums) > 0 {
+		sig.StopLoss = &nums[0]
+	} else {
+		sig.StopLoss = levels.stopLoss
+	}
+
+	if raw, ok := groups[GroupLeverage]; ok {
+		if leverage, err := strconv.Atoi(strings.TrimSpace(raw)); err == nil && leverage > 0 {
+			sig.Leverage = &leverage
+		}
+	} else if leverage := ExtractLeverage(clean); leverage > 0 {
+		sig.Leverage = &leverage
+	}
+
+	return sig, nil
+}
+
+// symbolFromCapture normalises a captured ticker such as "#btc", "BTC/USDT" or "ETH"
+func symbolFromCapture(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if symbol := ExtractSymbol(raw); symbol != "" {
+		return symbol
+	}
+	base := strings.Trim(strings.ToUpper(raw), "#$")
+	if !hasLetter(base) {
+		return ""
+	}
+	return joinSymbol(base, "")
+}
+
+// chainParser tries each channel rule in order before falling back to the generic parser
+type chainParser struct {
+	parsers []Parser
+}
+
+// NewChainParser builds the fallback chain template rules → generic parser for a channel
+func NewChainParser(rules []models.ParserRule) (Parser, error) {
+	parsers := make([]Parser, 0, len(rules)+1)
+	for _, rule := range rules {
+		p, err := NewRuleParser(rule)
+		if err != nil {
+			return nil, err
+		}
+		parsers = append(parsers, p)
+	}
+	parsers = append(parsers, NewGenericParser())
+
+	return &chainParser{parsers: parsers}, nil
+}
+
+// ValidateRules reports the first rule that does not compile
+func ValidateRules(rules []models.ParserRule) error {
+	_, err := NewChainParser(rules)
+	return err
+}
+
+// Parse returns the result of the first parser that extracts a complete signal
+func (p *chainParser) Parse(text string) (*models.Signal, error) {
+	var firstErr error
+	for _, parser := range p.parsers {
+		sig, err := parser.Parse(text)
+		if err == nil {
+			return sig, nil
+		}
+		// Prefer the most specific failure over a plain "not a signal"
+		if firstErr == nil || errors.Is(firstErr, exceptions.ErrNotASignal) {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
@@ -13,6 +13,9 @@ type Cache interface {
 	// Set stores a key-value pair in cache with optional expiration
 	Set(ctx context.Context, key, value string, expiration time.Duration) error
 
+	// SetNX stores a key-value pair only if the key does not exist, reporting whether it was stored
+	SetNX(ctx context.Context, key, value string, expiration time.Duration) (bool, error)
+
 	// Delete removes a key from cache
 	Delete(ctx context.Context, key string) error
 
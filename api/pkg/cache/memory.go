@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryCache implements the Cache interface in process memory.
+// It is meant for tests and single-instance development; state is not shared between instances.
+type MemoryCache struct {
+	mu    sync.Mutex
+	items map[string]memoryItem
+	now   func() time.Time
+}
+
+type memoryItem struct {
+	value     string
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		items: make(map[string]memoryItem),
+		now:   time.Now,
+	}
+}
+
+// get returns a live item, evicting it if it has expired; callers must hold mu
+func (m *MemoryCache) get(key string) (memoryItem, bool) {
+	item, ok := m.items[key]
+	if !ok {
+		return memoryItem{}, false
+	}
+	if !item.expiresAt.IsZero() && !m.now().Before(item.expiresAt) {
+		delete(m.items, key)
+		return memoryItem{}, false
+	}
+	return item, true
+}
+
+func (m *MemoryCache) expiry(expiration time.Duration) time.Time {
+	if expiration <= 0 {
+		return time.Time{}
+	}
+	return m.now().Add(expiration)
+}
+
+// Get retrieves a value by key
+func (m *MemoryCache) Get(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.get(key)
+	if !ok {
+		return "", fmt.Errorf("key not found: %s", key)
+	}
+	return item.value, nil
+}
+
+// Set stores a key-value pair with optional expiration
+func (m *MemoryCache) Set(ctx context.Context, key, value string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items[key] = memoryItem{value: value, expiresAt: m.expiry(expiration)}
+	return nil
+}
+
+// SetNX stores a key-value pair only if the key does not exist yet
+func (m *MemoryCache) SetNX(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.get(key); ok {
+		return false, nil
+	}
+	m.items[key] = memoryItem{value: value, expiresAt: m.expiry(expiration)}
+	return true, nil
+}
+
+// Delete removes a key
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.items, key)
+	return nil
+}
+
+// Exists checks if a key exists
+func (m *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.get(key)
+	return ok, nil
+}
+
+// Expire sets expiration time for a key
+func (m *MemoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.get(key)
+	if !ok {
+		return fmt.Errorf("key not found: %s", key)
+	}
+	item.expiresAt = m.expiry(expiration)
+	m.items[key] = item
+	return nil
+}
+
+// TTL returns the remaining time to live for a key, -1 when it never expires and -2 when it does not exist
+func (m *MemoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.get(key)
+	switch {
+	case !ok:
+		return -2, nil
+	case item.expiresAt.IsZero():
+		return -1, nil
+	default:
+		return item.expiresAt.Sub(m.now()), nil
+	}
+}
+
+// Flush removes all keys
+func (m *MemoryCache) Flush(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items = make(map[string]memoryItem)
+	return nil
+}
+
+// Close is a no-op for the in-memory cache
+func (m *MemoryCache) Close() error {
+	return nil
+}
+
+// Ping always succeeds for the in-memory cache
+func (m *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
@@ -96,6 +96,15 @@ func (r *RedisCache) Set(ctx context.Context, key, value string, expiration time
 	return nil
 }
 
+// SetNX atomically stores a key-value pair only if the key does not exist yet
+func (r *RedisCache) SetNX(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set key %s if absent: %w", key, err)
+	}
+	return ok, nil
+}
+
 // Delete removes a key from Redis
 func (r *RedisCache) Delete(ctx context.Context, key string) error {
 	err := r.client.Del(ctx, key).Err()
@@ -0,0 +1,225 @@
+package unit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"copier/internal/exchange"
+)
+
+const (
+	testAPIKey    = "test-key"
+	testAPISecret = "test-secret"
+)
+
+// binanceStub verifies the API key and signature of signed requests before calling handle
+func binanceStub(t *testing.T, handle func(w http.ResponseWriter, r *http.Request)) (*exchange.BinanceFutures, *httptest.Server) {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if query := r.URL.RawQuery; strings.Contains(query, "signature=") {
+			if r.Header.Get("X-MBX-APIKEY") != testAPIKey {
+				t.Errorf("%s: missing API key header", r.URL.Path)
+			}
+			payload, sig, _ := strings.Cut(query, "&signature=")
+			mac := hmac.New(sha256.New, []byte(testAPISecret))
+			mac.Write([]byte(payload))
+			if sig != hex.EncodeToString(mac.Sum(nil)) {
+				t.Errorf("%s: bad signature", r.URL.Path)
+			}
+			if r.URL.Query().Get("recvWindow") != "7000" || r.URL.Query().Get("timestamp") == "" {
+				t.Errorf("%s: recvWindow/timestamp missing from %q", r.URL.Path, query)
+			}
+		}
+		handle(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := exchange.NewBinanceFutures(exchange.BinanceFuturesConfig{
+		BaseURL:    srv.URL,
+		APIKey:     testAPIKey,
+		APISecret:  testAPISecret,
+		RecvWindow: 7 * time.Second,
+		HTTPClient: srv.Client(),
+	})
+	return client, srv
+}
+
+func TestBinanceBalancesAndPositions(t *testing.T) {
+	client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v2/balance":
+			fmt.Fprint(w, `[{"asset":"USDT","balance":"1000.5","availableBalance":"800.25","crossUnPnl":"-3.5"}]`)
+		case "/fapi/v2/positionRisk":
+			fmt.Fprint(w, `[
+				{"symbol":"BTCUSDT","positionAmt":"-0.010","entryPrice":"60000","markPrice":"59000","unRealizedProfit":"10","leverage":"10","marginType":"isolated","liquidationPrice":"65000"},
+				{"symbol":"ETHUSDT","positionAmt":"0.000","entryPrice":"0","markPrice":"3000","unRealizedProfit":"0","leverage":"20","marginType":"cross","liquidationPrice":"0"}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	balances, err := client.Balances(context.Background())
+	if err != nil {
+		t.Fatalf("Balances: %v", err)
+	}
+	if len(balances) != 1 || balances[0].Asset != "USDT" || balances[0].Available != 800.25 || balances[0].UnrealizedPnL != -3.5 {
+		t.Errorf("balances = %+v", balances)
+	}
+
+	positions, err := client.Positions(context.Background())
+	if err != nil {
+		t.Fatalf("Positions: %v", err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("expected flat positions to be skipped, got %+v", positions)
+	}
+	if p := positions[0]; p.Symbol != "BTCUSDT" || p.Quantity != -0.01 || p.Leverage != 10 || p.MarginType != "isolated" {
+		t.Errorf("position = %+v", p)
+	}
+}
+
+func TestBinancePlaceOrderParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		req  exchange.OrderRequest
+		want map[string]string
+		none []string
+	}{
+		{
+			name: "limit entry",
+			req:  exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 0.015, Price: 61000.5, ClientOrderID: "cp-1"},
+			want: map[string]string{"symbol": "BTCUSDT", "side": "BUY", "type": "LIMIT", "timeInForce": "GTC", "price": "61000.5", "quantity": "0.015", "newClientOrderId": "cp-1"},
+			none: []string{"stopPrice", "reduceOnly"},
+		},
+		{
+			name: "reduce-only stop",
+			req:  exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideSell, Type: exchange.OrderTypeStopMarket, Quantity: 0.015, Price: 59000, ReduceOnly: true},
+			want: map[string]string{"type": "STOP_MARKET", "stopPrice": "59000", "reduceOnly": "true", "quantity": "0.015"},
+			none: []string{"price", "timeInForce"},
+		},
+		{
+			name: "close position take profit",
+			req:  exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideSell, Type: exchange.OrderTypeTakeProfit, Price: 65000, ClosePosition: true},
+			want: map[string]string{"type": "TAKE_PROFIT_MARKET", "stopPrice": "65000", "closePosition": "true"},
+			none: []string{"quantity", "reduceOnly"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/fapi/v1/order" {
+					t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+					return
+				}
+				q := r.URL.Query()
+				for k, v := range tt.want {
+					if q.Get(k) != v {
+						t.Errorf("%s = %q, want %q", k, q.Get(k), v)
+					}
+				}
+				for _, k := range tt.none {
+					if q.Has(k) {
+						t.Errorf("unexpected parameter %s=%q", k, q.Get(k))
+					}
+				}
+				fmt.Fprint(w, `{"orderId":42,"clientOrderId":"cp-1","symbol":"BTCUSDT","side":"BUY","type":"LIMIT","status":"NEW","price":"61000.5","origQty":"0.015","executedQty":"0","avgPrice":"0","updateTime":1700000000000}`)
+			})
+
+			order, err := client.PlaceOrder(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("PlaceOrder: %v", err)
+			}
+			if order.OrderID != "42" || order.Status != exchange.OrderStatusNew || !order.Status.Open() {
+				t.Errorf("order = %+v", order)
+			}
+		})
+	}
+}
+
+func TestBinanceErrors(t *testing.T) {
+	client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		switch r.URL.Path {
+		case "/fapi/v1/order":
+			fmt.Fprint(w, `{"code":-2011,"msg":"Unknown order sent."}`)
+		default:
+			fmt.Fprint(w, `{"code":-2019,"msg":"Margin is insufficient."}`)
+		}
+	})
+
+	err := client.CancelOrder(context.Background(), "BTCUSDT", "1")
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("cancel of unknown order: got %v, want ErrOrderNotFound", err)
+	}
+
+	err = client.SetLeverage(context.Background(), "BTCUSDT", 10)
+	var apiErr *exchange.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != -2019 || apiErr.Status != http.StatusBadRequest {
+		t.Errorf("leverage error = %v, want APIError -2019", err)
+	}
+}
+
+func TestBinanceResyncsClockOutsideRecvWindow(t *testing.T) {
+	var orders atomic.Int32
+	client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/time":
+			fmt.Fprintf(w, `{"serverTime":%d}`, time.Now().Add(time.Hour).UnixMilli())
+		case "/fapi/v1/openOrders":
+			orders.Add(1)
+			var ts int64
+			fmt.Sscan(r.URL.Query().Get("timestamp"), &ts)
+			if time.Until(time.UnixMilli(ts)) < 30*time.Minute {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	if _, err := client.OpenOrders(context.Background(), "BTCUSDT"); err != nil {
+		t.Fatalf("OpenOrders: %v", err)
+	}
+	if orders.Load() != 2 {
+		t.Errorf("expected one retry after clock sync, got %d requests", orders.Load())
+	}
+}
+
+func TestBinanceSymbolInfo(t *testing.T) {
+	client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("signature") {
+			t.Errorf("exchange info should not be signed")
+		}
+		fmt.Fprint(w, `{"symbols":[{"symbol":"BTCUSDT","status":"TRADING","baseAsset":"BTC","quoteAsset":"USDT","filters":[
+			{"filterType":"PRICE_FILTER","tickSize":"0.10","minPrice":"556.80","maxPrice":"4529764"},
+			{"filterType":"LOT_SIZE","stepSize":"0.001","minQty":"0.001","maxQty":"1000"},
+			{"filterType":"MIN_NOTIONAL","notional":"100"}
+		]}]}`)
+	})
+
+	info, err := client.SymbolInfo(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("SymbolInfo: %v", err)
+	}
+	if info.TickSize != 0.1 || info.StepSize != 0.001 || info.MinQty != 0.001 || info.MinNotional != 100 || info.QuoteAsset != "USDT" {
+		t.Errorf("info = %+v", info)
+	}
+
+	if _, err := client.SymbolInfo(context.Background(), "NOPEUSDT"); !errors.Is(err, exchange.ErrUnknownSymbol) {
+		t.Errorf("unknown symbol: got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"sync"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+
+	"github.com/google/uuid"
+)
+
+func TestAllocateWithoutRoutesUsesEveryPlatform(t *testing.T) {
+	platforms := []*models.Platform{{ID: uuid.New(), Name: "main"}, {ID: uuid.New(), Name: "sub"}}
+	settings := &models.TradeSettings{PerTradeAmount: 100}
+
+	allocations := execution.Allocate(platforms, nil, settings)
+	if len(allocations) != 2 {
+		t.Fatalf("expected 2 allocations, got %d", len(allocations))
+	}
+	for i, allocation := range allocations {
+		if allocation.Platform != platforms[i] || allocation.Settings != settings {
+			t.Errorf("allocation %d: expected platform %s with the user's settings", i, platforms[i].Name)
+		}
+	}
+}
+
+func TestAllocateFollowsRoutes(t *testing.T) {
+	main := &models.Platform{ID: uuid.New(), Name: "main"}
+	sub := &models.Platform{ID: uuid.New(), Name: "sub"}
+	paper := &models.Platform{ID: uuid.New(), Name: "paper"}
+	settings := &models.TradeSettings{SizingMode: models.SizingModeBalancePercentage, BalancePercentage: 10}
+
+	routes := []*models.ChannelRoute{
+		{PlatformID: sub.ID, Weight: 50},
+		{PlatformID: main.ID, FixedAmount: 250},
+		{PlatformID: paper.ID, Disabled: true},
+		{PlatformID: uuid.New()},
+	}
+	allocations := execution.Allocate([]*models.Platform{main, sub, paper}, routes, settings)
+	if len(allocations) != 2 {
+		t.Fatalf("expected 2 allocations, got %d", len(allocations))
+	}
+	if allocations[0].Platform != sub || allocations[0].Settings.BalancePercentage != 5 {
+		t.Errorf("expected sub at 5%% of balance, got %s at %v%%", allocations[0].Platform.Name, allocations[0].Settings.BalancePercentage)
+	}
+	if allocations[1].Platform != main || allocations[1].Settings.SizingMode != models.SizingModeFixedAmount || allocations[1].Settings.PerTradeAmount != 250 {
+		t.Errorf("expected main with a fixed amount of 250, got %s %v", allocations[1].Settings.SizingMode, allocations[1].Settings.PerTradeAmount)
+	}
+	if settings.BalancePercentage != 10 || settings.SizingMode != models.SizingModeBalancePercentage {
+		t.Error("allocating must not change the user's settings")
+	}
+}
+
+func TestAllocateSettingsWeight(t *testing.T) {
+	settings := &models.TradeSettings{
+		PerTradeAmount:    100,
+		BalancePercentage: 80,
+		RiskAmount:        20,
+		FixedQuantity:     2,
+		MaxNotional:       500,
+	}
+
+	allocated := execution.AllocateSettings(settings, &models.ChannelRoute{Weight: 150})
+	if allocated.PerTradeAmount != 150 || allocated.RiskAmount != 30 || allocated.FixedQuantity != 3 {
+		t.Errorf("expected sizes scaled by 1.5, got amount %v risk %v quantity %v", allocated.PerTradeAmount, allocated.RiskAmount, allocated.FixedQuantity)
+	}
+	if allocated.BalancePercentage != 100 {
+		t.Errorf("expected balance share capped at 100%%, got %v", allocated.BalancePercentage)
+	}
+	if allocated.MaxNotional != 500 {
+		t.Errorf("expected max notional unchanged, got %v", allocated.MaxNotional)
+	}
+
+	unchanged := execution.AllocateSettings(settings, &models.ChannelRoute{})
+	if unchanged.PerTradeAmount != 100 || unchanged.BalancePercentage != 80 {
+		t.Errorf("expected a route without allocation to keep the settings, got %+v", unchanged)
+	}
+}
+
+func TestRiskBookReserveIsAtomic(t *testing.T) {
+	book := execution.NewRiskBook()
+	limits := models.RiskLimits{MaxOpenPositions: 3}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		reserved int
+	)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if book.Reserve(limits, "BTCUSDT", models.SignalSideLong, 100) == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved != 3 || book.OpenPositions != 3 {
+		t.Fatalf("expected 3 reservations, got %d with %d open positions", reserved, book.OpenPositions)
+	}
+
+	book.Release("BTCUSDT", models.SignalSideLong, 100)
+	if book.OpenPositions != 2 || book.TotalNotional != 200 || book.SymbolNotional["BTCUSDT"] != 200 {
+		t.Errorf("expected the release to free one position, got %+v", book)
+	}
+	if violation := book.Reserve(limits, "ETHUSDT", models.SignalSideShort, 100); violation != nil {
+		t.Errorf("expected a released slot to be reservable, got %v", violation)
+	}
+}
@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+
+	"github.com/google/uuid"
+)
+
+func TestClientOrderIDIsDeterministic(t *testing.T) {
+	signalID, positionID := uuid.New(), uuid.New()
+
+	id := execution.ClientOrderID(signalID, positionID, models.OrderPurposeTakeProfit, 2, 0)
+	if again := execution.ClientOrderID(signalID, positionID, models.OrderPurposeTakeProfit, 2, 0); again != id {
+		t.Errorf("expected the same leg to get the same ID, got %s and %s", id, again)
+	}
+	if len(id) > 36 || !execution.IsCopierClientOrderID(id) {
+		t.Errorf("expected a copier ID of at most 36 characters, got %q", id)
+	}
+
+	others := []string{
+		execution.ClientOrderID(signalID, positionID, models.OrderPurposeTakeProfit, 3, 0),
+		execution.ClientOrderID(signalID, positionID, models.OrderPurposeStopLoss, 2, 0),
+		execution.ClientOrderID(signalID, positionID, models.OrderPurposeTakeProfit, 2, 1),
+		execution.ClientOrderID(signalID, uuid.New(), models.OrderPurposeTakeProfit, 2, 0),
+	}
+	for _, other := range others {
+		if other == id {
+			t.Errorf("expected a different leg to get a different ID, both got %s", id)
+		}
+	}
+}
+
+func TestPaperClientOrderIDLookup(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{})
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 100})
+
+	req := exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 1, Price: 90, ClientOrderID: "cp-e1-abc"}
+	placed, err := paper.PlaceOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("place order: %v", err)
+	}
+
+	// a retry of the same leg is refused while the first order rests
+	if _, err := paper.PlaceOrder(ctx, req); !errors.Is(err, exchange.ErrDuplicateClientOrderID) {
+		t.Errorf("expected ErrDuplicateClientOrderID, got %v", err)
+	}
+	found, err := paper.GetOrderByClientID(ctx, "btcusdt", req.ClientOrderID)
+	if err != nil || found.OrderID != placed.OrderID {
+		t.Errorf("expected order %s found by client ID, got %+v (%v)", placed.OrderID, found, err)
+	}
+	if _, err := paper.GetOrderByClientID(ctx, "BTCUSDT", "cp-e1-unknown"); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound, got %v", err)
+	}
+}
+
+func TestBinanceGetOrderByClientID(t *testing.T) {
+	client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/order" || r.URL.Query().Get("origClientOrderId") != "cp-e1-abc" {
+			t.Errorf("unexpected %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"orderId":42,"clientOrderId":"cp-e1-abc","symbol":"BTCUSDT","side":"BUY","type":"LIMIT","status":"FILLED","price":"100","origQty":"1","executedQty":"1","avgPrice":"100","updateTime":1700000000000}`)
+	})
+
+	order, err := client.GetOrderByClientID(context.Background(), "BTCUSDT", "cp-e1-abc")
+	if err != nil {
+		t.Fatalf("GetOrderByClientID: %v", err)
+	}
+	if order.OrderID != "42" || order.Status != exchange.OrderStatusFilled {
+		t.Errorf("order = %+v", order)
+	}
+}
+
+func TestOutcomeUnknown(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rejected by the exchange", &exchange.APIError{Status: 400, Code: -2019}, false},
+		{"exchange internal error", &exchange.APIError{Status: 503, Code: -1000}, true},
+		{"timeout", &url.Error{Op: "Post", URL: "https://fapi.binance.com", Err: context.DeadlineExceeded}, true},
+		{"below minimum", exceptions.ErrBelowMinimum, false},
+		{"no error", nil, false},
+	}
+
+	for _, tc := range cases {
+		if got := exchange.OutcomeUnknown(tc.err); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+)
+
+func TestDecideEntryAgainstEntryRange(t *testing.T) {
+	stop := 95.0
+	long := &models.Signal{
+		Symbol:    "SOLUSDT",
+		Side:      models.SignalSideLong,
+		EntryLow:  99,
+		EntryHigh: 100,
+		Targets:   []float64{110, 120},
+		StopLoss:  &stop,
+	}
+
+	cases := []struct {
+		name  string
+		sig   *models.Signal
+		price float64
+		mode  execution.EntryMode
+		at    float64
+	}{
+		{"inside range", long, 99.5, execution.EntryMarket, 99.5},
+		{"below range of a long", long, 97, execution.EntryMarket, 97},
+		{"within over range", long, 101.5, execution.EntryMarket, 101.5},
+		{"beyond over range", long, 104, execution.EntryLimit, 100},
+		{"stop already hit", long, 94, execution.EntrySkip, 94},
+		{"first target already hit", long, 111, execution.EntrySkip, 111},
+		{"beyond over range of a short", &models.Signal{Side: models.SignalSideShort, EntryLow: 50, EntryHigh: 51, Targets: []float64{40}}, 47, execution.EntryLimit, 50},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := execution.DecideEntry(tc.sig, tc.price, 2)
+			if decision.Mode != tc.mode || decision.Price != tc.at {
+				t.Errorf("expected %s at %v, got %+v", tc.mode, tc.at, decision)
+			}
+			if tc.mode != execution.EntryMarket && decision.Reason == "" {
+				t.Errorf("expected a reason for %s entry", decision.Mode)
+			}
+		})
+	}
+}
+
+func TestPlanOrdersAtMarketPrice(t *testing.T) {
+	sig := &models.Signal{
+		Symbol:    "PEPEUSDT",
+		Side:      models.SignalSideShort,
+		EntryLow:  0.0000100,
+		EntryHigh: 0.0000102,
+		Targets:   []float64{0.0000090},
+	}
+	settings := &models.TradeSettings{
+		PerTradeAmount:     100,
+		StopLossStatus:     true,
+		StopLossPercentage: 5,
+		TakeProfitStatus:   true,
+		TakeProfitStep:     1,
+		TPPercentage:       []float64{100},
+	}
+
+	scaled := execution.ScaleSignal(sig, "1000PEPEUSDT", 1000)
+	if scaled.Symbol != "1000PEPEUSDT" || math.Abs(scaled.Targets[0]-0.009) > 1e-12 || sig.Targets[0] != 0.0000090 {
+		t.Fatalf("unexpected scaled signal: %+v", scaled)
+	}
+
+	plan := execution.PlanOrdersAt(scaled, settings, execution.DecideEntry(scaled, 0.0101, 0), execution.Account{})
+	if len(plan.Orders) != 3 {
+		t.Fatalf("expected entry, stop and take profit, got %+v", plan.Orders)
+	}
+
+	entry := plan.Orders[0]
+	if entry.Type != execution.OrderTypeMarket || entry.Side != execution.OrderSideSell || entry.Symbol != "1000PEPEUSDT" {
+		t.Errorf("unexpected entry order: %+v", entry)
+	}
+	if got := entry.Quantity * entry.Price; got < 99.999 || got > 100.001 {
+		t.Errorf("expected 100 USDT notional, got %v", got)
+	}
+	if sl := plan.Orders[1]; sl.Side != execution.OrderSideBuy || sl.Price <= entry.Price {
+		t.Errorf("expected a buy stop above entry for a short, got %+v", sl)
+	}
+}
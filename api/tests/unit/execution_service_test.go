@@ -0,0 +1,376 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/services"
+	"copier/internal/signal"
+	"copier/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// executionStore backs the fake repositories the execution service reads and writes
+type executionStore struct {
+	signals   []*models.Signal
+	actions   []*models.SignalAction
+	orders    []*models.Order
+	positions []*models.Position
+	events    []*models.PositionEvent
+	platform  *models.Platform
+}
+
+type executionSignalRepo struct {
+	repositories.SignalRepository
+	store *executionStore
+}
+
+func (r *executionSignalRepo) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Signal, error) {
+	for _, sig := range r.store.signals {
+		if sig.ID == id {
+			return sig, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *executionSignalRepo) FindPendingActions(ctx context.Context, types []models.SignalActionType, limit int) ([]*models.SignalAction, error) {
+	var pending []*models.SignalAction
+	for _, action := range r.store.actions {
+		if action.Status == models.SignalActionStatusPending && slices.Contains(types, action.Type) {
+			pending = append(pending, action)
+		}
+	}
+	return pending, nil
+}
+
+func (r *executionSignalRepo) UpdateActionColumns(ctx context.Context, id uuid.UUID, update *models.SignalAction, columns ...string) error {
+	for _, action := range r.store.actions {
+		if action.ID == id {
+			action.Status, action.Note, action.AppliedAt = update.Status, update.Note, update.AppliedAt
+		}
+	}
+	return nil
+}
+
+type executionOrderRepo struct {
+	repositories.OrderRepository
+	store *executionStore
+}
+
+func (r *executionOrderRepo) CreateOrder(ctx context.Context, order *models.Order) error {
+	order.ID = uuid.New()
+	r.store.orders = append(r.store.orders, order)
+	return nil
+}
+
+func (r *executionOrderRepo) FindByPosition(ctx context.Context, positionID uuid.UUID) ([]*models.Order, error) {
+	var orders []*models.Order
+	for _, order := range r.store.orders {
+		if order.PositionID != nil && *order.PositionID == positionID {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+// UpdateOrderColumns has nothing to copy: the service updates the stored orders it was handed
+func (r *executionOrderRepo) UpdateOrderColumns(ctx context.Context, id uuid.UUID, update *models.Order, columns ...string) error {
+	return nil
+}
+
+type executionPositionRepo struct {
+	repositories.PositionRepository
+	store *executionStore
+}
+
+func (r *executionPositionRepo) FindActiveBySignal(ctx context.Context, signalID uuid.UUID) ([]*models.Position, error) {
+	var active []*models.Position
+	for _, pos := range r.store.positions {
+		if pos.SignalID == signalID && slices.Contains(models.ActivePositionStatuses, pos.Status) {
+			active = append(active, pos)
+		}
+	}
+	return active, nil
+}
+
+func (r *executionPositionRepo) SaveTransition(ctx context.Context, pos *models.Position, from models.PositionStatus, event *models.PositionEvent, columns ...string) (bool, error) {
+	r.store.events = append(r.store.events, event)
+	return true, nil
+}
+
+type executionPlatformRepo struct {
+	repositories.PlatformRepository
+	store *executionStore
+}
+
+func (r *executionPlatformRepo) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Platform, error) {
+	return r.store.platform, nil
+}
+
+// executionPlatforms hands out the paper exchange of the test platform
+type executionPlatforms struct {
+	services.PlatformService
+	client exchange.ExchangeClient
+}
+
+func (p *executionPlatforms) Client(platform *models.Platform) (exchange.ExchangeClient, error) {
+	return p.client, nil
+}
+
+// executionSymbols trades every signal symbol as it is written
+type executionSymbols struct {
+	services.SymbolService
+}
+
+func (s *executionSymbols) Resolve(ctx context.Context, exchange models.Exchange, raw string) (signal.ResolvedSymbol, error) {
+	return signal.ResolvedSymbol{Symbol: strings.ToUpper(raw), Multiplier: 1}, nil
+}
+
+// newExecutionHarness creates an execution service trading on paper through fake repositories
+func newExecutionHarness(paper *exchange.PaperExchange) (*executionStore, services.ExecutionService) {
+	store := &executionStore{platform: &models.Platform{ID: uuid.New(), UserID: uuid.New(), Name: "paper", Paper: true}}
+	service := services.NewExecutionService(
+		&executionSignalRepo{store: store},
+		&executionOrderRepo{store: store},
+		&executionPositionRepo{store: store},
+		nil,
+		&executionPlatformRepo{store: store},
+		nil,
+		&executionPlatforms{client: paper},
+		&executionSymbols{},
+		services.NewSymbolInfoService(cache.NewMemoryCache(), 0),
+		services.NewKillSwitch(nil),
+		nil,
+		0,
+	)
+	return store, service
+}
+
+// openPaperLong opens a long position of quantity at price on paper, protected by a working stop
+func openPaperLong(t *testing.T, store *executionStore, paper *exchange.PaperExchange, sig *models.Signal, quantity, price, stop float64) *models.Position {
+	t.Helper()
+	ctx := context.Background()
+	paper.Tick(exchange.Tick{Symbol: sig.Symbol, Price: price})
+	if _, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: sig.Symbol, Side: exchange.SideBuy, Type: exchange.OrderTypeMarket, Quantity: quantity}); err != nil {
+		t.Fatalf("open position: %v", err)
+	}
+	placed, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: sig.Symbol, Side: exchange.SideSell, Type: exchange.OrderTypeStopMarket, Quantity: quantity, Price: stop, ReduceOnly: true})
+	if err != nil {
+		t.Fatalf("place stop: %v", err)
+	}
+
+	pos := &models.Position{
+		ID:                uuid.New(),
+		UserID:            sig.UserID,
+		SignalID:          sig.ID,
+		PlatformID:        store.platform.ID,
+		Symbol:            sig.Symbol,
+		Side:              models.SignalSideLong,
+		Status:            models.PositionStatusOpen,
+		Quantity:          quantity,
+		FilledQuantity:    quantity,
+		ProtectedQuantity: quantity,
+		EntryPrice:        price,
+		StopPrice:         stop,
+	}
+	store.signals = append(store.signals, sig)
+	store.positions = append(store.positions, pos)
+	store.orders = append(store.orders, &models.Order{
+		ID:              uuid.New(),
+		UserID:          sig.UserID,
+		SignalID:        sig.ID,
+		PlatformID:      store.platform.ID,
+		PositionID:      &pos.ID,
+		Purpose:         models.OrderPurposeStopLoss,
+		Leg:             1,
+		Symbol:          sig.Symbol,
+		Side:            string(exchange.SideSell),
+		Type:            string(exchange.OrderTypeStopMarket),
+		Price:           stop,
+		Quantity:        quantity,
+		ReduceOnly:      true,
+		Status:          models.OrderStatusNew,
+		ExchangeOrderID: placed.OrderID,
+	})
+	return pos
+}
+
+func TestApplyActionsMovesStop(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	store, service := newExecutionHarness(paper)
+
+	sig := &models.Signal{ID: uuid.New(), UserID: store.platform.UserID, Symbol: "BTCUSDT", Side: models.SignalSideLong, Status: models.SignalStatusActive}
+	pos := openPaperLong(t, store, paper, sig, 2, 100, 90)
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 110})
+
+	entry := 100.0
+	action := &models.SignalAction{ID: uuid.New(), SignalID: sig.ID, Type: models.SignalActionMoveStop, StopPrice: &entry, BreakEven: true, Status: models.SignalActionStatusPending}
+	store.actions = append(store.actions, action)
+
+	if err := service.ApplyActions(ctx); err != nil {
+		t.Fatalf("apply actions: %v", err)
+	}
+	if action.Status != models.SignalActionStatusApplied {
+		t.Fatalf("expected the action applied, got %s: %s", action.Status, action.Note)
+	}
+
+	open, err := paper.OpenOrders(ctx, "BTCUSDT")
+	if err != nil {
+		t.Fatalf("open orders: %v", err)
+	}
+	if len(open) != 1 || open[0].Type != exchange.OrderTypeStopMarket || open[0].StopPrice != 100 || open[0].Quantity != 2 {
+		t.Fatalf("expected one stop for 2 at 100 on the exchange, got %+v", open)
+	}
+	if store.orders[0].Status != models.OrderStatusCancelled {
+		t.Errorf("expected the previous stop cancelled, got %s", store.orders[0].Status)
+	}
+	if pos.StopPrice != 100 {
+		t.Errorf("expected the position's stop at 100, got %v", pos.StopPrice)
+	}
+}
+
+func TestApplyActionsRepricesWaitingStop(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	store, service := newExecutionHarness(paper)
+
+	sig := &models.Signal{ID: uuid.New(), UserID: store.platform.UserID, Symbol: "ETHUSDT", Side: models.SignalSideLong, Status: models.SignalStatusActive}
+	pos := &models.Position{ID: uuid.New(), SignalID: sig.ID, PlatformID: store.platform.ID, Symbol: "ETHUSDT", Side: models.SignalSideLong, Status: models.PositionStatusPendingEntry, Quantity: 1, StopPrice: 9}
+	stop := &models.Order{ID: uuid.New(), PositionID: &pos.ID, Purpose: models.OrderPurposeStopLoss, Leg: 1, Symbol: "ETHUSDT", Price: 9, Quantity: 1, Status: models.OrderStatusPending}
+	store.signals = append(store.signals, sig)
+	store.positions = append(store.positions, pos)
+	store.orders = append(store.orders, stop)
+
+	target := 12.0
+	action := &models.SignalAction{ID: uuid.New(), SignalID: sig.ID, Type: models.SignalActionMoveStop, StopPrice: &target, Status: models.SignalActionStatusPending}
+	store.actions = append(store.actions, action)
+
+	if err := service.ApplyActions(ctx); err != nil {
+		t.Fatalf("apply actions: %v", err)
+	}
+	if action.Status != models.SignalActionStatusApplied {
+		t.Fatalf("expected the action applied, got %s: %s", action.Status, action.Note)
+	}
+	if stop.Price != 12 || stop.SubmittedAt != nil || pos.StopPrice != 12 {
+		t.Errorf("expected the waiting stop repriced to 12 and not sent, got %v (sent %v), position %v", stop.Price, stop.SubmittedAt != nil, pos.StopPrice)
+	}
+}
+
+// staleTransitionRepo refuses every transition, as if another worker had moved the position on
+// to stored first
+type staleTransitionRepo struct {
+	executionPositionRepo
+	stored *models.Position
+}
+
+func (r *staleTransitionRepo) SaveTransition(ctx context.Context, pos *models.Position, from models.PositionStatus, event *models.PositionEvent, columns ...string) (bool, error) {
+	return false, nil
+}
+
+func (r *staleTransitionRepo) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Position, error) {
+	stored := *r.stored
+	return &stored, nil
+}
+
+func TestStaleTransitionKeepsStoredPosition(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	store, _ := newExecutionHarness(paper)
+
+	sig := &models.Signal{ID: uuid.New(), Symbol: "ETHUSDT", Side: models.SignalSideLong, Status: models.SignalStatusActive}
+	pos := &models.Position{ID: uuid.New(), SignalID: sig.ID, PlatformID: store.platform.ID, Symbol: "ETHUSDT", Side: models.SignalSideLong, Status: models.PositionStatusPendingEntry, Quantity: 1, StopPrice: 9}
+	store.signals = append(store.signals, sig)
+	store.positions = append(store.positions, pos)
+
+	// the expiry worker cancelled the position after it was loaded here
+	cancelled := *pos
+	cancelled.Status = models.PositionStatusCancelled
+	cancelled.CloseReason = "entry timed out"
+
+	service := services.NewExecutionService(
+		&executionSignalRepo{store: store},
+		&executionOrderRepo{store: store},
+		&staleTransitionRepo{executionPositionRepo: executionPositionRepo{store: store}, stored: &cancelled},
+		nil,
+		&executionPlatformRepo{store: store},
+		nil,
+		&executionPlatforms{client: paper},
+		&executionSymbols{},
+		services.NewSymbolInfoService(cache.NewMemoryCache(), 0),
+		services.NewKillSwitch(nil),
+		nil,
+		0,
+	)
+
+	target := 12.0
+	store.actions = append(store.actions, &models.SignalAction{ID: uuid.New(), SignalID: sig.ID, Type: models.SignalActionMoveStop, StopPrice: &target, Status: models.SignalActionStatusPending})
+	if err := service.ApplyActions(ctx); err != nil {
+		t.Fatalf("apply actions: %v", err)
+	}
+
+	if pos.Status != models.PositionStatusCancelled || pos.StopPrice != 9 || pos.CloseReason != "entry timed out" {
+		t.Errorf("expected the position to continue from the stored cancellation, got %s stop %v %q", pos.Status, pos.StopPrice, pos.CloseReason)
+	}
+}
+
+// trailingPositionRepo lists every stored position as trailing
+type trailingPositionRepo struct {
+	executionPositionRepo
+}
+
+func (r *trailingPositionRepo) FindTrailing(ctx context.Context, limit int) ([]*models.Position, error) {
+	return r.store.positions, nil
+}
+
+// failingOrderRepo cannot record new orders
+type failingOrderRepo struct {
+	executionOrderRepo
+}
+
+func (r *failingOrderRepo) CreateOrder(ctx context.Context, order *models.Order) error {
+	return errors.New("connection reset")
+}
+
+func TestTrailStopsReportsFailedMove(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	store, _ := newExecutionHarness(paper)
+
+	sig := &models.Signal{ID: uuid.New(), UserID: store.platform.UserID, Symbol: "BTCUSDT", Side: models.SignalSideLong, Status: models.SignalStatusActive}
+	pos := openPaperLong(t, store, paper, sig, 2, 100, 90)
+	pos.TargetsHit = 1
+	pos.StopRules.TrailingStopPercentage = 5
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 120})
+
+	service := services.NewExecutionService(
+		&executionSignalRepo{store: store},
+		&failingOrderRepo{executionOrderRepo: executionOrderRepo{store: store}},
+		&trailingPositionRepo{executionPositionRepo: executionPositionRepo{store: store}},
+		nil,
+		&executionPlatformRepo{store: store},
+		nil,
+		&executionPlatforms{client: paper},
+		&executionSymbols{},
+		services.NewSymbolInfoService(cache.NewMemoryCache(), 0),
+		services.NewKillSwitch(nil),
+		nil,
+		0,
+	)
+
+	if err := service.TrailStops(ctx); err == nil || !strings.Contains(err.Error(), pos.ID.String()) {
+		t.Fatalf("expected the failed trail reported for the position, got %v", err)
+	}
+	if pos.StopPrice != 90 || len(store.events) != 0 {
+		t.Errorf("expected the previous stop kept, got %v with %d events", pos.StopPrice, len(store.events))
+	}
+}
@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+	"copier/internal/services"
+
+	"github.com/google/uuid"
+)
+
+func TestResolveExpiry(t *testing.T) {
+	settings := models.ExpiryRules{EntryTimeoutMinutes: 720, MaxHoldingHours: 72}
+
+	if got := execution.ResolveExpiry(models.ExpiryRules{}, settings); got != settings {
+		t.Errorf("expected the user's rules without channel overrides, got %+v", got)
+	}
+
+	channel := models.ExpiryRules{EntryTimeoutMinutes: 60, CancelEntryAtTP1: true}
+	want := models.ExpiryRules{EntryTimeoutMinutes: 60, CancelEntryAtTP1: true, MaxHoldingHours: 72}
+	if got := execution.ResolveExpiry(channel, settings); got != want {
+		t.Errorf("expected the channel's limits to win, got %+v, want %+v", got, want)
+	}
+}
+
+func TestExpiryDeadlines(t *testing.T) {
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rules := models.ExpiryRules{EntryTimeoutMinutes: 90, MaxHoldingHours: 48}
+
+	if got := execution.EntryDeadline(rules, from); got == nil || !got.Equal(from.Add(90*time.Minute)) {
+		t.Errorf("unexpected entry deadline %v", got)
+	}
+	if got := execution.HoldingDeadline(rules, from); got == nil || !got.Equal(from.Add(48*time.Hour)) {
+		t.Errorf("unexpected holding deadline %v", got)
+	}
+	if execution.EntryDeadline(models.ExpiryRules{}, from) != nil || execution.HoldingDeadline(models.ExpiryRules{}, from) != nil {
+		t.Error("expected no deadlines without rules")
+	}
+}
+
+func TestReachedTarget(t *testing.T) {
+	cases := []struct {
+		name   string
+		side   models.SignalSide
+		target float64
+		price  float64
+		want   bool
+	}{
+		{"long below target", models.SignalSideLong, 110, 105, false},
+		{"long at target", models.SignalSideLong, 110, 110, true},
+		{"short above target", models.SignalSideShort, 90, 95, false},
+		{"short through target", models.SignalSideShort, 90, 88, true},
+		{"no target", models.SignalSideLong, 0, 105, false},
+	}
+
+	for _, tc := range cases {
+		if got := execution.ReachedTarget(tc.side, tc.target, tc.price); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestOpeningSetsHoldingDeadline(t *testing.T) {
+	pos := &models.Position{Side: models.SignalSideLong, Status: models.PositionStatusPendingEntry, Quantity: 1, Expiry: models.ExpiryRules{MaxHoldingHours: 24}}
+
+	if err := execution.ApplyEntryFill(pos, 1, 100, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos.OpenedAt == nil || pos.ExpiresAt == nil || !pos.ExpiresAt.Equal(pos.OpenedAt.Add(24*time.Hour)) {
+		t.Errorf("expected the position to expire 24h after opening, got opened %v expires %v", pos.OpenedAt, pos.ExpiresAt)
+	}
+
+	unlimited := &models.Position{Side: models.SignalSideLong, Status: models.PositionStatusPendingEntry, Quantity: 1}
+	if err := execution.ApplyEntryFill(unlimited, 1, 100, true); err != nil || unlimited.ExpiresAt != nil {
+		t.Errorf("expected no expiry without a holding limit, got %v (%v)", unlimited.ExpiresAt, err)
+	}
+}
+
+// awaitingTP1Repo lists positions waiting on entries that are cancelled at TP1
+type awaitingTP1Repo struct {
+	repositories.PositionRepository
+	positions []*models.Position
+}
+
+func (r *awaitingTP1Repo) FindEntryExpired(ctx context.Context, now time.Time, limit int) ([]*models.Position, error) {
+	return nil, nil
+}
+
+func (r *awaitingTP1Repo) FindHoldingExpired(ctx context.Context, now time.Time, limit int) ([]*models.Position, error) {
+	return nil, nil
+}
+
+func (r *awaitingTP1Repo) FindAwaitingEntryAtTP1(ctx context.Context, limit int) ([]*models.Position, error) {
+	return r.positions, nil
+}
+
+// cancellingExecutor records the positions whose entries were cancelled
+type cancellingExecutor struct {
+	services.ExecutionService
+	cancelled []uuid.UUID
+}
+
+func (e *cancellingExecutor) CancelEntries(ctx context.Context, pos *models.Position, reason string) error {
+	e.cancelled = append(e.cancelled, pos.ID)
+	return nil
+}
+
+func TestCancelEntryAtTP1WithoutTakeProfitOrders(t *testing.T) {
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 62100})
+
+	platform := &models.Platform{ID: uuid.New(), Paper: true}
+	// take profit orders are disabled, so the position only has its entry and stop loss
+	pos := &models.Position{
+		ID:          uuid.New(),
+		PlatformID:  platform.ID,
+		Symbol:      "BTCUSDT",
+		Side:        models.SignalSideLong,
+		Status:      models.PositionStatusPendingEntry,
+		FirstTarget: 62000,
+		Expiry:      models.ExpiryRules{CancelEntryAtTP1: true},
+		Orders: []models.Order{
+			{Purpose: models.OrderPurposeEntry, Leg: 1, Price: 61000},
+			{Purpose: models.OrderPurposeStopLoss, Price: 60000},
+		},
+	}
+	executor := &cancellingExecutor{}
+	service := services.NewExpiryService(
+		&awaitingTP1Repo{positions: []*models.Position{pos}},
+		&executionPlatformRepo{store: &executionStore{platform: platform}},
+		&executionPlatforms{client: paper},
+		executor,
+	)
+
+	report, err := service.EnforceExpiry(context.Background())
+	if err != nil {
+		t.Fatalf("enforce expiry: %v", err)
+	}
+	if report.EntriesPassedTP1 != 1 || len(executor.cancelled) != 1 || executor.cancelled[0] != pos.ID {
+		t.Errorf("expected the entry cancelled once the price passed TP1, got %+v", report)
+	}
+}
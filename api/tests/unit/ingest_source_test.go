@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"copier/internal/ingest"
+	"copier/internal/signal"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"text":"BTC/USDT LONG entry 61000 sl 60000"}`)
+	sig := ingest.Sign("s3cret", body)
+
+	if !ingest.VerifySignature("s3cret", body, sig) {
+		t.Error("valid signature rejected")
+	}
+	if !ingest.VerifySignature("s3cret", body, "sha256="+sig) {
+		t.Error("prefixed signature rejected")
+	}
+	if ingest.VerifySignature("other", body, sig) {
+		t.Error("signature accepted with wrong secret")
+	}
+	if ingest.VerifySignature("s3cret", append(body, ' '), sig) {
+		t.Error("signature accepted for modified body")
+	}
+	if ingest.VerifySignature("s3cret", body, "") {
+		t.Error("empty signature accepted")
+	}
+}
+
+func TestFileSourceReplaysNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.ndjson")
+	lines := `{"channel_id":"-100","message_id":"1","text":"BTC/USDT LONG"}
+
+not json
+{"message_id":"2","text":"missing channel"}
+{"channel_id":"-100","text":"close 50%","reply_to_id":"1"}`
+	if err := os.WriteFile(path, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []signal.Message
+	err := ingest.NewFileSource(path, false).Run(context.Background(), func(_ context.Context, msg signal.Message) error {
+		got = append(got, msg)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d messages, want 2: %+v", len(got), got)
+	}
+	if got[0].MessageID != "1" || got[0].ChannelID != "-100" {
+		t.Errorf("first message = %+v", got[0])
+	}
+	if got[1].MessageID == "" || got[1].ReplyToID != "1" {
+		t.Errorf("second message = %+v, want generated ID and reply_to_id 1", got[1])
+	}
+}
@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"copier/internal/services"
+	"copier/internal/shared/exceptions"
+	"copier/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+func TestKillSwitchHaltAndResume(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.NewMemoryCache()
+	// two instances sharing one cache see the same halt
+	first, second := services.NewKillSwitch(shared), services.NewKillSwitch(shared)
+
+	if halt, err := second.Status(ctx); err != nil || halt != nil {
+		t.Fatalf("expected no halt initially, got %+v (%v)", halt, err)
+	}
+
+	admin := uuid.New()
+	if err := first.Halt(ctx, services.TradingHalt{Reason: "exchange outage", HaltedBy: admin, HaltedAt: time.Now()}); err != nil {
+		t.Fatalf("halt: %v", err)
+	}
+	halt, err := second.Status(ctx)
+	if err != nil || halt == nil || halt.Reason != "exchange outage" || halt.HaltedBy != admin {
+		t.Fatalf("expected the halt seen by the other instance, got %+v (%v)", halt, err)
+	}
+
+	if err := second.Resume(ctx); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if halt, err := first.Status(ctx); err != nil || halt != nil {
+		t.Errorf("expected the halt lifted, got %+v (%v)", halt, err)
+	}
+}
+
+func TestKillSwitchWithoutCache(t *testing.T) {
+	ctx := context.Background()
+	ks := services.NewKillSwitch(nil)
+
+	if halt, err := ks.Status(ctx); err != nil || halt != nil {
+		t.Errorf("expected trading allowed without a cache, got %+v (%v)", halt, err)
+	}
+	if err := ks.Halt(ctx, services.TradingHalt{Reason: "test"}); !errors.Is(err, exceptions.ErrKillSwitchUnavailable) {
+		t.Errorf("expected ErrKillSwitchUnavailable, got %v", err)
+	}
+}
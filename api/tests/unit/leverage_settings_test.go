@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+)
+
+// countingExchange counts the account setting calls that reach the exchange
+type countingExchange struct {
+	*exchange.PaperExchange
+	leverageCalls int
+	marginCalls   int
+}
+
+func (c *countingExchange) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	c.leverageCalls++
+	return c.PaperExchange.SetLeverage(ctx, symbol, leverage)
+}
+
+func (c *countingExchange) SetMarginType(ctx context.Context, symbol string, marginType exchange.MarginType) error {
+	c.marginCalls++
+	return c.PaperExchange.SetMarginType(ctx, symbol, marginType)
+}
+
+func TestSettingsCacheSkipsRedundantCalls(t *testing.T) {
+	ctx := context.Background()
+	client := &countingExchange{PaperExchange: exchange.NewPaperExchange(exchange.PaperConfig{})}
+	cache := exchange.NewSettingsCache()
+	isolated10 := exchange.SymbolSettings{Leverage: 10, MarginType: exchange.MarginTypeIsolated}
+
+	for range 3 {
+		if err := cache.Apply(ctx, client, "platform", "BTCUSDT", isolated10); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+	if client.leverageCalls != 1 || client.marginCalls != 1 {
+		t.Errorf("expected one call of each, got %d leverage and %d margin", client.leverageCalls, client.marginCalls)
+	}
+
+	if err := cache.Apply(ctx, client, "platform", "BTCUSDT", exchange.SymbolSettings{Leverage: 5, MarginType: exchange.MarginTypeIsolated}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if client.leverageCalls != 2 || client.marginCalls != 1 {
+		t.Errorf("expected only leverage to change, got %d leverage and %d margin", client.leverageCalls, client.marginCalls)
+	}
+
+	if err := cache.Apply(ctx, client, "platform", "BTCUSDT", exchange.SymbolSettings{Leverage: 200}); err == nil {
+		t.Fatal("expected invalid leverage to fail")
+	}
+	if err := cache.Apply(ctx, client, "platform", "BTCUSDT", isolated10); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if client.marginCalls != 2 {
+		t.Errorf("expected a failed call to forget the symbol, got %d margin calls", client.marginCalls)
+	}
+
+	client.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 100})
+	if _, err := client.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeMarket, Quantity: 1}); err != nil {
+		t.Fatalf("place order: %v", err)
+	}
+	positions, _ := client.Positions(ctx)
+	if len(positions) != 1 || positions[0].Leverage != 10 || positions[0].MarginType != "isolated" {
+		t.Errorf("expected an isolated 10x position, got %+v", positions)
+	}
+}
+
+func TestLeverageSourceAndClamp(t *testing.T) {
+	requested := 50
+	sig := &models.Signal{Leverage: &requested}
+
+	settings := &models.TradeSettings{LeverageSource: models.LeverageSourceDefault, DefaultLeverage: 3, MaxLeverage: 20}
+	if got := execution.Leverage(sig, settings); got != 3 {
+		t.Errorf("expected the default leverage, got %d", got)
+	}
+
+	settings.LeverageSource = models.LeverageSourceSignal
+	if got := execution.Leverage(sig, settings); got != 20 {
+		t.Errorf("expected the signal's leverage clamped at 20, got %d", got)
+	}
+	if got := execution.Leverage(&models.Signal{}, settings); got != 3 {
+		t.Errorf("expected the default leverage without a signal leverage, got %d", got)
+	}
+
+	if err := execution.ValidateSizing(&models.TradeSettings{PerTradeAmount: 100, DefaultLeverage: 25, MaxLeverage: 20}); err == nil {
+		t.Error("expected a default leverage above the maximum to be rejected")
+	}
+}
+
+func TestBinanceMarginTypeAlreadySet(t *testing.T) {
+	client, _ := binanceStub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/marginType" || r.URL.Query().Get("marginType") != "ISOLATED" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":-4046,"msg":"No need to change margin type."}`)
+	})
+
+	if err := client.SetMarginType(context.Background(), "BTCUSDT", exchange.MarginTypeIsolated); err != nil {
+		t.Errorf("expected an unchanged margin type to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+)
+
+func TestPlanOrdersFromTradeSettings(t *testing.T) {
+	stop := 60000.0
+	sig := &models.Signal{
+		Symbol:    "BTCUSDT",
+		Side:      models.SignalSideLong,
+		EntryLow:  61000,
+		EntryHigh: 61000,
+		Targets:   []float64{62000, 63000},
+		StopLoss:  &stop,
+	}
+	settings := &models.TradeSettings{
+		PerTradeAmount:   610,
+		StopLossStatus:   true,
+		TakeProfitStatus: true,
+		TakeProfitStep:   3,
+		TPPercentage:     []float64{50, 30},
+	}
+
+	plan := execution.PlanOrders(sig, settings, execution.Account{})
+
+	if len(plan.Orders) != 4 {
+		t.Fatalf("expected entry, stop and two take profits, got %d orders", len(plan.Orders))
+	}
+	if entry := plan.Orders[0]; entry.Side != execution.OrderSideBuy || entry.Quantity != 0.01 {
+		t.Errorf("unexpected entry order: %+v", entry)
+	}
+	if sl := plan.Orders[1]; sl.Purpose != execution.PurposeStopLoss || sl.Price != stop || !sl.ReduceOnly {
+		t.Errorf("unexpected stop order: %+v", sl)
+	}
+	if tp := plan.Orders[2]; tp.Price != 62000 || tp.Quantity != 0.005 {
+		t.Errorf("unexpected first take profit: %+v", tp)
+	}
+
+	joined := strings.Join(plan.Warnings, "\n")
+	for _, want := range []string{"2 targets but take profit step is 3", "2 TP percentages configured for 3", "add up to 80%"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing warning %q in %v", want, plan.Warnings)
+		}
+	}
+}
+
+func TestPlanOrdersDerivesMissingStop(t *testing.T) {
+	sig := &models.Signal{Symbol: "ETHUSDT", Side: models.SignalSideShort, EntryLow: 2000, EntryHigh: 2000}
+	settings := &models.TradeSettings{PerTradeAmount: 100, StopLossStatus: true, StopLossPercentage: 5}
+
+	plan := execution.PlanOrders(sig, settings, execution.Account{})
+
+	if len(plan.Orders) != 2 || plan.Orders[1].Price != 2100 || plan.Orders[1].Side != execution.OrderSideBuy {
+		t.Fatalf("expected derived stop at 2100, got %+v", plan.Orders)
+	}
+	if len(plan.Warnings) == 0 || !strings.Contains(plan.Warnings[0], "no stop loss") {
+		t.Errorf("expected missing stop warning, got %v", plan.Warnings)
+	}
+}
@@ -0,0 +1,148 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"copier/internal/exchange"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// newFeelessPaper returns a paper account with fees and slippage disabled
+func newFeelessPaper() *exchange.PaperExchange {
+	return exchange.NewPaperExchange(exchange.PaperConfig{Balance: 1000, MakerFee: -1, TakerFee: -1, Slippage: -1})
+}
+
+func TestPaperLimitEntryAndStopLoss(t *testing.T) {
+	ctx := context.Background()
+	paper := newFeelessPaper()
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 61000})
+
+	entry, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 0.1, Price: 60000})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if entry.Status != exchange.OrderStatusNew {
+		t.Fatalf("limit below market should rest, got %s", entry.Status)
+	}
+
+	stop, _ := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideSell, Type: exchange.OrderTypeStopMarket, Quantity: 0.1, Price: 59000, ReduceOnly: true})
+	if stop.Status != exchange.OrderStatusNew {
+		t.Fatalf("stop should rest, got %s", stop.Status)
+	}
+
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 59900})
+	positions, _ := paper.Positions(ctx)
+	if len(positions) != 1 || !approx(positions[0].Quantity, 0.1) || positions[0].EntryPrice != 60000 {
+		t.Fatalf("expected long 0.1 @ 60000, got %+v", positions)
+	}
+
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 58900})
+	positions, _ = paper.Positions(ctx)
+	if len(positions) != 0 {
+		t.Fatalf("stop should have closed the position, got %+v", positions)
+	}
+
+	balances, _ := paper.Balances(ctx)
+	if !approx(balances[0].Balance, 1000-110) {
+		t.Errorf("balance = %v, want 890 after a 1100 point loss on 0.1", balances[0].Balance)
+	}
+}
+
+func TestPaperShortTakeProfitWithFeesAndSlippage(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{Balance: 1000, TakerFee: 0.001, Slippage: 0.01})
+	paper.Tick(exchange.Tick{Symbol: "ETHUSDT", Price: 100})
+
+	entry, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "ETHUSDT", Side: exchange.SideSell, Type: exchange.OrderTypeMarket, Quantity: 1})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if entry.Status != exchange.OrderStatusFilled || !approx(entry.AvgPrice, 99) {
+		t.Fatalf("market sell should fill at 99 after slippage, got %+v", entry)
+	}
+
+	paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "ETHUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeTakeProfit, Price: 90, ClosePosition: true})
+	paper.Tick(exchange.Tick{Symbol: "ETHUSDT", Price: 95})
+	if positions, _ := paper.Positions(ctx); len(positions) != 1 {
+		t.Fatalf("take profit triggered early: %+v", positions)
+	}
+
+	paper.Tick(exchange.Tick{Symbol: "ETHUSDT", Price: 90})
+	if positions, _ := paper.Positions(ctx); len(positions) != 0 {
+		t.Fatalf("take profit should have closed the short, got %+v", positions)
+	}
+
+	// sold at 99, bought back at 90.9 (90 + 1% slippage), fees of 0.099 and 0.0909
+	balances, _ := paper.Balances(ctx)
+	if want := 1000 + 8.1 - 0.099 - 0.0909; !approx(balances[0].Balance, want) {
+		t.Errorf("balance = %v, want %v", balances[0].Balance, want)
+	}
+}
+
+func TestPaperReduceOnlyExpiresWithoutPosition(t *testing.T) {
+	ctx := context.Background()
+	paper := newFeelessPaper()
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 60000})
+
+	order, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideSell, Type: exchange.OrderTypeMarket, Quantity: 1, ReduceOnly: true})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if order.Status != exchange.OrderStatusExpired {
+		t.Errorf("reduce-only with no position should expire, got %s", order.Status)
+	}
+
+	if _, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "SOLUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeMarket, Quantity: 1}); !errors.Is(err, exchange.ErrNoPrice) {
+		t.Errorf("market order without a price: got %v, want ErrNoPrice", err)
+	}
+}
+
+func TestPaperCancelAndOpenOrders(t *testing.T) {
+	ctx := context.Background()
+	paper := newFeelessPaper()
+
+	order, _ := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 0.1, Price: 50000})
+	if open, _ := paper.OpenOrders(ctx, ""); len(open) != 1 {
+		t.Fatalf("expected one open order, got %d", len(open))
+	}
+
+	if err := paper.CancelOrder(ctx, "BTCUSDT", order.OrderID); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if err := paper.CancelOrder(ctx, "BTCUSDT", order.OrderID); !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("second cancel: got %v, want ErrOrderNotFound", err)
+	}
+	if open, _ := paper.OpenOrders(ctx, "BTCUSDT"); len(open) != 0 {
+		t.Errorf("cancelled order still open: %+v", open)
+	}
+}
+
+func TestParsePriceCSVReplay(t *testing.T) {
+	ticks, err := exchange.ParsePriceCSV(strings.NewReader("time,symbol,price\n1700000000,btcusdt,60000\n2023-11-14T22:14:00Z,BTCUSDT,59000\n,BTCUSDT,58000\n"))
+	if err != nil {
+		t.Fatalf("ParsePriceCSV: %v", err)
+	}
+	if len(ticks) != 3 || ticks[0].Symbol != "BTCUSDT" || ticks[0].Time.Unix() != 1700000000 || !ticks[2].Time.IsZero() {
+		t.Fatalf("ticks = %+v", ticks)
+	}
+
+	paper := newFeelessPaper()
+	paper.PlaceOrder(context.Background(), exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 0.01, Price: 59000})
+	paper.Replay(ticks)
+
+	positions, _ := paper.Positions(context.Background())
+	if len(positions) != 1 || positions[0].MarkPrice != 58000 {
+		t.Errorf("positions after replay = %+v", positions)
+	}
+
+	if _, err := exchange.ParsePriceCSV(strings.NewReader("1700000000,BTCUSDT,60000\n1700000001,BTCUSDT,abc\n")); err == nil {
+		t.Error("expected an error for an invalid price")
+	}
+}
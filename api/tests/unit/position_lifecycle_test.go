@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestPositionLifecycleThroughFills(t *testing.T) {
+	pos := &models.Position{Side: models.SignalSideShort, Status: models.PositionStatusPendingEntry, Quantity: 2}
+
+	if err := execution.ApplyEntryFill(pos, 0.5, 100, false); err != nil || pos.Status != models.PositionStatusPartiallyFilled {
+		t.Fatalf("expected partially filled, got %s (%v)", pos.Status, err)
+	}
+	if err := execution.ApplyEntryFill(pos, 2, 101, false); err != nil || pos.Status != models.PositionStatusOpen || pos.OpenedAt == nil {
+		t.Fatalf("expected open, got %s (%v)", pos.Status, err)
+	}
+
+	if err := execution.ApplyExitFill(pos, 1, 91, false); err != nil || pos.Status != models.PositionStatusPartiallyClosed {
+		t.Fatalf("expected partially closed, got %s (%v)", pos.Status, err)
+	}
+	if err := execution.ApplyExitFill(pos, 5, 111, true); err != nil || pos.Status != models.PositionStatusStopped {
+		t.Fatalf("expected stopped, got %s (%v)", pos.Status, err)
+	}
+
+	if pos.ClosedQuantity != 2 || pos.ClosedAt == nil {
+		t.Errorf("expected the stop to close only what remained, got %+v", pos)
+	}
+	if math.Abs(pos.RealizedPnL) > 1e-9 || pos.ExitPrice != 101 {
+		t.Errorf("expected a flat short after +10 and -10, got pnl %v exit %v", pos.RealizedPnL, pos.ExitPrice)
+	}
+}
+
+func TestPositionRejectsInvalidTransitions(t *testing.T) {
+	cases := []struct {
+		from, to models.PositionStatus
+	}{
+		{models.PositionStatusPendingEntry, models.PositionStatusClosed},
+		{models.PositionStatusOpen, models.PositionStatusCancelled},
+		{models.PositionStatusOpen, models.PositionStatusPendingEntry},
+		{models.PositionStatusClosed, models.PositionStatusOpen},
+		{models.PositionStatusExpired, models.PositionStatusCancelled},
+	}
+
+	for _, tc := range cases {
+		pos := &models.Position{Status: tc.from}
+		err := execution.TransitionPosition(pos, tc.to)
+		if !errors.Is(err, exceptions.ErrInvalidTransition) || pos.Status != tc.from {
+			t.Errorf("%s -> %s: expected ErrInvalidTransition, got %v", tc.from, tc.to, err)
+		}
+	}
+
+	pending := &models.Position{Status: models.PositionStatusPendingEntry, Quantity: 1}
+	if err := execution.ApplyExitFill(pending, 1, 100, false); err != nil || pending.Status != models.PositionStatusPendingEntry {
+		t.Errorf("expected exit fill without exposure to be ignored, got %s (%v)", pending.Status, err)
+	}
+}
+
+func TestChangedColumnsOnlyListsWhatChanged(t *testing.T) {
+	before := models.Position{Side: models.SignalSideLong, Status: models.PositionStatusPendingEntry, Quantity: 2, StopPrice: 90}
+	after := before
+	if err := execution.ApplyEntryFill(&after, 2, 100, false); err != nil {
+		t.Fatalf("fill: %v", err)
+	}
+
+	got := execution.ChangedColumns(&before, &after)
+	want := []string{"status", "filled_quantity", "entry_price", "opened_at"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected columns %v, got %v", want, got)
+	}
+	if columns := execution.ChangedColumns(&after, &after); len(columns) != 0 {
+		t.Errorf("expected no columns for an unchanged position, got %v", columns)
+	}
+}
+
+func TestChangedColumnsAreSchemaColumns(t *testing.T) {
+	positions, err := schema.Parse(&models.Position{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+
+	now := time.Now()
+	after := models.Position{
+		Status:            models.PositionStatusClosed,
+		FilledQuantity:    1,
+		ClosedQuantity:    1,
+		EntryPrice:        100,
+		ExitPrice:         110,
+		RealizedPnL:       10,
+		ProtectedQuantity: 1,
+		StopPrice:         95,
+		TargetsHit:        1,
+		PeakPrice:         112,
+		CloseReason:       "target",
+		EntryExpiresAt:    &now,
+		ExpiresAt:         &now,
+		OpenedAt:          &now,
+		ClosedAt:          &now,
+	}
+	columns := execution.ChangedColumns(&models.Position{}, &after)
+	if !slices.Contains(columns, "realized_pnl") {
+		t.Errorf("expected realized_pnl among %v", columns)
+	}
+	for _, column := range columns {
+		if positions.LookUpField(column) == nil {
+			t.Errorf("column %q is not a position column", column)
+		}
+	}
+}
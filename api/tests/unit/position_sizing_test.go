@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+)
+
+func TestSizePositionModes(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings models.TradeSettings
+		stop     float64
+		leverage int
+		account  execution.Account
+		quantity float64
+	}{
+		{"fixed amount", models.TradeSettings{SizingMode: models.SizingModeFixedAmount, PerTradeAmount: 500}, 0, 1, execution.Account{}, 5},
+		{"empty mode defaults to fixed amount", models.TradeSettings{PerTradeAmount: 200}, 0, 1, execution.Account{}, 2},
+		{"balance percentage with leverage", models.TradeSettings{SizingMode: models.SizingModeBalancePercentage, BalancePercentage: 10}, 0, 5, execution.Account{Available: 1000}, 5},
+		{"fixed risk long", models.TradeSettings{SizingMode: models.SizingModeFixedRisk, RiskAmount: 20}, 98, 1, execution.Account{}, 10},
+		{"fixed risk short", models.TradeSettings{SizingMode: models.SizingModeFixedRisk, RiskAmount: 20}, 104, 1, execution.Account{}, 5},
+		{"fixed quantity", models.TradeSettings{SizingMode: models.SizingModeFixedQuantity, FixedQuantity: 3}, 0, 1, execution.Account{}, 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := execution.SizePosition(&tc.settings, 100, tc.stop, tc.leverage, tc.account)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(size.Quantity-tc.quantity) > 1e-9 {
+				t.Errorf("expected quantity %v, got %v", tc.quantity, size.Quantity)
+			}
+			if len(size.Warnings) != 0 {
+				t.Errorf("expected no caps, got %v", size.Warnings)
+			}
+		})
+	}
+}
+
+func TestSizePositionCaps(t *testing.T) {
+	settings := &models.TradeSettings{SizingMode: models.SizingModeFixedQuantity, FixedQuantity: 10, MaxNotional: 600}
+	size, err := execution.SizePosition(settings, 100, 0, 1, execution.Account{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size.Notional != 600 || size.Quantity != 6 || len(size.Warnings) != 1 {
+		t.Errorf("expected notional capped at 600, got %+v", size)
+	}
+
+	// the available balance at leverage caps below the maximum notional
+	size, err = execution.SizePosition(settings, 100, 0, 2, execution.Account{Available: 200})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size.Notional != 400 || len(size.Warnings) != 2 {
+		t.Errorf("expected notional capped at 400 available, got %+v", size)
+	}
+}
+
+func TestSizePositionRejects(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings models.TradeSettings
+		entry    float64
+		stop     float64
+		account  execution.Account
+	}{
+		{"fixed risk without stop loss", models.TradeSettings{SizingMode: models.SizingModeFixedRisk, RiskAmount: 20}, 100, 0, execution.Account{}},
+		{"fixed risk with very tight stop", models.TradeSettings{SizingMode: models.SizingModeFixedRisk, RiskAmount: 20}, 100, 99.95, execution.Account{}},
+		{"balance percentage without balance", models.TradeSettings{SizingMode: models.SizingModeBalancePercentage, BalancePercentage: 10}, 100, 0, execution.Account{}},
+		{"missing mode value", models.TradeSettings{SizingMode: models.SizingModeFixedQuantity}, 100, 0, execution.Account{}},
+		{"unknown mode", models.TradeSettings{SizingMode: "martingale", PerTradeAmount: 100}, 100, 0, execution.Account{}},
+		{"no entry price", models.TradeSettings{PerTradeAmount: 100}, 0, 0, execution.Account{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := execution.SizePosition(&tc.settings, tc.entry, tc.stop, 1, tc.account)
+			if !errors.Is(err, exceptions.ErrInvalidSizing) {
+				t.Errorf("expected ErrInvalidSizing, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLeverageCappedBySettings(t *testing.T) {
+	requested := 20
+	sig := &models.Signal{Leverage: &requested}
+
+	if got := execution.Leverage(sig, &models.TradeSettings{MaxLeverage: 10}); got != 10 {
+		t.Errorf("expected leverage capped at 10, got %d", got)
+	}
+	if got := execution.Leverage(sig, &models.TradeSettings{}); got != 20 {
+		t.Errorf("expected the signal's leverage without a cap, got %d", got)
+	}
+	if got := execution.Leverage(&models.Signal{}, &models.TradeSettings{MaxLeverage: 10}); got != 1 {
+		t.Errorf("expected 1x when the signal has no leverage, got %d", got)
+	}
+}
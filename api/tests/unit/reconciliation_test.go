@@ -0,0 +1,254 @@
+package unit
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// reconcileStore backs the fake repositories the reconciliation service reads and writes
+type reconcileStore struct {
+	orders    []*models.Order
+	positions []*models.Position
+	events    []*models.ReconciliationEvent
+}
+
+type reconcileOrderRepo struct {
+	repositories.OrderRepository
+	store *reconcileStore
+}
+
+func (r *reconcileOrderRepo) FindWorkingByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Order, error) {
+	var working []*models.Order
+	for _, order := range r.store.orders {
+		if order.Status == models.OrderStatusNew || order.Status == models.OrderStatusPartiallyFilled {
+			working = append(working, order)
+		}
+	}
+	return working, nil
+}
+
+func (r *reconcileOrderRepo) FindByExchangeOrderIDs(ctx context.Context, platformID uuid.UUID, ids []string) ([]*models.Order, error) {
+	var found []*models.Order
+	for _, order := range r.store.orders {
+		if slices.Contains(ids, order.ExchangeOrderID) {
+			found = append(found, order)
+		}
+	}
+	return found, nil
+}
+
+type reconcilePositionRepo struct {
+	repositories.PositionRepository
+	store *reconcileStore
+}
+
+func (r *reconcilePositionRepo) FindActiveByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Position, error) {
+	return r.store.positions, nil
+}
+
+type reconcileAuditRepo struct {
+	repositories.ReconciliationRepository
+	store *reconcileStore
+}
+
+func (r *reconcileAuditRepo) CreateEvents(ctx context.Context, events []*models.ReconciliationEvent) error {
+	r.store.events = append(r.store.events, events...)
+	return nil
+}
+
+// reconcilePlatforms hands out the paper exchange of the test platform
+type reconcilePlatforms struct {
+	services.PlatformService
+	client exchange.ExchangeClient
+}
+
+func (p *reconcilePlatforms) Client(platform *models.Platform) (exchange.ExchangeClient, error) {
+	return p.client, nil
+}
+
+// reconcileEngine records the repairs the reconciliation service asks the engine for
+type reconcileEngine struct {
+	services.ExecutionService
+	client  exchange.ExchangeClient
+	missing []uuid.UUID
+	settled []uuid.UUID
+}
+
+func (e *reconcileEngine) SyncOrder(ctx context.Context, order *models.Order) error {
+	current, err := e.client.GetOrder(ctx, order.Symbol, order.ExchangeOrderID)
+	if err != nil {
+		return err
+	}
+	order.Status = models.OrderStatus(map[exchange.OrderStatus]string{
+		exchange.OrderStatusFilled:   "filled",
+		exchange.OrderStatusCanceled: "cancelled",
+	}[current.Status])
+	order.FilledQuantity = current.ExecutedQty
+	return nil
+}
+
+func (e *reconcileEngine) MarkOrderMissing(ctx context.Context, order *models.Order, reason string) error {
+	e.missing = append(e.missing, order.ID)
+	order.Status = models.OrderStatusCancelled
+	return nil
+}
+
+func (e *reconcileEngine) SettleExternalClose(ctx context.Context, pos *models.Position, price float64, reason string) error {
+	e.settled = append(e.settled, pos.ID)
+	return nil
+}
+
+func TestReconcilePlatform(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 100})
+	paper.Tick(exchange.Tick{Symbol: "ETHUSDT", Price: 10})
+
+	// an order placed by hand on the exchange, and a stored entry that filled while the copier was down
+	orphan, _ := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 1, Price: 90})
+	filled, _ := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeLimit, Quantity: 2, Price: 95})
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 94})
+	// a position opened outside the copier
+	if _, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "ETHUSDT", Side: exchange.SideSell, Type: exchange.OrderTypeMarket, Quantity: 3}); err != nil {
+		t.Fatalf("place order: %v", err)
+	}
+
+	solPosition := &models.Position{ID: uuid.New(), Symbol: "SOLUSDT", Side: models.SignalSideLong, Status: models.PositionStatusOpen, Quantity: 5, FilledQuantity: 5}
+	btcPosition := &models.Position{ID: uuid.New(), Symbol: "BTCUSDT", Side: models.SignalSideLong, Status: models.PositionStatusOpen, Quantity: 2, FilledQuantity: 2}
+	store := &reconcileStore{
+		orders: []*models.Order{
+			{ID: uuid.New(), Symbol: "BTCUSDT", Status: models.OrderStatusNew, ExchangeOrderID: filled.OrderID, Quantity: 2},
+			{ID: uuid.New(), Symbol: "BTCUSDT", Status: models.OrderStatusNew, ExchangeOrderID: "999", Quantity: 1},
+		},
+		positions: []*models.Position{solPosition, btcPosition},
+	}
+	engine := &reconcileEngine{client: paper}
+	service := services.NewReconciliationService(
+		&reconcileAuditRepo{store: store},
+		&reconcileOrderRepo{store: store},
+		&reconcilePositionRepo{store: store},
+		nil,
+		&reconcilePlatforms{client: paper},
+		engine,
+	)
+
+	platform := &models.Platform{ID: uuid.New(), UserID: uuid.New(), Paper: true}
+	events, err := service.ReconcilePlatform(ctx, platform)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+
+	kinds := make(map[models.ReconciliationKind]int)
+	for _, event := range events {
+		kinds[event.Kind]++
+		if event.PlatformID != platform.ID || event.UserID != platform.UserID {
+			t.Errorf("event %s not attributed to the platform", event.Kind)
+		}
+		if event.Kind == models.ReconciliationOrphanOrder && event.ExchangeOrderID != orphan.OrderID {
+			t.Errorf("expected order %s flagged as orphan, got %s", orphan.OrderID, event.ExchangeOrderID)
+		}
+	}
+
+	want := map[models.ReconciliationKind]int{
+		models.ReconciliationOrderChanged:      1,
+		models.ReconciliationOrderMissing:      1,
+		models.ReconciliationOrphanOrder:       1,
+		models.ReconciliationPositionClosed:    1,
+		models.ReconciliationUntrackedPosition: 1,
+	}
+	for kind, n := range want {
+		if kinds[kind] != n {
+			t.Errorf("expected %d %s events, got %d (%v)", n, kind, kinds[kind], kinds)
+		}
+	}
+	if len(events) != len(store.events) {
+		t.Errorf("expected every discrepancy audited, got %d of %d", len(store.events), len(events))
+	}
+	if store.orders[0].Status != models.OrderStatusFilled {
+		t.Errorf("expected the filled entry repaired, got %s", store.orders[0].Status)
+	}
+	if len(engine.missing) != 1 || engine.missing[0] != store.orders[1].ID {
+		t.Errorf("expected the unknown order marked missing, got %v", engine.missing)
+	}
+	if len(engine.settled) != 1 || engine.settled[0] != solPosition.ID {
+		t.Errorf("expected only the flat SOL position settled, got %v", engine.settled)
+	}
+}
+
+// fillBetweenReads fills an entry on the exchange and records it in the store right after the
+// first of the stored and exchange position reads, as the execution worker may
+type fillBetweenReads struct {
+	exchange.ExchangeClient
+	fill func()
+}
+
+func (c *fillBetweenReads) Positions(ctx context.Context) ([]exchange.Position, error) {
+	held, err := c.ExchangeClient.Positions(ctx)
+	c.once()
+	return held, err
+}
+
+func (c *fillBetweenReads) once() {
+	if c.fill != nil {
+		c.fill()
+		c.fill = nil
+	}
+}
+
+type fillBetweenPositionRepo struct {
+	reconcilePositionRepo
+	client *fillBetweenReads
+}
+
+func (r *fillBetweenPositionRepo) FindActiveByPlatform(ctx context.Context, platformID uuid.UUID) ([]*models.Position, error) {
+	stored := slices.Clone(r.store.positions)
+	r.client.once()
+	return stored, nil
+}
+
+func TestReconcileDoesNotCloseEntryFilledDuringRun(t *testing.T) {
+	ctx := context.Background()
+	paper := exchange.NewPaperExchange(exchange.PaperConfig{MakerFee: -1, TakerFee: -1, Slippage: -1})
+	paper.Tick(exchange.Tick{Symbol: "BTCUSDT", Price: 100})
+
+	pos := &models.Position{ID: uuid.New(), Symbol: "BTCUSDT", Side: models.SignalSideLong, Status: models.PositionStatusPendingEntry, Quantity: 1}
+	store := &reconcileStore{positions: []*models.Position{pos}}
+	client := &fillBetweenReads{ExchangeClient: paper}
+	client.fill = func() {
+		if _, err := paper.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.SideBuy, Type: exchange.OrderTypeMarket, Quantity: 1}); err != nil {
+			t.Fatalf("fill entry: %v", err)
+		}
+		pos.Status, pos.FilledQuantity, pos.EntryPrice = models.PositionStatusOpen, 1, 100
+	}
+
+	engine := &reconcileEngine{client: paper}
+	service := services.NewReconciliationService(
+		&reconcileAuditRepo{store: store},
+		&reconcileOrderRepo{store: store},
+		&fillBetweenPositionRepo{reconcilePositionRepo: reconcilePositionRepo{store: store}, client: client},
+		nil,
+		&reconcilePlatforms{client: client},
+		engine,
+	)
+
+	events, err := service.ReconcilePlatform(ctx, &models.Platform{ID: uuid.New(), UserID: uuid.New(), Paper: true})
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if len(engine.settled) != 0 {
+		t.Fatalf("expected the freshly filled position left open, got it settled")
+	}
+	for _, event := range events {
+		if event.Kind == models.ReconciliationPositionClosed {
+			t.Errorf("expected no position closed, got %+v", event)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+)
+
+func riskBook() *execution.RiskBook {
+	book := execution.NewRiskBook()
+	book.Add("BTCUSDT", models.SignalSideLong, 600)
+	book.Add("ETHUSDT", models.SignalSideShort, 300)
+	return book
+}
+
+func TestCheckExposure(t *testing.T) {
+	cases := []struct {
+		name     string
+		limits   models.RiskLimits
+		symbol   string
+		side     models.SignalSide
+		notional float64
+		rule     models.RiskRule
+	}{
+		{"no limits", models.RiskLimits{}, "BTCUSDT", models.SignalSideLong, 10000, ""},
+		{"open positions", models.RiskLimits{MaxOpenPositions: 2}, "SOLUSDT", models.SignalSideLong, 100, models.RiskRuleMaxOpenPositions},
+		{"total notional", models.RiskLimits{MaxTotalNotional: 1000}, "SOLUSDT", models.SignalSideLong, 200, models.RiskRuleMaxTotalNotional},
+		{"symbol notional", models.RiskLimits{MaxSymbolNotional: 700}, "btcusdt", models.SignalSideShort, 200, models.RiskRuleMaxSymbolNotional},
+		{"other symbol fits", models.RiskLimits{MaxSymbolNotional: 700}, "SOLUSDT", models.SignalSideLong, 200, ""},
+		{"side notional", models.RiskLimits{MaxSideNotional: 500}, "SOLUSDT", models.SignalSideShort, 250, models.RiskRuleMaxSideNotional},
+		{"within every limit", models.RiskLimits{MaxOpenPositions: 3, MaxTotalNotional: 1000, MaxSideNotional: 800}, "SOLUSDT", models.SignalSideLong, 100, ""},
+	}
+
+	for _, tc := range cases {
+		violation := execution.CheckExposure(tc.limits, riskBook(), tc.symbol, tc.side, tc.notional)
+		switch {
+		case tc.rule == "" && violation != nil:
+			t.Errorf("%s: unexpected violation %v", tc.name, violation)
+		case tc.rule != "" && (violation == nil || violation.Rule != tc.rule):
+			t.Errorf("%s: expected %s, got %v", tc.name, tc.rule, violation)
+		case violation != nil && !errors.Is(violation, exceptions.ErrRiskLimit):
+			t.Errorf("%s: expected ErrRiskLimit, got %v", tc.name, violation)
+		}
+	}
+}
+
+func TestRiskPeriods(t *testing.T) {
+	// a Wednesday afternoon
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	day, week := execution.RiskPeriods(now)
+	if !day.Equal(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)) || !week.Equal(time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected periods %v and %v", day, week)
+	}
+
+	sunday := time.Date(2024, 5, 19, 23, 0, 0, 0, time.UTC)
+	if _, week := execution.RiskPeriods(sunday); !week.Equal(time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Sunday to belong to the week starting Monday, got %v", week)
+	}
+}
+
+func TestLossLimitsPauseUntilNextPeriod(t *testing.T) {
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	limits := models.RiskLimits{DailyLossLimit: 100, WeeklyLossLimit: 300}
+
+	book := execution.NewRiskBook()
+	book.DailyPnL, book.WeeklyPnL = -50, -50
+	if violation := execution.CheckLossLimits(limits, book, now); violation != nil {
+		t.Fatalf("unexpected violation %v", violation)
+	}
+
+	book.DailyPnL, book.WeeklyPnL = -120, -120
+	violation := execution.CheckLossLimits(limits, book, now)
+	if violation == nil || violation.Rule != models.RiskRuleDailyLossLimit || !violation.Until.Equal(time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the daily limit to pause until tomorrow, got %+v", violation)
+	}
+
+	book.WeeklyPnL = -350
+	violation = execution.CheckLossLimits(limits, book, now)
+	if violation == nil || violation.Rule != models.RiskRuleWeeklyLossLimit || !violation.Until.Equal(time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the weekly limit to pause until Monday, got %+v", violation)
+	}
+}
+
+func TestPositionExposure(t *testing.T) {
+	pos := &models.Position{
+		Status:         models.PositionStatusPartiallyFilled,
+		FilledQuantity: 2,
+		ClosedQuantity: 0.5,
+		EntryPrice:     100,
+		Orders: []models.Order{
+			{Purpose: models.OrderPurposeEntry, Status: models.OrderStatusFilled, Quantity: 2, FilledQuantity: 2, Price: 100},
+			{Purpose: models.OrderPurposeEntry, Status: models.OrderStatusNew, Quantity: 1, Price: 90},
+			{Purpose: models.OrderPurposeStopLoss, Status: models.OrderStatusNew, Quantity: 3, Price: 80},
+		},
+	}
+
+	// 1.5 held at 100 plus 1 still waiting at 90
+	if got := execution.PositionExposure(pos); got != 240 {
+		t.Errorf("expected exposure 240, got %v", got)
+	}
+}
+
+func TestBuildRiskBudget(t *testing.T) {
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	book := riskBook()
+	book.DailyPnL = -40
+
+	budget := execution.BuildRiskBudget(models.RiskLimits{MaxOpenPositions: 5, MaxTotalNotional: 1000, DailyLossLimit: 100}, book, now)
+	if budget.RemainingPositions == nil || *budget.RemainingPositions != 3 {
+		t.Errorf("expected 3 positions left, got %v", budget.RemainingPositions)
+	}
+	if budget.RemainingNotional == nil || *budget.RemainingNotional != 100 {
+		t.Errorf("expected 100 notional left, got %v", budget.RemainingNotional)
+	}
+	if budget.RemainingDailyLoss == nil || *budget.RemainingDailyLoss != 60 || budget.RemainingWeeklyLoss != nil {
+		t.Errorf("unexpected loss budget %v / %v", budget.RemainingDailyLoss, budget.RemainingWeeklyLoss)
+	}
+	if budget.PausedUntil != nil {
+		t.Errorf("expected copying not paused, got %v", budget.PausedUntil)
+	}
+}
@@ -0,0 +1,152 @@
+package unit
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+)
+
+func scaledSignal() *models.Signal {
+	stop := 90.0
+	return &models.Signal{
+		Symbol:    "BTCUSDT",
+		Side:      models.SignalSideLong,
+		EntryLow:  96,
+		EntryHigh: 100,
+		StopLoss:  &stop,
+		Targets:   []float64{110, 120},
+	}
+}
+
+func TestScaledEntriesSpreadAcrossZone(t *testing.T) {
+	legs := execution.ScaledEntries(scaledSignal(), 3, []float64{50, 30, 20})
+	want := []execution.EntryLeg{{Price: 100, Weight: 50}, {Price: 98, Weight: 30}, {Price: 96, Weight: 20}}
+	if len(legs) != len(want) {
+		t.Fatalf("expected %d legs, got %+v", len(want), legs)
+	}
+	for i := range want {
+		if legs[i] != want[i] {
+			t.Errorf("leg %d: got %+v, want %+v", i+1, legs[i], want[i])
+		}
+	}
+
+	short := scaledSignal()
+	short.Side = models.SignalSideShort
+	if legs := execution.ScaledEntries(short, 2, nil); legs[0].Price != 96 || legs[1].Price != 100 || legs[0].Weight != 50 {
+		t.Errorf("expected a short to scale up from the low edge evenly, got %+v", legs)
+	}
+}
+
+func TestDecideEntryStrategy(t *testing.T) {
+	sig := scaledSignal()
+	cases := []struct {
+		name     string
+		strategy models.EntryStrategy
+		price    float64
+		mode     execution.EntryMode
+		at       float64
+	}{
+		{"first price inside the zone", models.EntryStrategyFirstPrice, 99, execution.EntryMarket, 99},
+		{"midpoint waits", models.EntryStrategyMidpoint, 99, execution.EntryLimit, 98},
+		{"midpoint already better", models.EntryStrategyMidpoint, 97, execution.EntryMarket, 97},
+		{"scaled grid", models.EntryStrategyScaled, 101, execution.EntryScaled, 98},
+		{"scaled past the zone", models.EntryStrategyScaled, 95, execution.EntryMarket, 95},
+	}
+
+	for _, tc := range cases {
+		settings := &models.TradeSettings{EntryStrategy: tc.strategy, ScaledEntryCount: 3, OverRangePercentage: 5}
+		decision := execution.DecideEntryStrategy(sig, settings, tc.price)
+		if decision.Mode != tc.mode || math.Abs(decision.Price-tc.at) > 1e-9 {
+			t.Errorf("%s: got %s at %v, want %s at %v", tc.name, decision.Mode, decision.Price, tc.mode, tc.at)
+		}
+	}
+}
+
+func TestPlanScaledEntries(t *testing.T) {
+	settings := &models.TradeSettings{
+		PerTradeAmount:     980,
+		StopLossStatus:     true,
+		TakeProfitStatus:   true,
+		TakeProfitStep:     2,
+		TPPercentage:       []float64{50, 50},
+		EntryStrategy:      models.EntryStrategyScaled,
+		ScaledEntryCount:   3,
+		ScaledEntryWeights: []float64{50, 30, 20},
+	}
+
+	plan := execution.PlanOrders(scaledSignal(), settings, execution.Account{})
+	entries := plan.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected three entry orders, got %+v", plan.Orders)
+	}
+	for i, entry := range entries {
+		if entry.Leg != i+1 || entry.Type != execution.OrderTypeLimit {
+			t.Errorf("entry %d: unexpected leg or type %+v", i+1, entry)
+		}
+	}
+	if math.Abs(entries[0].Quantity/plan.EntryQuantity()-0.5) > 1e-9 {
+		t.Errorf("expected the first entry to take half the position, got %v of %v", entries[0].Quantity, plan.EntryQuantity())
+	}
+	if sl := plan.Orders[3]; sl.Purpose != execution.PurposeStopLoss || math.Abs(sl.Quantity-plan.EntryQuantity()) > 1e-9 {
+		t.Errorf("expected the stop to cover every entry, got %+v", sl)
+	}
+}
+
+func TestApplySymbolRulesMergesTinyEntries(t *testing.T) {
+	sig := &models.Signal{Symbol: "BTCUSDT", Side: models.SignalSideLong, EntryLow: 59000, EntryHigh: 60000}
+	settings := &models.TradeSettings{
+		PerTradeAmount:     600,
+		EntryStrategy:      models.EntryStrategyScaled,
+		ScaledEntryCount:   3,
+		ScaledEntryWeights: []float64{60, 30, 10},
+	}
+
+	plan := execution.PlanOrders(sig, settings, execution.Account{})
+	if err := execution.ApplySymbolRules(plan, btcRules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the 10% leg is below the minimum notional and folds into the 30% leg
+	entries := plan.Entries()
+	if len(entries) != 2 || entries[1].Leg != 2 || entries[1].Price != 59500 {
+		t.Errorf("expected the last entry merged into the second, got %+v", entries)
+	}
+}
+
+func TestValidateEntryStrategy(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings models.TradeSettings
+		valid    bool
+	}{
+		{"first price", models.TradeSettings{EntryStrategy: models.EntryStrategyFirstPrice}, true},
+		{"scaled evenly", models.TradeSettings{EntryStrategy: models.EntryStrategyScaled, ScaledEntryCount: 4}, true},
+		{"scaled weighted", models.TradeSettings{EntryStrategy: models.EntryStrategyScaled, ScaledEntryCount: 2, ScaledEntryWeights: []float64{70, 30}}, true},
+		{"one scaled order", models.TradeSettings{EntryStrategy: models.EntryStrategyScaled, ScaledEntryCount: 1}, false},
+		{"weights per order", models.TradeSettings{EntryStrategy: models.EntryStrategyScaled, ScaledEntryCount: 3, ScaledEntryWeights: []float64{50, 50}}, false},
+		{"weights under 100", models.TradeSettings{EntryStrategy: models.EntryStrategyScaled, ScaledEntryCount: 2, ScaledEntryWeights: []float64{50, 40}}, false},
+		{"unknown", models.TradeSettings{EntryStrategy: "ladder"}, false},
+	}
+
+	for _, tc := range cases {
+		err := execution.ValidateEntryStrategy(&tc.settings)
+		if tc.valid != (err == nil) || (err != nil && !errors.Is(err, exceptions.ErrInvalidEntry)) {
+			t.Errorf("%s: unexpected result %v", tc.name, err)
+		}
+	}
+}
+
+func TestEntryFillAfterTargetKeepsStatus(t *testing.T) {
+	pos := &models.Position{Side: models.SignalSideLong, Status: models.PositionStatusPartiallyClosed, Quantity: 3, FilledQuantity: 1, ClosedQuantity: 0.5, EntryPrice: 100}
+
+	if err := execution.ApplyEntryFill(pos, 2, 99, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos.Status != models.PositionStatusPartiallyClosed || pos.FilledQuantity != 2 || pos.Remaining() != 1.5 {
+		t.Errorf("expected the fill added without a status change, got %s with %v remaining", pos.Status, pos.Remaining())
+	}
+}
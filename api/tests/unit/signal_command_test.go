@@ -0,0 +1,112 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+	"copier/internal/signal"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text    string
+		symbol  string
+		actions []models.SignalActionType
+		check   func(t *testing.T, cmd *signal.Command)
+	}{
+		{
+			text:    "TP1 hit ✅ move SL to entry",
+			actions: []models.SignalActionType{models.SignalActionTargetHit, models.SignalActionMoveStop},
+			check: func(t *testing.T, cmd *signal.Command) {
+				if cmd.Actions[0].Target != 1 || !cmd.Actions[1].Stop.BreakEven {
+					t.Errorf("unexpected actions: %+v", cmd.Actions)
+				}
+			},
+		},
+		{
+			text:    "Close 50% now and set stop to 61200",
+			actions: []models.SignalActionType{models.SignalActionMoveStop, models.SignalActionClosePartial},
+			check: func(t *testing.T, cmd *signal.Command) {
+				if p := cmd.Actions[0].Stop.Price; p == nil || *p != 61200 {
+					t.Errorf("stop price = %v, want 61200", p)
+				}
+				if cmd.Actions[1].Percent != 50 {
+					t.Errorf("percent = %v, want 50", cmd.Actions[1].Percent)
+				}
+			},
+		},
+		{
+			text:    "Cancel #BTC signal",
+			symbol:  "BTCUSDT",
+			actions: []models.SignalActionType{models.SignalActionCancelPending},
+		},
+		{
+			text:    "SL moved to TP2",
+			actions: []models.SignalActionType{models.SignalActionMoveStop},
+			check: func(t *testing.T, cmd *signal.Command) {
+				if cmd.Actions[0].Stop.Target != 2 {
+					t.Errorf("stop target = %d, want 2", cmd.Actions[0].Stop.Target)
+				}
+			},
+		},
+		{
+			text:    "ETH/USDT close all positions",
+			symbol:  "ETHUSDT",
+			actions: []models.SignalActionType{models.SignalActionCloseAll},
+		},
+		{
+			text:    "Close #SOL now",
+			symbol:  "SOLUSDT",
+			actions: []models.SignalActionType{models.SignalActionCloseAll},
+		},
+		{
+			text:    "Trade closed manually in profit",
+			actions: []models.SignalActionType{models.SignalActionCloseAll},
+		},
+		{
+			text:    "Signal cancelled, entry was not reached",
+			actions: []models.SignalActionType{models.SignalActionCancelPending},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			cmd, err := signal.ParseCommand(tt.text)
+			if err != nil {
+				t.Fatalf("ParseCommand returned error: %v", err)
+			}
+			if cmd.Symbol != tt.symbol {
+				t.Errorf("symbol = %q, want %q", cmd.Symbol, tt.symbol)
+			}
+			if len(cmd.Actions) != len(tt.actions) {
+				t.Fatalf("actions = %+v, want %v", cmd.Actions, tt.actions)
+			}
+			for i, want := range tt.actions {
+				if cmd.Actions[i].Type != want {
+					t.Errorf("action %d = %s, want %s", i, cmd.Actions[i].Type, want)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, cmd)
+			}
+		})
+	}
+
+	chatter := []string{
+		"We are getting closer to the target",
+		"BTC is close to breakout",
+		"ETH daily close above 3000 would be bullish",
+		"Markets closed for the weekend, see you Monday",
+		"We exited the range on SOL",
+		"This BTC idea is invalid below 60000",
+		"Void of momentum on #ETH today",
+		"Don't cancel your subscription, big BTC move coming",
+	}
+	for _, text := range chatter {
+		if _, err := signal.ParseCommand(text); !errors.Is(err, exceptions.ErrNotACommand) {
+			t.Errorf("%q: expected ErrNotACommand, got %v", text, err)
+		}
+	}
+}
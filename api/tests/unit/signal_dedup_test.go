@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"copier/internal/signal"
+	"copier/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+func TestFingerprintIgnoresFormatting(t *testing.T) {
+	parser := signal.NewGenericParser()
+	channelID := uuid.New()
+
+	original, err := parser.Parse("🚀 #BTC LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	forwarded, err := parser.Parse("Forwarded from VIP\nBTC/USDT long\nentry 61,000 - 61,500\ntp1 62000\nstop loss 60000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := parser.Parse("#BTC LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 59500")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signal.Fingerprint(channelID, original) != signal.Fingerprint(channelID, forwarded) {
+		t.Error("reformatted repost produced a different fingerprint")
+	}
+	if signal.Fingerprint(channelID, original) == signal.Fingerprint(channelID, changed) {
+		t.Error("different stop loss produced the same fingerprint")
+	}
+	if signal.Fingerprint(channelID, original) == signal.Fingerprint(uuid.New(), original) {
+		t.Error("fingerprint is not scoped to the channel")
+	}
+}
+
+func TestDeduplicatorClaims(t *testing.T) {
+	ctx := context.Background()
+	d := signal.NewDeduplicator(cache.NewMemoryCache(), time.Minute, time.Hour)
+	channelID := uuid.New()
+
+	if first, err := d.ClaimMessage(ctx, channelID, "42"); err != nil || !first {
+		t.Fatalf("first claim = %v, %v", first, err)
+	}
+	if first, _ := d.ClaimMessage(ctx, channelID, "42"); first {
+		t.Error("redelivered message claimed twice")
+	}
+	if err := d.ReleaseMessage(ctx, channelID, "42"); err != nil {
+		t.Fatal(err)
+	}
+	if first, _ := d.ClaimMessage(ctx, channelID, "42"); !first {
+		t.Error("released message could not be claimed again")
+	}
+
+	if _, first, err := d.ClaimContent(ctx, "fp", "42"); err != nil || !first {
+		t.Fatalf("first content claim = %v, %v", first, err)
+	}
+	prior, first, err := d.ClaimContent(ctx, "fp", "43")
+	if err != nil || first || prior != "42" {
+		t.Errorf("duplicate content claim = %q, %v, %v; want prior 42", prior, first, err)
+	}
+	if err := d.ReleaseContent(ctx, "fp", "43"); err != nil {
+		t.Fatal(err)
+	}
+	if prior, first, _ := d.ClaimContent(ctx, "fp", "44"); first || prior != "42" {
+		t.Error("content claim released by a message that did not hold it")
+	}
+	if err := d.ReleaseContent(ctx, "fp", "42"); err != nil {
+		t.Fatal(err)
+	}
+	if _, first, _ := d.ClaimContent(ctx, "fp", "42"); !first {
+		t.Error("released content could not be claimed again")
+	}
+}
+
+func TestDeduplicatorWithoutCache(t *testing.T) {
+	d := signal.NewDeduplicator(nil, 0, 0)
+	if d.Window() != signal.DefaultDedupWindow {
+		t.Errorf("window = %v, want default", d.Window())
+	}
+	for range 2 {
+		if first, err := d.ClaimMessage(context.Background(), uuid.New(), "1"); err != nil || !first {
+			t.Errorf("claim without cache = %v, %v; want true", first, err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package unit
+
+import (
+	"testing"
+
+	"copier/internal/signal"
+)
+
+func TestDiffEditedSignal(t *testing.T) {
+	parser := signal.NewGenericParser()
+
+	original, err := parser.Parse("BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nTP2: 63000\nSL: 6000")
+	if err != nil {
+		t.Fatalf("parse original: %v", err)
+	}
+	edited, err := parser.Parse("BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nTP2: 63000\nSL: 60000")
+	if err != nil {
+		t.Fatalf("parse edit: %v", err)
+	}
+
+	changes := signal.Diff(original, edited)
+	if len(changes) != 1 || changes[0].Field != "stop_loss" {
+		t.Fatalf("changes = %+v, want a single stop_loss change", changes)
+	}
+
+	if changes := signal.Diff(original, original); len(changes) != 0 {
+		t.Errorf("identical signals produced changes: %+v", changes)
+	}
+}
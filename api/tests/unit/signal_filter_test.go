@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/services"
+	"copier/internal/signal"
+
+	"github.com/google/uuid"
+)
+
+func filterTestSignal(side models.SignalSide, stop *float64, targets ...float64) *models.Signal {
+	leverage := 20
+	return &models.Signal{
+		Symbol:    "BTCUSDT",
+		Side:      side,
+		EntryLow:  100,
+		EntryHigh: 100,
+		Targets:   targets,
+		StopLoss:  stop,
+		Leverage:  &leverage,
+	}
+}
+
+func TestApplyFilter(t *testing.T) {
+	stop := 95.0
+	long := filterTestSignal(models.SignalSideLong, &stop, 110, 120)
+
+	tests := []struct {
+		name   string
+		filter models.ChannelFilter
+		sig    *models.Signal
+		reject string
+	}{
+		{name: "empty filter copies everything", sig: long},
+		{name: "allow by base asset", filter: models.ChannelFilter{AllowSymbols: []string{"BTC"}}, sig: long},
+		{name: "not allowed", filter: models.ChannelFilter{AllowSymbols: []string{"ETHUSDT"}}, sig: long, reject: "allow list"},
+		{name: "deny wins over allow", filter: models.ChannelFilter{AllowSymbols: []string{"BTC"}, DenySymbols: []string{"btcusdt"}}, sig: long, reject: "deny list"},
+		{name: "quote asset", filter: models.ChannelFilter{QuoteAssets: []string{"USDC"}}, sig: long, reject: "not quoted"},
+		{name: "short only", filter: models.ChannelFilter{Side: models.SignalSideShort}, sig: long, reject: "short signals only"},
+		{name: "leverage cap", filter: models.ChannelFilter{MaxLeverage: 10}, sig: long, reject: "exceeds maximum 10x"},
+		{name: "risk reward met", filter: models.ChannelFilter{MinRiskReward: 2}, sig: long},
+		{name: "risk reward too low", filter: models.ChannelFilter{MinRiskReward: 2.5}, sig: long, reject: "below minimum"},
+		{name: "risk reward without stop", filter: models.ChannelFilter{MinRiskReward: 1}, sig: filterTestSignal(models.SignalSideLong, nil, 110), reject: "cannot be computed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := signal.ApplyFilter(tt.filter, tt.sig)
+			if tt.reject == "" && reason != "" {
+				t.Errorf("rejected: %s", reason)
+			}
+			if tt.reject != "" && !strings.Contains(reason, tt.reject) {
+				t.Errorf("reason = %q, want it to mention %q", reason, tt.reject)
+			}
+		})
+	}
+}
+
+func TestRiskRewardShort(t *testing.T) {
+	stop := 104.0
+	ratio, ok := signal.RiskReward(filterTestSignal(models.SignalSideShort, &stop, 90))
+	if !ok || ratio != 2.5 {
+		t.Errorf("RiskReward = %v, %v; want 2.5", ratio, ok)
+	}
+
+	// A stop on the wrong side of the entry gives no usable ratio
+	wrong := 90.0
+	if ratio, ok := signal.RiskReward(filterTestSignal(models.SignalSideShort, &wrong, 80)); !ok || ratio != 0 {
+		t.Errorf("RiskReward with misplaced stop = %v, %v; want 0", ratio, ok)
+	}
+}
+
+func TestCopyAfter(t *testing.T) {
+	posted := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if at := signal.CopyAfter(models.ChannelFilter{}, posted); at != nil {
+		t.Errorf("CopyAfter without delay = %v, want nil", at)
+	}
+	at := signal.CopyAfter(models.ChannelFilter{CopyDelaySeconds: 90}, posted)
+	if at == nil || !at.Equal(posted.Add(90*time.Second)) {
+		t.Errorf("CopyAfter = %v, want 90s after posting", at)
+	}
+}
+
+// updatingChannelRepo records the update a channel service stores
+type updatingChannelRepo struct {
+	repositories.ChannelRepository
+	stored *models.Channel
+}
+
+func (r *updatingChannelRepo) UpdateChannel(ctx context.Context, id uuid.UUID, update *models.Channel) error {
+	r.stored = update
+	return nil
+}
+
+func (r *updatingChannelRepo) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Channel, error) {
+	return r.stored, nil
+}
+
+func TestUpdateChannelNormalizesFilter(t *testing.T) {
+	repo := &updatingChannelRepo{}
+	service := services.NewChannelService(repo, nil)
+
+	update := &models.Channel{Filter: models.ChannelFilter{
+		AllowSymbols: []string{" btc ", "ethusdt"},
+		DenySymbols:  []string{"doge"},
+		QuoteAssets:  []string{"usdt"},
+	}}
+	channel, err := service.UpdateChannel(context.Background(), uuid.New(), update)
+	if err != nil {
+		t.Fatalf("update channel: %v", err)
+	}
+	filter := channel.Filter
+	if !slices.Equal(filter.AllowSymbols, []string{"BTC", "ETHUSDT"}) || !slices.Equal(filter.DenySymbols, []string{"DOGE"}) || !slices.Equal(filter.QuoteAssets, []string{"USDT"}) {
+		t.Errorf("expected upper-cased lists, got %+v", filter)
+	}
+	if reason := signal.ApplyFilter(filter, filterTestSignal(models.SignalSideLong, nil, 62000)); reason != "" {
+		t.Errorf("expected BTCUSDT allowed by the updated filter, got %q", reason)
+	}
+
+	if _, err := service.UpdateChannel(context.Background(), uuid.New(), &models.Channel{Name: "renamed"}); err != nil {
+		t.Fatalf("update channel: %v", err)
+	}
+	if repo.stored.Filter.AllowSymbols != nil || repo.stored.Filter.QuoteAssets != nil {
+		t.Errorf("expected an update without a filter to leave it unset, got %+v", repo.stored.Filter)
+	}
+}
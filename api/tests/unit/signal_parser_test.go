@@ -0,0 +1,195 @@
+package unit
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/shared/exceptions"
+	"copier/internal/signal"
+)
+
+func TestGenericParserFormats(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		symbol    string
+		side      models.SignalSide
+		entryLow  float64
+		entryHigh float64
+		targets   []float64
+		stopLoss  float64
+		leverage  int
+	}{
+		{
+			name:      "pair with entry range",
+			text:      "BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nTP2: 63000\nTP3: 64000\nSL: 60000\nLeverage: 10x",
+			symbol:    "BTCUSDT",
+			side:      models.SignalSideLong,
+			entryLow:  61000,
+			entryHigh: 61500,
+			targets:   []float64{62000, 63000, 64000},
+			stopLoss:  60000,
+			leverage:  10,
+		},
+		{
+			name:      "emojis and hashtags",
+			text:      "🚀 #ETH SHORT 🔻\n💰 Entry zone: 3,450 - 3,480\n🎯 Targets: 3400 - 3350 - 3300\n⛔️ Stop loss: 3,550\nCross 20x",
+			symbol:    "ETHUSDT",
+			side:      models.SignalSideShort,
+			entryLow:  3450,
+			entryHigh: 3480,
+			targets:   []float64{3400, 3350, 3300},
+			stopLoss:  3550,
+			leverage:  20,
+		},
+		{
+			name:      "inline numbered targets",
+			text:      "BTC/USDT LONG\nEntry: 61000\nTargets: 1) 62000 2) 63000 3. 64000.5\nSL: 60000",
+			symbol:    "BTCUSDT",
+			side:      models.SignalSideLong,
+			entryLow:  61000,
+			entryHigh: 61000,
+			targets:   []float64{62000, 63000, 64000.5},
+			stopLoss:  60000,
+		},
+		{
+			name:      "target block and percentages",
+			text:      "SOLUSDT Buy\nEntry 145.5\nTargets:\n1) 150 (30%)\n2) 155\n3) 160\nSL 140",
+			symbol:    "SOLUSDT",
+			side:      models.SignalSideLong,
+			entryLow:  145.5,
+			entryHigh: 145.5,
+			targets:   []float64{150, 155, 160},
+			stopLoss:  140,
+		},
+		{
+			name:      "tp without index",
+			text:      "#1000PEPE/USDT long\nbuy zone 0.0105 0.0110\ntp 0.0120\nstop 0.0099",
+			symbol:    "1000PEPEUSDT",
+			side:      models.SignalSideLong,
+			entryLow:  0.0105,
+			entryHigh: 0.0110,
+			targets:   []float64{0.012},
+			stopLoss:  0.0099,
+		},
+	}
+
+	parser := signal.NewGenericParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := parser.Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if sig.Symbol != tt.symbol {
+				t.Errorf("symbol = %q, want %q", sig.Symbol, tt.symbol)
+			}
+			if sig.Side != tt.side {
+				t.Errorf("side = %q, want %q", sig.Side, tt.side)
+			}
+			if sig.EntryLow != tt.entryLow || sig.EntryHigh != tt.entryHigh {
+				t.Errorf("entry = %v-%v, want %v-%v", sig.EntryLow, sig.EntryHigh, tt.entryLow, tt.entryHigh)
+			}
+			if !slices.Equal(sig.Targets, tt.targets) {
+				t.Errorf("targets = %v, want %v", sig.Targets, tt.targets)
+			}
+			if sig.StopLoss == nil || *sig.StopLoss != tt.stopLoss {
+				t.Errorf("stop loss = %v, want %v", sig.StopLoss, tt.stopLoss)
+			}
+			if tt.leverage == 0 && sig.Leverage != nil {
+				t.Errorf("leverage = %d, want none", *sig.Leverage)
+			}
+			if tt.leverage != 0 && (sig.Leverage == nil || *sig.Leverage != tt.leverage) {
+				t.Errorf("leverage = %v, want %d", sig.Leverage, tt.leverage)
+			}
+		})
+	}
+}
+
+func TestGenericParserRejectsNonSignals(t *testing.T) {
+	parser := signal.NewGenericParser()
+
+	if _, err := parser.Parse("Good morning traders, big day ahead!"); !errors.Is(err, exceptions.ErrNotASignal) {
+		t.Errorf("expected ErrNotASignal, got %v", err)
+	}
+	if _, err := parser.Parse("#BTC TP1 hit ✅"); !errors.Is(err, exceptions.ErrSignalMissingSide) {
+		t.Errorf("expected ErrSignalMissingSide, got %v", err)
+	}
+	if _, err := parser.Parse("BTC/USDT LONG soon"); !errors.Is(err, exceptions.ErrSignalMissingEntry) {
+		t.Errorf("expected ErrSignalMissingEntry, got %v", err)
+	}
+}
+
+func TestChainParserPrefersChannelRules(t *testing.T) {
+	rules := []models.ParserRule{
+		{
+			Name:     "vip-template",
+			Template: "{side} {symbol} @ {entry} targets {targets} stop {stop}",
+		},
+		{
+			Name:    "regex",
+			Pattern: `(?i)(?P<symbol>[A-Z]+) (?P<side>long|short) (?P<entry_low>[\d.]+)/(?P<entry_high>[\d.]+) tp1 (?P<tp1>[\d.]+) tp2 (?P<tp2>[\d.]+)`,
+		},
+		{
+			Name:     "header-only",
+			Template: "ALERT {symbol} {side} @ {entry}",
+		},
+	}
+
+	parser, err := signal.NewChainParser(rules)
+	if err != nil {
+		t.Fatalf("NewChainParser returned error: %v", err)
+	}
+
+	sig, err := parser.Parse("SHORT #SOL @ 150-152 targets 145, 140 stop 155")
+	if err != nil {
+		t.Fatalf("template rule failed: %v", err)
+	}
+	if sig.MatchedRule != "vip-template" || sig.Symbol != "SOLUSDT" || sig.Side != models.SignalSideShort {
+		t.Errorf("unexpected template result: rule=%s symbol=%s side=%s", sig.MatchedRule, sig.Symbol, sig.Side)
+	}
+	if !slices.Equal(sig.Targets, []float64{145, 140}) || sig.StopLoss == nil || *sig.StopLoss != 155 {
+		t.Errorf("unexpected template levels: targets=%v stop=%v", sig.Targets, sig.StopLoss)
+	}
+
+	sig, err = parser.Parse("ADA long 0.45/0.47 tp1 0.5 tp2 0.55")
+	if err != nil {
+		t.Fatalf("regex rule failed: %v", err)
+	}
+	if sig.MatchedRule != "regex" || sig.EntryLow != 0.45 || sig.EntryHigh != 0.47 || !slices.Equal(sig.Targets, []float64{0.5, 0.55}) {
+		t.Errorf("unexpected regex result: %+v", sig)
+	}
+
+	sig, err = parser.Parse("ALERT #ETH long @ 3000\nTargets:\n1) 3100\n2) 3200\nSL: 2900")
+	if err != nil {
+		t.Fatalf("rule with generic levels failed: %v", err)
+	}
+	if sig.MatchedRule != "header-only" || sig.EntryLow != 3000 || !slices.Equal(sig.Targets, []float64{3100, 3200}) || sig.StopLoss == nil || *sig.StopLoss != 2900 {
+		t.Errorf("expected targets and stop loss the rule does not capture extracted generically, got rule=%s targets=%v stop=%v", sig.MatchedRule, sig.Targets, sig.StopLoss)
+	}
+
+	sig, err = parser.Parse("BTC/USDT LONG\nEntry: 61000\nTP1: 62000\nSL: 60000")
+	if err != nil {
+		t.Fatalf("generic fallback failed: %v", err)
+	}
+	if sig.MatchedRule != signal.GenericRuleName {
+		t.Errorf("matched rule = %q, want %q", sig.MatchedRule, signal.GenericRuleName)
+	}
+}
+
+func TestValidateRulesRejectsBadRules(t *testing.T) {
+	bad := [][]models.ParserRule{
+		{{Name: "broken", Pattern: `(?P<symbol>[A-Z+`}},
+		{{Name: "unknown", Pattern: `(?P<coin>[A-Z]+)`}},
+		{{Name: "placeholder", Template: "{coin} {side}"}},
+		{{Name: "both", Pattern: `(?P<side>long)`, Template: "{side}"}},
+	}
+
+	for _, rules := range bad {
+		if err := signal.ValidateRules(rules); !errors.Is(err, exceptions.ErrInvalidParserRule) {
+			t.Errorf("rule %q: expected ErrInvalidParserRule, got %v", rules[0].Name, err)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"copier/database/repositories"
+	"copier/internal/database/models"
+	"copier/internal/services"
+	"copier/internal/signal"
+	"copier/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// signalStore backs the fake repositories the signal service reads and writes
+type signalStore struct {
+	signals []*models.Signal
+	actions []*models.SignalAction
+}
+
+func (s *signalStore) find(id uuid.UUID) *models.Signal {
+	for _, sig := range s.signals {
+		if sig.ID == id {
+			return sig
+		}
+	}
+	return nil
+}
+
+// ingestSignalRepo hands out copies of the stored signals, as loading them from the database does
+type ingestSignalRepo struct {
+	repositories.SignalRepository
+	store *signalStore
+}
+
+func (r *ingestSignalRepo) FindByIDTyped(ctx context.Context, id uuid.UUID) (*models.Signal, error) {
+	sig := r.store.find(id)
+	if sig == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	loaded := *sig
+	return &loaded, nil
+}
+
+func (r *ingestSignalRepo) FindByChannelAndMessage(ctx context.Context, channelID uuid.UUID, messageID string) (*models.Signal, error) {
+	for _, sig := range r.store.signals {
+		if sig.ChannelID == channelID && sig.MessageID == messageID {
+			loaded := *sig
+			return &loaded, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *ingestSignalRepo) AmendSignal(ctx context.Context, id uuid.UUID, from []models.SignalStatus, update *models.Signal, columns ...string) (bool, error) {
+	sig := r.store.find(id)
+	if sig == nil || !slices.Contains(from, sig.Status) {
+		return false, nil
+	}
+	sig.Symbol, sig.Side, sig.EntryLow, sig.EntryHigh = update.Symbol, update.Side, update.EntryLow, update.EntryHigh
+	sig.Targets, sig.StopLoss, sig.Leverage = update.Targets, update.StopLoss, update.Leverage
+	sig.RawText, sig.Fingerprint = update.RawText, update.Fingerprint
+	sig.Status, sig.StatusReason, sig.ExecuteAfter = update.Status, update.StatusReason, update.ExecuteAfter
+	return true, nil
+}
+
+func (r *ingestSignalRepo) HasMessage(ctx context.Context, channelID uuid.UUID, messageID string) (bool, error) {
+	for _, sig := range r.store.signals {
+		if sig.ChannelID == channelID && sig.MessageID == messageID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *ingestSignalRepo) TransitionStatus(ctx context.Context, id uuid.UUID, from []models.SignalStatus, to models.SignalStatus, reason string) (bool, error) {
+	sig := r.store.find(id)
+	if sig == nil || !slices.Contains(from, sig.Status) {
+		return false, nil
+	}
+	sig.Status, sig.StatusReason = to, reason
+	return true, nil
+}
+
+func (r *ingestSignalRepo) CreateAction(ctx context.Context, action *models.SignalAction) error {
+	r.store.actions = append(r.store.actions, action)
+	return nil
+}
+
+// ingestSymbols resolves symbols without aliases
+type ingestSymbols struct {
+	services.SymbolService
+}
+
+func (s *ingestSymbols) Table(ctx context.Context) (*signal.SymbolTable, error) {
+	return signal.NewSymbolTable(nil), nil
+}
+
+// newSignalHarness creates a signal service storing through fake repositories, with a long-only
+// channel whose message "1" produced a BTC signal of status
+func newSignalHarness(t *testing.T, status models.SignalStatus) (*signalStore, *models.Channel, *models.Signal, services.SignalService) {
+	t.Helper()
+	channel := &models.Channel{ID: uuid.New(), UserID: uuid.New(), Filter: models.ChannelFilter{Side: models.SignalSideLong}}
+
+	sig, err := signal.NewGenericParser().Parse("BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60000")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	sig.ID = uuid.New()
+	sig.UserID = channel.UserID
+	sig.ChannelID = channel.ID
+	sig.MessageID = "1"
+	sig.Status = status
+	sig.PostedAt = time.Now()
+	sig.Fingerprint = signal.Fingerprint(channel.ID, sig)
+
+	store := &signalStore{signals: []*models.Signal{sig}}
+	service := services.NewSignalService(&ingestSignalRepo{store: store}, nil, nil, &ingestSymbols{}, signal.NewDeduplicator(nil, 0, 0))
+	return store, channel, sig, service
+}
+
+func editMessage(text string) signal.Message {
+	return signal.Message{Kind: signal.MessageKindEdit, MessageID: "1", Text: text, PostedAt: time.Now()}
+}
+
+func TestEditAmendsNewSignal(t *testing.T) {
+	store, channel, sig, service := newSignalHarness(t, models.SignalStatusNew)
+	fingerprint := sig.Fingerprint
+
+	if _, err := service.IngestForChannel(context.Background(), channel, editMessage("BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60500")); err != nil {
+		t.Fatalf("ingest edit: %v", err)
+	}
+
+	if sig.StopLoss == nil || *sig.StopLoss != 60500 || sig.Status != models.SignalStatusNew {
+		t.Errorf("expected the new signal amended to SL 60500, got %v (%s)", sig.StopLoss, sig.Status)
+	}
+	if sig.Fingerprint == fingerprint || sig.Fingerprint != signal.Fingerprint(channel.ID, sig) {
+		t.Errorf("expected the fingerprint recomputed for the edited content")
+	}
+	if len(store.actions) != 1 || store.actions[0].Status != models.SignalActionStatusApplied {
+		t.Fatalf("expected one applied amend action, got %+v", store.actions)
+	}
+}
+
+func TestEditRunsChannelFilter(t *testing.T) {
+	store, channel, sig, service := newSignalHarness(t, models.SignalStatusNew)
+
+	if _, err := service.IngestForChannel(context.Background(), channel, editMessage("BTC/USDT SHORT\nEntry: 61000-61500\nTP1: 60000\nSL: 62000")); err != nil {
+		t.Fatalf("ingest edit: %v", err)
+	}
+
+	if sig.Status != models.SignalStatusFiltered || sig.StatusReason == "" {
+		t.Errorf("expected the edited short filtered from a long-only channel, got %s %q", sig.Status, sig.StatusReason)
+	}
+	if len(store.actions) != 1 || store.actions[0].Status != models.SignalActionStatusApplied {
+		t.Fatalf("expected one applied amend action, got %+v", store.actions)
+	}
+}
+
+func TestEditOfCopiedSignalIsSkipped(t *testing.T) {
+	for _, status := range []models.SignalStatus{models.SignalStatusActive, models.SignalStatusClosed} {
+		store, channel, sig, service := newSignalHarness(t, status)
+
+		if _, err := service.IngestForChannel(context.Background(), channel, editMessage("BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60500")); err != nil {
+			t.Fatalf("%s: ingest edit: %v", status, err)
+		}
+
+		if *sig.StopLoss != 60000 || sig.Status != status {
+			t.Errorf("%s: expected the signal left as it was, got SL %v (%s)", status, *sig.StopLoss, sig.Status)
+		}
+		if len(store.actions) != 1 || store.actions[0].Status != models.SignalActionStatusSkipped {
+			t.Fatalf("%s: expected one skipped amend action, got %+v", status, store.actions)
+		}
+	}
+}
+
+func TestEditLosesRaceWithExecution(t *testing.T) {
+	store, channel, sig, service := newSignalHarness(t, models.SignalStatusNew)
+	// the edit loads the signal while it is new, then the execution worker claims it
+	repo := &claimingSignalRepo{ingestSignalRepo: ingestSignalRepo{store: store}, claim: sig}
+	service = services.NewSignalService(repo, nil, nil, &ingestSymbols{}, signal.NewDeduplicator(nil, 0, 0))
+
+	if _, err := service.IngestForChannel(context.Background(), channel, editMessage("BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60500")); err != nil {
+		t.Fatalf("ingest edit: %v", err)
+	}
+
+	if *sig.StopLoss != 60000 || sig.Status != models.SignalStatusActive {
+		t.Errorf("expected the claimed signal left as it was, got SL %v (%s)", *sig.StopLoss, sig.Status)
+	}
+	if len(store.actions) != 1 || store.actions[0].Status != models.SignalActionStatusSkipped {
+		t.Fatalf("expected one skipped amend action, got %+v", store.actions)
+	}
+}
+
+// claimingSignalRepo marks claim active right after it is loaded, as the execution worker would
+type claimingSignalRepo struct {
+	ingestSignalRepo
+	claim *models.Signal
+}
+
+func (r *claimingSignalRepo) FindByChannelAndMessage(ctx context.Context, channelID uuid.UUID, messageID string) (*models.Signal, error) {
+	loaded, err := r.ingestSignalRepo.FindByChannelAndMessage(ctx, channelID, messageID)
+	r.claim.Status = models.SignalStatusActive
+	return loaded, err
+}
+
+// newSignalStoreRepo stores new signals, failing the first createFailures creations
+type newSignalStoreRepo struct {
+	ingestSignalRepo
+	createFailures int
+	findErr        error
+}
+
+func (r *newSignalStoreRepo) FindRecentByFingerprint(ctx context.Context, channelID uuid.UUID, fingerprint string, since, until time.Time) (*models.Signal, error) {
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	for _, sig := range r.store.signals {
+		if sig.ChannelID == channelID && sig.Fingerprint == fingerprint && sig.Status != models.SignalStatusSuppressed {
+			return sig, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *newSignalStoreRepo) CreateSignal(ctx context.Context, sig *models.Signal) error {
+	if r.createFailures > 0 {
+		r.createFailures--
+		return errors.New("connection reset")
+	}
+	r.store.signals = append(r.store.signals, sig)
+	return nil
+}
+
+func TestRedeliveryAfterFailedStoreIsNotDuplicate(t *testing.T) {
+	ctx := context.Background()
+	store := &signalStore{}
+	repo := &newSignalStoreRepo{ingestSignalRepo: ingestSignalRepo{store: store}, createFailures: 1}
+	dedup := signal.NewDeduplicator(cache.NewMemoryCache(), time.Minute, time.Hour)
+	service := services.NewSignalService(repo, nil, nil, &ingestSymbols{}, dedup)
+	channel := &models.Channel{ID: uuid.New(), UserID: uuid.New()}
+	msg := signal.Message{MessageID: "7", Text: "BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60000", PostedAt: time.Now()}
+
+	if _, err := service.IngestForChannel(ctx, channel, msg); err == nil {
+		t.Fatal("expected the failed store to be reported")
+	}
+	sig, err := service.IngestForChannel(ctx, channel, msg)
+	if err != nil {
+		t.Fatalf("redelivery: %v", err)
+	}
+	if sig.Status != models.SignalStatusNew {
+		t.Errorf("expected the redelivered signal stored as new, got %s %q", sig.Status, sig.StatusReason)
+	}
+}
+
+func TestFingerprintLookupErrorIsReturned(t *testing.T) {
+	store := &signalStore{}
+	lookup := errors.New("connection reset")
+	repo := &newSignalStoreRepo{ingestSignalRepo: ingestSignalRepo{store: store}, findErr: lookup}
+	service := services.NewSignalService(repo, nil, nil, &ingestSymbols{}, signal.NewDeduplicator(nil, 0, 0))
+	channel := &models.Channel{ID: uuid.New(), UserID: uuid.New()}
+	msg := signal.Message{MessageID: "7", Text: "BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60000", PostedAt: time.Now()}
+
+	if _, err := service.IngestForChannel(context.Background(), channel, msg); !errors.Is(err, lookup) {
+		t.Fatalf("expected the lookup error, got %v", err)
+	}
+	if len(store.signals) != 0 {
+		t.Errorf("expected no signal stored, got %d", len(store.signals))
+	}
+}
+
+func commandReply(text string) signal.Message {
+	return signal.Message{MessageID: "2", ReplyToID: "1", Text: text, PostedAt: time.Now()}
+}
+
+func TestCancelLosesRaceWithExecution(t *testing.T) {
+	store, channel, sig, _ := newSignalHarness(t, models.SignalStatusNew)
+	// the command loads the signal while it is new, then the execution worker claims it
+	repo := &claimingSignalRepo{ingestSignalRepo: ingestSignalRepo{store: store}, claim: sig}
+	service := services.NewSignalService(repo, nil, nil, &ingestSymbols{}, signal.NewDeduplicator(nil, 0, 0))
+
+	if _, err := service.IngestForChannel(context.Background(), channel, commandReply("Cancel #BTC signal")); err != nil {
+		t.Fatalf("ingest cancel: %v", err)
+	}
+
+	if sig.Status != models.SignalStatusActive {
+		t.Errorf("expected the claimed signal left active, got %s", sig.Status)
+	}
+	if len(store.actions) != 1 || store.actions[0].Status != models.SignalActionStatusPending {
+		t.Fatalf("expected one pending cancel action for the worker, got %+v", store.actions)
+	}
+}
+
+func TestCloseAllClosesOpenSignal(t *testing.T) {
+	for _, status := range models.OpenSignalStatuses {
+		store, channel, sig, service := newSignalHarness(t, status)
+
+		if _, err := service.IngestForChannel(context.Background(), channel, commandReply("BTC/USDT close all positions")); err != nil {
+			t.Fatalf("%s: ingest close: %v", status, err)
+		}
+
+		if sig.Status != models.SignalStatusClosed {
+			t.Errorf("%s: expected the signal closed, got %s", status, sig.Status)
+		}
+		if len(store.actions) != 1 || store.actions[0].Type != models.SignalActionCloseAll {
+			t.Fatalf("%s: expected one close action, got %+v", status, store.actions)
+		}
+	}
+}
+
+func TestEditTurnsNonSignalPostIntoSignal(t *testing.T) {
+	ctx := context.Background()
+	store := &signalStore{}
+	repo := &newSignalStoreRepo{ingestSignalRepo: ingestSignalRepo{store: store}}
+	dedup := signal.NewDeduplicator(cache.NewMemoryCache(), time.Minute, time.Hour)
+	service := services.NewSignalService(repo, nil, nil, &ingestSymbols{}, dedup)
+	channel := &models.Channel{ID: uuid.New(), UserID: uuid.New()}
+
+	post := signal.Message{MessageID: "9", Text: "Good morning traders, setup coming soon", PostedAt: time.Now()}
+	if _, err := service.IngestForChannel(ctx, channel, post); !services.IsParseError(err) {
+		t.Fatalf("expected the post rejected as not a signal, got %v", err)
+	}
+
+	edit := signal.Message{Kind: signal.MessageKindEdit, MessageID: "9", Text: "BTC/USDT LONG\nEntry: 61000-61500\nTP1: 62000\nSL: 60000", PostedAt: time.Now()}
+	sig, err := service.IngestForChannel(ctx, channel, edit)
+	if err != nil {
+		t.Fatalf("ingest edit: %v", err)
+	}
+	if sig.Status != models.SignalStatusNew || len(store.signals) != 1 {
+		t.Errorf("expected the edit stored as a new signal, got %s with %d stored", sig.Status, len(store.signals))
+	}
+}
+
+func (r *ingestSignalRepo) FindLatestOpenBySymbol(ctx context.Context, channelID uuid.UUID, symbol string, historical bool) (*models.Signal, error) {
+	for _, sig := range slices.Backward(r.store.signals) {
+		if sig.ChannelID == channelID && sig.Symbol == symbol && slices.Contains(models.OpenSignalStatuses, sig.Status) {
+			loaded := *sig
+			return &loaded, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestChatterDoesNotCloseOpenSignal(t *testing.T) {
+	store, channel, sig, service := newSignalHarness(t, models.SignalStatusActive)
+
+	for i, text := range []string{"BTC is close to breakout", "This BTC idea is invalid below 60000"} {
+		msg := signal.Message{MessageID: fmt.Sprint(10 + i), Text: text, PostedAt: time.Now()}
+		if _, err := service.IngestForChannel(context.Background(), channel, msg); !services.IsParseError(err) {
+			t.Errorf("%q: expected the message ignored, got %v", text, err)
+		}
+	}
+
+	if sig.Status != models.SignalStatusActive || len(store.actions) != 0 {
+		t.Errorf("expected the open signal untouched, got %s with %d actions", sig.Status, len(store.actions))
+	}
+}
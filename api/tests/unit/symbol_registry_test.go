@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/signal"
+)
+
+func symbolTestTable() *signal.SymbolTable {
+	return signal.NewSymbolTable([]*models.SymbolAlias{
+		{Alias: "BITCOIN", Symbol: "BTCUSDT", Multiplier: 1},
+		{Exchange: models.ExchangeBinanceFutures, Alias: "PEPEUSDT", Symbol: "1000PEPEUSDT", Multiplier: 1000},
+	})
+}
+
+func TestNormalizeAlias(t *testing.T) {
+	for raw, want := range map[string]string{
+		"#btc":      "BTC",
+		"BTC/USDT":  "BTCUSDT",
+		"BTC-PERP":  "BTC",
+		"BTCUSDT.P": "BTCUSDT",
+		"eth_usdt":  "ETHUSDT",
+		"$Bitcoin":  "BITCOIN",
+	} {
+		if got := signal.NormalizeAlias(raw); got != want {
+			t.Errorf("NormalizeAlias(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestSymbolTableCanonical(t *testing.T) {
+	table := symbolTestTable()
+
+	tests := []struct {
+		raw   string
+		want  string
+		known bool
+	}{
+		{"#BTC", "BTCUSDT", false},
+		{"BTC/USDT", "BTCUSDT", false},
+		{"BTC-PERP", "BTCUSDT", false},
+		{"Bitcoin", "BTCUSDT", true},
+		{"1000PEPE", "1000PEPEUSDT", false},
+		{"ETHUSDC", "ETHUSDC", false},
+	}
+	for _, tt := range tests {
+		got, known := table.Canonical(tt.raw)
+		if got != tt.want || known != tt.known {
+			t.Errorf("Canonical(%q) = %q, %v; want %q, %v", tt.raw, got, known, tt.want, tt.known)
+		}
+	}
+}
+
+func TestSymbolTableForExchange(t *testing.T) {
+	table := symbolTestTable()
+
+	got := table.ForExchange(models.ExchangeBinanceFutures, "#PEPE")
+	if got.Symbol != "1000PEPEUSDT" || got.Multiplier != 1000 {
+		t.Errorf("ForExchange(PEPE) = %+v, want 1000PEPEUSDT x1000", got)
+	}
+
+	got = table.ForExchange(models.ExchangeBinanceFutures, "Bitcoin")
+	if got.Symbol != "BTCUSDT" || got.Multiplier != 1 {
+		t.Errorf("ForExchange(Bitcoin) = %+v, want BTCUSDT x1", got)
+	}
+}
+
+func TestWithSymbolsRecognisesAliasWords(t *testing.T) {
+	parser := signal.WithSymbols(signal.NewGenericParser(), symbolTestTable())
+
+	sig, err := parser.Parse("Bitcoin long\nEntry: 61000\nSL: 60000")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if sig.Symbol != "BTCUSDT" {
+		t.Errorf("symbol = %q, want BTCUSDT", sig.Symbol)
+	}
+	if sig.RawText != "Bitcoin long\nEntry: 61000\nSL: 60000" {
+		t.Errorf("raw text was rewritten: %q", sig.RawText)
+	}
+}
@@ -0,0 +1,162 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/exchange"
+	"copier/internal/execution"
+	"copier/internal/services"
+	"copier/internal/shared/exceptions"
+	"copier/pkg/cache"
+)
+
+var btcRules = &exchange.SymbolInfo{
+	Symbol:      "BTCUSDT",
+	TickSize:    0.1,
+	StepSize:    0.001,
+	MinQty:      0.001,
+	MaxQty:      1000,
+	MinNotional: 100,
+}
+
+func TestRoundingToSymbolRules(t *testing.T) {
+	cases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"quantity rounds down", execution.RoundDown(0.12345, 0.001), 0.123},
+		{"exact step is kept", execution.RoundDown(0.3, 0.1), 0.3},
+		{"whole step", execution.RoundDown(17.9, 1), 17},
+		{"no step", execution.RoundDown(0.12345, 0), 0.12345},
+		{"price rounds to nearest tick", execution.RoundToTick(64123.46, 0.1), 64123.5},
+		{"coarse tick", execution.RoundToTick(1.23456, 0.0005), 1.2345},
+	}
+
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestApplySymbolRulesRoundsPlan(t *testing.T) {
+	stop := 59000.04
+	sig := &models.Signal{
+		Symbol:    "BTCUSDT",
+		Side:      models.SignalSideLong,
+		EntryLow:  60000.03,
+		EntryHigh: 60000.03,
+		StopLoss:  &stop,
+		Targets:   []float64{61000.06, 62000.01, 63000.09},
+	}
+	settings := &models.TradeSettings{
+		PerTradeAmount:   1000,
+		StopLossStatus:   true,
+		TakeProfitStatus: true,
+		TakeProfitStep:   3,
+		TPPercentage:     []float64{50, 30, 20},
+	}
+
+	plan := execution.PlanOrders(sig, settings, execution.Account{})
+	if err := execution.ApplySymbolRules(plan, btcRules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entry := plan.Orders[0]
+	if entry.Quantity != 0.016 || entry.Price != 60000 {
+		t.Errorf("expected entry of 0.016 at 60000, got %v at %v", entry.Quantity, entry.Price)
+	}
+	if sl := plan.Orders[1]; sl.Quantity != entry.Quantity || sl.Price != 59000 {
+		t.Errorf("expected stop of the entry quantity at 59000, got %v at %v", sl.Quantity, sl.Price)
+	}
+
+	total := 0.0
+	for _, order := range plan.Orders[2:] {
+		total += order.Quantity
+	}
+	if len(plan.Orders) != 5 || total < entry.Quantity-1e-9 || total > entry.Quantity+1e-9 {
+		t.Errorf("expected three targets adding up to the entry, got %+v", plan.Orders[2:])
+	}
+}
+
+func TestApplySymbolRulesMergesTinyTargets(t *testing.T) {
+	sig := &models.Signal{Symbol: "BTCUSDT", Side: models.SignalSideLong, EntryLow: 50000, EntryHigh: 50000, Targets: []float64{51000, 52000, 53000}}
+	settings := &models.TradeSettings{PerTradeAmount: 100, TakeProfitStatus: true, TakeProfitStep: 3, TPPercentage: []float64{50, 30, 20}}
+
+	plan := execution.PlanOrders(sig, settings, execution.Account{})
+	if err := execution.ApplySymbolRules(plan, btcRules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 0.002 cannot be split 50/30/20 in steps of 0.001 without a zero leg
+	targets := plan.Orders[1:]
+	if len(targets) != 2 || targets[0].Quantity != 0.001 || targets[1].Quantity != 0.001 {
+		t.Errorf("expected TP3 merged into TP2, got %+v", targets)
+	}
+}
+
+func TestApplySymbolRulesRejectsBelowMinimum(t *testing.T) {
+	sig := &models.Signal{Symbol: "BTCUSDT", Side: models.SignalSideLong, EntryLow: 60000, EntryHigh: 60000}
+
+	cases := []struct {
+		name   string
+		amount float64
+	}{
+		{"below min notional", 90},
+		{"below min quantity", 30},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := execution.PlanOrders(sig, &models.TradeSettings{PerTradeAmount: tc.amount}, execution.Account{})
+			if err := execution.ApplySymbolRules(plan, btcRules); !errors.Is(err, exceptions.ErrBelowMinimum) {
+				t.Errorf("expected ErrBelowMinimum, got %v", err)
+			}
+		})
+	}
+
+	// reduce-only orders only need the minimum quantity
+	if err := execution.CheckMinimums(btcRules, 0.001, 60000, true); err != nil {
+		t.Errorf("expected a small reduce-only order to pass, got %v", err)
+	}
+}
+
+// countingSymbolInfo counts how often trading rules are loaded from the exchange
+type countingSymbolInfo struct {
+	*exchange.PaperExchange
+	loads int
+}
+
+func (c *countingSymbolInfo) SymbolInfo(ctx context.Context, symbol string) (*exchange.SymbolInfo, error) {
+	c.loads++
+	return c.PaperExchange.SymbolInfo(ctx, symbol)
+}
+
+func TestSymbolInfoServiceCaches(t *testing.T) {
+	ctx := context.Background()
+	client := &countingSymbolInfo{PaperExchange: exchange.NewPaperExchange(exchange.PaperConfig{
+		Symbols: map[string]exchange.SymbolInfo{"BTCUSDT": *btcRules},
+	})}
+	service := services.NewSymbolInfoService(cache.NewMemoryCache(), 0)
+
+	for range 3 {
+		info, err := service.Info(ctx, client, "btcusdt")
+		if err != nil {
+			t.Fatalf("info: %v", err)
+		}
+		if info.StepSize != btcRules.StepSize || info.MinNotional != btcRules.MinNotional {
+			t.Errorf("unexpected rules %+v", info)
+		}
+	}
+	if client.loads != 1 {
+		t.Errorf("expected one load from the exchange, got %d", client.loads)
+	}
+
+	service.Invalidate(ctx, client, "BTCUSDT")
+	if _, err := service.Info(ctx, client, "BTCUSDT"); err != nil || client.loads != 2 {
+		t.Errorf("expected a reload after invalidation, got %d loads (%v)", client.loads, err)
+	}
+}
@@ -0,0 +1,97 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+
+	"copier/internal/database/models"
+	"copier/internal/execution"
+	"copier/internal/shared/exceptions"
+)
+
+func TestValidateTakeProfitLadder(t *testing.T) {
+	cases := []struct {
+		name        string
+		step        int
+		percentages []float64
+		valid       bool
+	}{
+		{"valid ladder", 3, []float64{50, 30, 20}, true},
+		{"count differs from step", 3, []float64{50, 50}, false},
+		{"does not sum to 100", 2, []float64{50, 30}, false},
+		{"zero leg", 2, []float64{100, 0}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := execution.ValidateTakeProfitLadder(tc.step, tc.percentages)
+			if tc.valid != (err == nil) {
+				t.Fatalf("expected valid=%v, got %v", tc.valid, err)
+			}
+			if err != nil && !errors.Is(err, exceptions.ErrInvalidTakeProfit) {
+				t.Errorf("expected ErrInvalidTakeProfit, got %v", err)
+			}
+		})
+	}
+
+	conflicting := models.StopRules{TrailToPreviousTP: true, TrailingStopPercentage: 2}
+	if err := execution.ValidateStopRules(conflicting); !errors.Is(err, exceptions.ErrInvalidStopRules) {
+		t.Errorf("expected conflicting stop rules to be rejected, got %v", err)
+	}
+}
+
+func TestLadderPercentagesFoldMissingTargets(t *testing.T) {
+	settings := &models.TradeSettings{TakeProfitStep: 3, TPPercentage: []float64{50, 30, 20}}
+
+	ladder := execution.LadderPercentages(settings, 2)
+	if len(ladder) != 2 || ladder[0] != 50 || ladder[1] != 50 {
+		t.Errorf("expected the missing TP3 share on TP2, got %v", ladder)
+	}
+	if ladder := execution.LadderPercentages(settings, 5); len(ladder) != 3 {
+		t.Errorf("expected one leg per step, got %v", ladder)
+	}
+}
+
+func TestStopFollowsTargets(t *testing.T) {
+	targets := []float64{110, 120, 130}
+	pos := &models.Position{
+		Side:       models.SignalSideLong,
+		EntryPrice: 100,
+		StopPrice:  90,
+		StopRules:  models.StopRules{TrailToPreviousTP: true},
+	}
+
+	if stop, ok := execution.StopAfterTarget(pos, targets, 1); !ok || stop != 100 {
+		t.Errorf("expected break-even after TP1, got %v %v", stop, ok)
+	}
+	pos.StopPrice = 100
+	if stop, ok := execution.StopAfterTarget(pos, targets, 2); !ok || stop != 110 {
+		t.Errorf("expected stop at TP1 after TP2, got %v %v", stop, ok)
+	}
+
+	pos.StopRules = models.StopRules{BreakEvenAfterTP1: true}
+	if _, ok := execution.StopAfterTarget(pos, targets, 2); ok {
+		t.Error("expected break-even rule to leave a stop already at entry")
+	}
+}
+
+func TestTrailingStopFollowsBestPrice(t *testing.T) {
+	pos := &models.Position{
+		Side:      models.SignalSideShort,
+		StopPrice: 110,
+		StopRules: models.StopRules{TrailingStopPercentage: 2},
+	}
+
+	peak, stop, moved := execution.TrailingStop(pos, 100)
+	if !moved || peak != 100 || stop != 102 {
+		t.Fatalf("expected stop 2%% above 100, got peak %v stop %v moved %v", peak, stop, moved)
+	}
+	pos.PeakPrice, pos.StopPrice = peak, stop
+
+	if _, _, moved := execution.TrailingStop(pos, 101); moved {
+		t.Error("expected an adverse move to leave the stop")
+	}
+	if peak, _, moved := execution.TrailingStop(pos, 99.9); moved || peak != 99.9 {
+		t.Errorf("expected a small improvement to only raise the peak, got peak %v moved %v", peak, moved)
+	}
+}
@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"copier/internal/ingest"
+)
+
+const telegramExport = `{
+  "name": "Crypto Signals VIP",
+  "type": "public_channel",
+  "id": 1234567890,
+  "messages": [
+    {"id": 1, "type": "service", "date": "2024-05-01T09:00:00", "action": "create_channel", "text": ""},
+    {"id": 2, "type": "message", "date": "2024-05-01T10:00:00", "date_unixtime": "1714557600",
+     "text": [{"type": "hashtag", "text": "#BTC"}, " LONG\nEntry: 61000-61500\nSL: 60000"]},
+    {"id": 3, "type": "message", "date": "2024-05-01T12:30:00", "reply_to_message_id": 2, "text": "TP1 hit"},
+    {"id": 4, "type": "message", "date": "2024-05-01T13:00:00", "photo": "photos/1.jpg", "text": ""}
+  ]
+}`
+
+func TestParseTelegramExport(t *testing.T) {
+	export, err := ingest.ParseTelegramExport(strings.NewReader(telegramExport))
+	if err != nil {
+		t.Fatalf("ParseTelegramExport: %v", err)
+	}
+
+	msgs := export.SignalMessages("-1001234567890")
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2: %+v", len(msgs), msgs)
+	}
+
+	first := msgs[0]
+	if first.MessageID != "2" || first.Text != "#BTC LONG\nEntry: 61000-61500\nSL: 60000" {
+		t.Errorf("first message = %+v", first)
+	}
+	if !first.PostedAt.Equal(time.Unix(1714557600, 0)) {
+		t.Errorf("posted_at = %v, want unix 1714557600", first.PostedAt)
+	}
+	if !first.Historical || first.ChannelID != "-1001234567890" {
+		t.Errorf("first message not tagged as channel history: %+v", first)
+	}
+
+	reply := msgs[1]
+	if reply.ReplyToID != "2" {
+		t.Errorf("reply_to_id = %q, want 2", reply.ReplyToID)
+	}
+	if want := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC); !reply.PostedAt.Equal(want) {
+		t.Errorf("posted_at = %v, want %v", reply.PostedAt, want)
+	}
+}
+
+func TestParseTelegramExportRejectsInvalidJSON(t *testing.T) {
+	if _, err := ingest.ParseTelegramExport(strings.NewReader("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}